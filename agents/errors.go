@@ -1,6 +1,12 @@
 package agents
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tmc/langchaingo/schema"
+)
 
 var (
 	// ErrExecutorInputNotString is returned if an input to the executor call function is not a string.
@@ -38,3 +44,52 @@ func NewParserErrorHandler(formatFunc func(string) string) *ParserErrorHandler {
 		Formatter: formatFunc,
 	}
 }
+
+// ToolErrorHandler is the struct used to handle errors returned by tools in the executor. Without
+// a ToolErrorHandler a tool error aborts the run. With one, failed tool calls are first retried
+// MaxRetries times. If the call still fails and Formatter is set, the formatted error is added as
+// the observation of the action, so the agent can react to it in the next step. Otherwise the run
+// is aborted with the error. Errors caused by the context being canceled are never handled.
+type ToolErrorHandler struct {
+	// MaxRetries is the number of times a failed tool call is retried.
+	MaxRetries int
+	// Backoff returns how long to wait before the given retry, starting at 1. If nil
+	// the wait starts at 100ms and doubles with each retry.
+	Backoff func(retry int) time.Duration
+	// Formatter formats the error of a tool as an observation. If nil the error aborts the run.
+	Formatter func(action schema.AgentAction, err error) string
+}
+
+// NewToolErrorHandler creates a tool error handler that gives tool errors to the agent as
+// observations. If formatFunc is nil, DefaultToolErrorFormatter is used.
+func NewToolErrorHandler(formatFunc func(schema.AgentAction, error) string) *ToolErrorHandler {
+	if formatFunc == nil {
+		formatFunc = DefaultToolErrorFormatter
+	}
+	return &ToolErrorHandler{
+		Formatter: formatFunc,
+	}
+}
+
+// NewRetryToolErrorHandler creates a tool error handler that retries failed tool calls up to
+// maxRetries times, waiting according to backoff between attempts, and aborts the run if the
+// tool keeps failing.
+func NewRetryToolErrorHandler(maxRetries int, backoff func(retry int) time.Duration) *ToolErrorHandler {
+	return &ToolErrorHandler{
+		MaxRetries: maxRetries,
+		Backoff:    backoff,
+	}
+}
+
+// DefaultToolErrorFormatter formats a tool error as an observation telling the agent the
+// tool failed.
+func DefaultToolErrorFormatter(action schema.AgentAction, err error) string {
+	return fmt.Sprintf("%s returned an error: %s", action.Tool, err)
+}
+
+func (h *ToolErrorHandler) backoff(retry int) time.Duration {
+	if h.Backoff != nil {
+		return h.Backoff(retry)
+	}
+	return _defaultToolRetryBackoff << (retry - 1)
+}
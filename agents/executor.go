@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/tmc/langchaingo/callbacks"
 	"github.com/tmc/langchaingo/chains"
@@ -12,7 +14,10 @@ import (
 	"github.com/tmc/langchaingo/tools"
 )
 
-const _intermediateStepsOutputKey = "intermediateSteps"
+const (
+	_intermediateStepsOutputKey = "intermediateSteps"
+	_defaultToolRetryBackoff    = 100 * time.Millisecond
+)
 
 // Executor is the chain responsible for running agents.
 type Executor struct {
@@ -20,9 +25,14 @@ type Executor struct {
 	Memory           schema.Memory
 	CallbacksHandler callbacks.Handler
 	ErrorHandler     *ParserErrorHandler
+	ToolErrorHandler *ToolErrorHandler
 
 	MaxIterations           int
 	ReturnIntermediateSteps bool
+	// MaxConcurrentActions is the maximum number of actions from a single
+	// plan that are run at the same time. Values below 2 run the actions
+	// sequentially.
+	MaxConcurrentActions int
 }
 
 var (
@@ -44,6 +54,8 @@ func NewExecutor(agent Agent, opts ...Option) *Executor {
 		ReturnIntermediateSteps: options.returnIntermediateSteps,
 		CallbacksHandler:        options.callbacksHandler,
 		ErrorHandler:            options.errorHandler,
+		ToolErrorHandler:        options.toolErrorHandler,
+		MaxConcurrentActions:    options.maxConcurrentActions,
 	}
 }
 
@@ -106,6 +118,14 @@ func (e *Executor) doIteration( // nolint
 		return steps, e.getReturn(finish, steps), nil
 	}
 
+	if e.MaxConcurrentActions > 1 && len(actions) > 1 {
+		newSteps, err := e.doActionsConcurrently(ctx, nameToTool, actions)
+		if err != nil {
+			return steps, nil, err
+		}
+		return append(steps, newSteps...), nil, nil
+	}
+
 	for _, action := range actions {
 		steps, err = e.doAction(ctx, steps, nameToTool, action)
 		if err != nil {
@@ -122,27 +142,130 @@ func (e *Executor) doAction(
 	nameToTool map[string]tools.Tool,
 	action schema.AgentAction,
 ) ([]schema.AgentStep, error) {
+	step, err := e.runAction(ctx, nameToTool, action)
+	if err != nil {
+		return nil, err
+	}
+	return append(steps, step), nil
+}
+
+// doActionsConcurrently runs the actions with at most MaxConcurrentActions
+// running at once and returns their steps in the order of actions. The first
+// error cancels the actions still running or waiting to run.
+func (e *Executor) doActionsConcurrently(
+	ctx context.Context,
+	nameToTool map[string]tools.Tool,
+	actions []schema.AgentAction,
+) ([]schema.AgentStep, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	steps := make([]schema.AgentStep, len(actions))
+	sem := make(chan struct{}, e.MaxConcurrentActions)
+
+	for i, action := range actions {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(i int, action schema.AgentAction) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			step, err := e.runAction(ctx, nameToTool, action)
+			if err != nil {
+				errOnce.Do(func() {
+					firstErr = err
+					cancel()
+				})
+				return
+			}
+			steps[i] = step
+		}(i, action)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return steps, nil
+}
+
+// runAction calls the tool of the action and returns the resulting step.
+func (e *Executor) runAction(
+	ctx context.Context,
+	nameToTool map[string]tools.Tool,
+	action schema.AgentAction,
+) (schema.AgentStep, error) {
 	if e.CallbacksHandler != nil {
 		e.CallbacksHandler.HandleAgentAction(ctx, action)
 	}
 
 	tool, ok := nameToTool[strings.ToUpper(action.Tool)]
 	if !ok {
-		return append(steps, schema.AgentStep{
+		return schema.AgentStep{
 			Action:      action,
 			Observation: fmt.Sprintf("%s is not a valid tool, try another one", action.Tool),
-		}), nil
+		}, nil
 	}
 
-	observation, err := tool.Call(ctx, action.ToolInput)
+	observation, err := e.callTool(ctx, tool, action)
+	if errors.Is(err, tools.ErrInvalidArguments) {
+		// Let the agent fix the arguments in the next step.
+		return schema.AgentStep{
+			Action:      action,
+			Observation: err.Error(),
+		}, nil
+	}
 	if err != nil {
-		return nil, err
+		if e.ToolErrorHandler == nil || e.ToolErrorHandler.Formatter == nil || ctx.Err() != nil {
+			return schema.AgentStep{}, err
+		}
+		observation = e.ToolErrorHandler.Formatter(action, err)
 	}
 
-	return append(steps, schema.AgentStep{
+	return schema.AgentStep{
 		Action:      action,
 		Observation: observation,
-	}), nil
+	}, nil
+}
+
+// callTool calls the tool with the input of the action, retrying failed calls as
+// configured by the tool error handler.
+func (e *Executor) callTool(ctx context.Context, tool tools.Tool, action schema.AgentAction) (string, error) {
+	observation, err := tool.Call(ctx, action.ToolInput)
+	if err == nil || e.ToolErrorHandler == nil || errors.Is(err, tools.ErrInvalidArguments) {
+		return observation, err
+	}
+
+	for retry := 1; retry <= e.ToolErrorHandler.MaxRetries && ctx.Err() == nil; retry++ {
+		timer := time.NewTimer(e.ToolErrorHandler.backoff(retry))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return "", ctx.Err()
+		case <-timer.C:
+		}
+
+		observation, err = tool.Call(ctx, action.ToolInput)
+		if err == nil {
+			return observation, nil
+		}
+	}
+	return "", err
 }
 
 func (e *Executor) getReturn(finish *schema.AgentFinish, steps []schema.AgentStep) map[string]any {
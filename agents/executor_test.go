@@ -2,9 +2,12 @@ package agents_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/tmc/langchaingo/agents"
@@ -73,6 +76,231 @@ func TestExecutorWithErrorHandler(t *testing.T) {
 	}, a.recordedIntermediateSteps)
 }
 
+// toolsAgent plans the given actions once and then finishes.
+type toolsAgent struct {
+	testAgent
+	tools []tools.Tool
+}
+
+func (a *toolsAgent) Plan(
+	ctx context.Context,
+	intermediateSteps []schema.AgentStep,
+	inputs map[string]string,
+) ([]schema.AgentAction, *schema.AgentFinish, error) {
+	if len(intermediateSteps) > 0 {
+		return nil, &schema.AgentFinish{ReturnValues: map[string]any{"output": "done"}}, nil
+	}
+	return a.testAgent.Plan(ctx, intermediateSteps, inputs)
+}
+
+func (a *toolsAgent) GetTools() []tools.Tool {
+	return a.tools
+}
+
+// blockingTool waits until release is closed, so calls only complete if they
+// run concurrently.
+type blockingTool struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (t blockingTool) Name() string        { return "block" }
+func (t blockingTool) Description() string { return "blocks until released" }
+
+func (t blockingTool) Call(ctx context.Context, input string) (string, error) {
+	if input == "fail" {
+		return "", errors.New("tool failed")
+	}
+	t.started <- struct{}{}
+	select {
+	case <-t.release:
+		return "echo " + input, nil
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+func TestExecutorConcurrentActions(t *testing.T) {
+	t.Parallel()
+
+	tool := blockingTool{started: make(chan struct{}), release: make(chan struct{})}
+	actions := make([]schema.AgentAction, 3)
+	for i := range actions {
+		actions[i] = schema.AgentAction{Tool: "block", ToolInput: fmt.Sprint(i)}
+	}
+	a := &toolsAgent{testAgent: testAgent{actions: actions}, tools: []tools.Tool{tool}}
+	executor := agents.NewExecutor(
+		a,
+		agents.WithMaxConcurrentActions(3),
+		agents.WithReturnIntermediateSteps(),
+	)
+
+	go func() {
+		for range actions {
+			<-tool.started
+		}
+		close(tool.release)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	result, err := chains.Call(ctx, executor, nil)
+	require.NoError(t, err)
+	require.Equal(t, []schema.AgentStep{
+		{Action: actions[0], Observation: "echo 0"},
+		{Action: actions[1], Observation: "echo 1"},
+		{Action: actions[2], Observation: "echo 2"},
+	}, result["intermediateSteps"])
+}
+
+func TestExecutorConcurrentActionsError(t *testing.T) {
+	t.Parallel()
+
+	tool := blockingTool{started: make(chan struct{}, 2), release: make(chan struct{})}
+	a := &toolsAgent{
+		testAgent: testAgent{actions: []schema.AgentAction{
+			{Tool: "block", ToolInput: "0"},
+			{Tool: "block", ToolInput: "fail"},
+			{Tool: "block", ToolInput: "2"},
+		}},
+		tools: []tools.Tool{tool},
+	}
+	executor := agents.NewExecutor(a, agents.WithMaxConcurrentActions(2))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err := chains.Call(ctx, executor, nil)
+	require.EqualError(t, err, "tool failed")
+	require.NoError(t, ctx.Err())
+}
+
+// flakyTool fails the first failures calls.
+type flakyTool struct {
+	failures int
+	calls    int
+}
+
+func (t *flakyTool) Name() string        { return "flaky" }
+func (t *flakyTool) Description() string { return "fails a few times" }
+
+func (t *flakyTool) Call(_ context.Context, input string) (string, error) {
+	t.calls++
+	if t.calls <= t.failures {
+		return "", fmt.Errorf("failure %d", t.calls)
+	}
+	return "echo " + input, nil
+}
+
+func TestExecutorWithToolErrorHandler(t *testing.T) {
+	t.Parallel()
+
+	action := schema.AgentAction{Tool: "flaky", ToolInput: "x"}
+	noWait := func(int) time.Duration { return 0 }
+	testCases := []struct {
+		name        string
+		handler     *agents.ToolErrorHandler
+		failures    int
+		calls       int
+		observation string
+		err         string
+	}{
+		{
+			name:     "abort",
+			failures: 1,
+			calls:    1,
+			err:      "failure 1",
+		},
+		{
+			name:        "observe",
+			handler:     agents.NewToolErrorHandler(nil),
+			failures:    1,
+			calls:       1,
+			observation: "flaky returned an error: failure 1",
+		},
+		{
+			name:        "retry",
+			handler:     agents.NewRetryToolErrorHandler(2, noWait),
+			failures:    2,
+			calls:       3,
+			observation: "echo x",
+		},
+		{
+			name:     "retries exhausted",
+			handler:  agents.NewRetryToolErrorHandler(2, noWait),
+			failures: 3,
+			calls:    3,
+			err:      "failure 3",
+		},
+		{
+			name: "retry then observe",
+			handler: &agents.ToolErrorHandler{
+				MaxRetries: 1,
+				Backoff:    noWait,
+				Formatter:  func(_ schema.AgentAction, err error) string { return "oops: " + err.Error() },
+			},
+			failures:    3,
+			calls:       2,
+			observation: "oops: failure 2",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tool := &flakyTool{failures: tc.failures}
+			a := &toolsAgent{
+				testAgent: testAgent{actions: []schema.AgentAction{action}},
+				tools:     []tools.Tool{tool},
+			}
+			executor := agents.NewExecutor(
+				a,
+				agents.WithToolErrorHandler(tc.handler),
+				agents.WithReturnIntermediateSteps(),
+			)
+
+			result, err := chains.Call(context.Background(), executor, nil)
+			require.Equal(t, tc.calls, tool.calls)
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, []schema.AgentStep{
+				{Action: action, Observation: tc.observation},
+			}, result["intermediateSteps"])
+		})
+	}
+}
+
+func TestExecutorWithStructuredTool(t *testing.T) {
+	t.Parallel()
+
+	type addArgs struct {
+		A int `json:"a"`
+		B int `json:"b"`
+	}
+	tool, err := tools.NewFunc("add", "adds two numbers", func(_ context.Context, args addArgs) (string, error) {
+		return fmt.Sprint(args.A + args.B), nil
+	})
+	require.NoError(t, err)
+
+	actions := []schema.AgentAction{
+		{Tool: "add", ToolInput: `{"a": 1, "b": 2}`},
+		{Tool: "add", ToolInput: `{"a": 1}`},
+	}
+	a := &toolsAgent{testAgent: testAgent{actions: actions}, tools: []tools.Tool{tool}}
+	executor := agents.NewExecutor(a, agents.WithReturnIntermediateSteps())
+
+	result, err := chains.Call(context.Background(), executor, nil)
+	require.NoError(t, err)
+	require.Equal(t, []schema.AgentStep{
+		{Action: actions[0], Observation: "3"},
+		{Action: actions[1], Observation: `invalid tool arguments for add: missing required argument "b"`},
+	}, result["intermediateSteps"])
+}
+
 func TestExecutorWithMRKLAgent(t *testing.T) {
 	t.Parallel()
 
@@ -51,6 +51,14 @@ func NewOpenAIFunctionsAgent(llm llms.Model, tools []tools.Tool, opts ...Option)
 func (o *OpenAIFunctionsAgent) functions() []llms.FunctionDefinition {
 	res := make([]llms.FunctionDefinition, 0)
 	for _, tool := range o.Tools {
+		if st, ok := tool.(tools.StructuredTool); ok {
+			res = append(res, llms.FunctionDefinition{
+				Name:        st.Name(),
+				Description: st.Description(),
+				Parameters:  st.Parameters(),
+			})
+			continue
+		}
 		res = append(res, llms.FunctionDefinition{
 			Name:        tool.Name(),
 			Description: tool.Description(),
@@ -214,8 +222,9 @@ func (o *OpenAIFunctionsAgent) ParseOutput(contentResp *llms.ContentResponse) (
 		return nil, nil, err
 	}
 
+	// Structured tools take the arguments object as is.
 	toolInput := toolInputStr
-	if arg1, ok := toolInputMap["__arg1"]; ok {
+	if arg1, ok := toolInputMap["__arg1"]; ok && !o.isStructuredTool(functionName) {
 		toolInputCheck, ok := arg1.(string)
 		if ok {
 			toolInput = toolInputCheck
@@ -236,3 +245,12 @@ func (o *OpenAIFunctionsAgent) ParseOutput(contentResp *llms.ContentResponse) (
 		},
 	}, nil, nil
 }
+
+func (o *OpenAIFunctionsAgent) isStructuredTool(name string) bool {
+	for _, tool := range o.Tools {
+		if _, ok := tool.(tools.StructuredTool); ok && tool.Name() == name {
+			return true
+		}
+	}
+	return false
+}
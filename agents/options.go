@@ -13,16 +13,19 @@ type Options struct {
 	memory                  schema.Memory
 	callbacksHandler        callbacks.Handler
 	errorHandler            *ParserErrorHandler
+	toolErrorHandler        *ToolErrorHandler
 	maxIterations           int
+	maxConcurrentActions    int
 	returnIntermediateSteps bool
 	outputKey               string
 	promptPrefix            string
 	formatInstructions      string
 	promptSuffix            string
 
-	// openai
+	// openai and tool calling
 	systemMessage string
 	extraMessages []prompts.MessageFormatter
+	toolChoice    any
 }
 
 // Option is a function type that can be used to modify the creation of the agents
@@ -62,6 +65,10 @@ func openAIFunctionsDefaultOptions() Options {
 	}
 }
 
+func toolCallingDefaultOptions() Options {
+	return openAIFunctionsDefaultOptions()
+}
+
 func (co Options) getMrklPrompt(tools []tools.Tool) prompts.PromptTemplate {
 	if co.prompt.Template != "" {
 		return co.prompt
@@ -96,6 +103,16 @@ func WithMaxIterations(iterations int) Option {
 	}
 }
 
+// WithMaxConcurrentActions is an option for letting the executor run up to n of the
+// actions returned by a single plan at the same time. The intermediate steps keep
+// the order of the actions. Callback handlers and tools must be safe for concurrent
+// use when n is greater than 1.
+func WithMaxConcurrentActions(n int) Option {
+	return func(co *Options) {
+		co.maxConcurrentActions = n
+	}
+}
+
 // WithOutputKey is an option for setting the output key of the agent.
 func WithOutputKey(outputKey string) Option {
 	return func(co *Options) {
@@ -161,6 +178,22 @@ func WithParserErrorHandler(errorHandler *ParserErrorHandler) Option {
 	}
 }
 
+// WithToolErrorHandler is an option for setting a tool error handler to an executor.
+func WithToolErrorHandler(errorHandler *ToolErrorHandler) Option {
+	return func(co *Options) {
+		co.toolErrorHandler = errorHandler
+	}
+}
+
+// WithToolChoice is an option for setting the tool choice a ToolCallingAgent gives the
+// model on the first call of a run, eg: "required" or an llms.ToolChoice. See
+// llms.CallOptions.ToolChoice for the accepted values.
+func WithToolChoice(choice any) Option {
+	return func(co *Options) {
+		co.toolChoice = choice
+	}
+}
+
 type OpenAIOption struct{}
 
 func NewOpenAIOption() OpenAIOption {
@@ -0,0 +1,276 @@
+package agents
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/prompts"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/tools"
+)
+
+// _toolInputArg is the single string argument given to tools that are not
+// structured tools.
+const _toolInputArg = "__arg1"
+
+// ToolCallingAgent is an Agent driven by the native tool calling API of the
+// model. It works with any llms.Model that supports llms.WithTools and reports
+// the calls in ContentChoice.ToolCalls. All the tool calls of a turn are
+// returned as actions, so they can be run concurrently by the executor.
+type ToolCallingAgent struct {
+	// LLM is the model used to plan the actions.
+	LLM llms.Model
+	// Prompt is the prompt the conversation starts with. The tool calls and
+	// their results are added after it.
+	Prompt prompts.FormatPrompter
+	// Tools is a list of the tools the agent can use.
+	Tools []tools.Tool
+	// OutputKey is the key where the final output is placed.
+	OutputKey string
+	// ToolChoice is passed to the model with llms.WithToolChoice on the first
+	// call of a run, before any tool was called. If nil, the model decides.
+	ToolChoice any
+	// CallbacksHandler is the handler for callbacks.
+	CallbacksHandler callbacks.Handler
+}
+
+var _ Agent = (*ToolCallingAgent)(nil)
+
+// NewToolCallingAgent creates a new ToolCallingAgent. The system message and
+// extra messages options of OpenAIOption can be used to customize its prompt.
+func NewToolCallingAgent(llm llms.Model, tools []tools.Tool, opts ...Option) *ToolCallingAgent {
+	options := toolCallingDefaultOptions()
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return &ToolCallingAgent{
+		LLM:              llm,
+		Prompt:           createToolCallingPrompt(options),
+		Tools:            tools,
+		OutputKey:        options.outputKey,
+		ToolChoice:       options.toolChoice,
+		CallbacksHandler: options.callbacksHandler,
+	}
+}
+
+// Plan decides what actions to take or returns the final result of the input.
+func (a *ToolCallingAgent) Plan(
+	ctx context.Context,
+	intermediateSteps []schema.AgentStep,
+	inputs map[string]string,
+) ([]schema.AgentAction, *schema.AgentFinish, error) {
+	fullInputs := make(map[string]any, len(inputs))
+	for key, value := range inputs {
+		fullInputs[key] = value
+	}
+	prompt, err := a.Prompt.FormatPrompt(fullInputs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	messages := make([]llms.MessageContent, 0, len(prompt.Messages())+2*len(intermediateSteps))
+	for _, msg := range prompt.Messages() {
+		messages = append(messages, llms.TextParts(msg.GetType(), msg.GetContent()))
+	}
+	messages = append(messages, a.constructScratchPad(intermediateSteps)...)
+
+	callOpts := []llms.CallOption{llms.WithTools(a.tools())}
+	if a.ToolChoice != nil && len(intermediateSteps) == 0 {
+		callOpts = append(callOpts, llms.WithToolChoice(a.ToolChoice))
+	}
+	if a.CallbacksHandler != nil {
+		callOpts = append(callOpts, llms.WithStreamingFunc(func(ctx context.Context, chunk []byte) error {
+			a.CallbacksHandler.HandleStreamingFunc(ctx, chunk)
+			return nil
+		}))
+	}
+
+	result, err := a.LLM.GenerateContent(ctx, messages, callOpts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return a.ParseOutput(result)
+}
+
+// ParseOutput turns the tool calls of all the choices of the response into
+// actions, or returns the text of the response as the final answer if there
+// are none.
+func (a *ToolCallingAgent) ParseOutput(contentResp *llms.ContentResponse) (
+	[]schema.AgentAction, *schema.AgentFinish, error,
+) {
+	if contentResp == nil || len(contentResp.Choices) == 0 {
+		return nil, nil, ErrAgentNoReturn
+	}
+
+	var (
+		content   strings.Builder
+		toolCalls []llms.ToolCall
+	)
+	for _, choice := range contentResp.Choices {
+		content.WriteString(choice.Content)
+		for _, toolCall := range choice.ToolCalls {
+			if toolCall.FunctionCall != nil {
+				toolCalls = append(toolCalls, toolCall)
+			}
+		}
+	}
+
+	// finish
+	if len(toolCalls) == 0 {
+		return nil, &schema.AgentFinish{
+			ReturnValues: map[string]any{
+				a.OutputKey: content.String(),
+			},
+			Log: content.String(),
+		}, nil
+	}
+
+	// actions
+	var log strings.Builder
+	for _, toolCall := range toolCalls {
+		fmt.Fprintf(&log, "Invoking: %s with %s (%s)\n",
+			toolCall.FunctionCall.Name, toolCall.FunctionCall.Arguments, toolCall.ID)
+	}
+	if content.Len() > 0 {
+		fmt.Fprintf(&log, "responded: %s\n", content.String())
+	}
+
+	actions := make([]schema.AgentAction, 0, len(toolCalls))
+	for _, toolCall := range toolCalls {
+		actions = append(actions, schema.AgentAction{
+			Tool:      toolCall.FunctionCall.Name,
+			ToolInput: a.toolInput(toolCall.FunctionCall),
+			Log:       log.String(),
+			ToolID:    toolCall.ID,
+		})
+	}
+	return actions, nil, nil
+}
+
+func (a *ToolCallingAgent) GetInputKeys() []string {
+	return a.Prompt.GetInputVariables()
+}
+
+func (a *ToolCallingAgent) GetOutputKeys() []string {
+	return []string{a.OutputKey}
+}
+
+func (a *ToolCallingAgent) GetTools() []tools.Tool {
+	return a.Tools
+}
+
+// tools returns the definitions of the tools of the agent. Tools that are not
+// structured tools take a single string argument.
+func (a *ToolCallingAgent) tools() []llms.Tool {
+	res := make([]llms.Tool, 0, len(a.Tools))
+	for _, tool := range a.Tools {
+		var parameters any = map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				_toolInputArg: map[string]any{"title": _toolInputArg, "type": "string"},
+			},
+			"required": []string{_toolInputArg},
+		}
+		if st, ok := tool.(tools.StructuredTool); ok {
+			parameters = st.Parameters()
+		}
+		res = append(res, llms.Tool{
+			Type: "function",
+			Function: &llms.FunctionDefinition{
+				Name:        tool.Name(),
+				Description: tool.Description(),
+				Parameters:  parameters,
+			},
+		})
+	}
+	return res
+}
+
+// toolInput returns the input of the tool called by call. Structured tools
+// take the arguments object as is.
+func (a *ToolCallingAgent) toolInput(call *llms.FunctionCall) string {
+	for _, tool := range a.Tools {
+		if _, ok := tool.(tools.StructuredTool); ok && tool.Name() == call.Name {
+			return call.Arguments
+		}
+	}
+
+	var args map[string]any
+	if err := json.Unmarshal([]byte(call.Arguments), &args); err != nil {
+		return call.Arguments
+	}
+	if arg, ok := args[_toolInputArg].(string); ok {
+		return arg
+	}
+	return call.Arguments
+}
+
+// constructScratchPad turns the steps into the messages of the conversation
+// with the model. Consecutive steps planned in the same turn share the same
+// log and are grouped in a single AI message followed by the tool responses.
+func (a *ToolCallingAgent) constructScratchPad(steps []schema.AgentStep) []llms.MessageContent {
+	messages := make([]llms.MessageContent, 0)
+	for i := 0; i < len(steps); {
+		// Steps without a tool, eg: added by a ParserErrorHandler.
+		if steps[i].Action.Tool == "" {
+			messages = append(messages, llms.TextParts(llms.ChatMessageTypeHuman, steps[i].Observation))
+			i++
+			continue
+		}
+
+		j := i + 1
+		for j < len(steps) && steps[j].Action.Tool != "" && steps[j].Action.Log == steps[i].Action.Log {
+			j++
+		}
+
+		aiMessage := llms.MessageContent{Role: llms.ChatMessageTypeAI}
+		toolMessage := llms.MessageContent{Role: llms.ChatMessageTypeTool}
+		for _, step := range steps[i:j] {
+			aiMessage.Parts = append(aiMessage.Parts, llms.ToolCall{
+				ID:   step.Action.ToolID,
+				Type: "function",
+				FunctionCall: &llms.FunctionCall{
+					Name:      step.Action.Tool,
+					Arguments: a.toolArguments(step.Action),
+				},
+			})
+			toolMessage.Parts = append(toolMessage.Parts, llms.ToolCallResponse{
+				ToolCallID: step.Action.ToolID,
+				Name:       step.Action.Tool,
+				Content:    step.Observation,
+			})
+		}
+		messages = append(messages, aiMessage, toolMessage)
+		i = j
+	}
+	return messages
+}
+
+// toolArguments returns the arguments the model called the tool of action
+// with, which is the inverse of toolInput.
+func (a *ToolCallingAgent) toolArguments(action schema.AgentAction) string {
+	for _, tool := range a.Tools {
+		if _, ok := tool.(tools.StructuredTool); ok && tool.Name() == action.Tool {
+			return action.ToolInput
+		}
+	}
+	args, err := json.Marshal(map[string]string{_toolInputArg: action.ToolInput})
+	if err != nil {
+		return action.ToolInput
+	}
+	return string(args)
+}
+
+func createToolCallingPrompt(opts Options) prompts.ChatPromptTemplate {
+	messageFormatters := []prompts.MessageFormatter{prompts.NewSystemMessagePromptTemplate(opts.systemMessage, nil)}
+	messageFormatters = append(messageFormatters, opts.extraMessages...)
+	messageFormatters = append(messageFormatters, prompts.NewHumanMessagePromptTemplate("{{.input}}", []string{"input"}))
+
+	return prompts.NewChatPromptTemplate(messageFormatters)
+}
@@ -0,0 +1,139 @@
+package agents_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/agents"
+	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/tools"
+)
+
+// scriptedModel returns its responses in order and records the messages and
+// options of every call.
+type scriptedModel struct {
+	responses []*llms.ContentResponse
+
+	recordedMessages [][]llms.MessageContent
+	recordedOptions  []llms.CallOptions
+}
+
+func (m *scriptedModel) GenerateContent(
+	_ context.Context,
+	messages []llms.MessageContent,
+	options ...llms.CallOption,
+) (*llms.ContentResponse, error) {
+	opts := llms.CallOptions{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	m.recordedMessages = append(m.recordedMessages, messages)
+	m.recordedOptions = append(m.recordedOptions, opts)
+
+	resp := m.responses[0]
+	m.responses = m.responses[1:]
+	return resp, nil
+}
+
+func (m *scriptedModel) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+type echoTool struct{}
+
+func (echoTool) Name() string        { return "echo" }
+func (echoTool) Description() string { return "echoes its input" }
+
+func (echoTool) Call(_ context.Context, input string) (string, error) {
+	return "echo " + input, nil
+}
+
+func TestToolCallingAgent(t *testing.T) {
+	t.Parallel()
+
+	type addArgs struct {
+		A int `json:"a"`
+		B int `json:"b"`
+	}
+	add, err := tools.NewFunc("add", "adds two numbers", func(_ context.Context, args addArgs) (string, error) {
+		return fmt.Sprint(args.A + args.B), nil
+	})
+	require.NoError(t, err)
+
+	model := &scriptedModel{responses: []*llms.ContentResponse{
+		// Anthropic style: one choice per content block.
+		{Choices: []*llms.ContentChoice{
+			{Content: "Let me check."},
+			{ToolCalls: []llms.ToolCall{{
+				ID: "call_1", Type: "function",
+				FunctionCall: &llms.FunctionCall{Name: "add", Arguments: `{"a":1,"b":2}`},
+			}}},
+			{ToolCalls: []llms.ToolCall{{
+				ID: "call_2", Type: "function",
+				FunctionCall: &llms.FunctionCall{Name: "echo", Arguments: `{"__arg1":"hi"}`},
+			}}},
+		}},
+		{Choices: []*llms.ContentChoice{{Content: "3 and echo hi"}}},
+	}}
+
+	agent := agents.NewToolCallingAgent(model, []tools.Tool{add, echoTool{}},
+		agents.WithToolChoice("required"))
+	executor := agents.NewExecutor(agent, agents.WithMaxConcurrentActions(2))
+
+	result, err := chains.Run(context.Background(), executor, "add 1 and 2 and echo hi")
+	require.NoError(t, err)
+	require.Equal(t, "3 and echo hi", result)
+
+	require.Len(t, model.recordedOptions, 2)
+	require.Equal(t, "required", model.recordedOptions[0].ToolChoice)
+	require.Nil(t, model.recordedOptions[1].ToolChoice)
+	require.Len(t, model.recordedOptions[0].Tools, 2)
+	require.Equal(t, add.Parameters(), model.recordedOptions[0].Tools[0].Function.Parameters)
+
+	second := model.recordedMessages[1]
+	require.Len(t, second, 4)
+	require.Equal(t, llms.MessageContent{
+		Role: llms.ChatMessageTypeAI,
+		Parts: []llms.ContentPart{
+			llms.ToolCall{
+				ID: "call_1", Type: "function",
+				FunctionCall: &llms.FunctionCall{Name: "add", Arguments: `{"a":1,"b":2}`},
+			},
+			llms.ToolCall{
+				ID: "call_2", Type: "function",
+				FunctionCall: &llms.FunctionCall{Name: "echo", Arguments: `{"__arg1":"hi"}`},
+			},
+		},
+	}, second[2])
+	require.Equal(t, llms.MessageContent{
+		Role: llms.ChatMessageTypeTool,
+		Parts: []llms.ContentPart{
+			llms.ToolCallResponse{ToolCallID: "call_1", Name: "add", Content: "3"},
+			llms.ToolCallResponse{ToolCallID: "call_2", Name: "echo", Content: "echo hi"},
+		},
+	}, second[3])
+}
+
+func TestToolCallingAgentParseOutput(t *testing.T) {
+	t.Parallel()
+
+	agent := agents.NewToolCallingAgent(&scriptedModel{}, []tools.Tool{echoTool{}})
+	actions, finish, err := agent.ParseOutput(&llms.ContentResponse{Choices: []*llms.ContentChoice{{
+		ToolCalls: []llms.ToolCall{{
+			ID: "call_1", Type: "function",
+			FunctionCall: &llms.FunctionCall{Name: "echo", Arguments: `{"__arg1":"hi"}`},
+		}},
+	}}})
+	require.NoError(t, err)
+	require.Nil(t, finish)
+	require.Equal(t, []schema.AgentAction{{
+		Tool:      "echo",
+		ToolInput: "hi",
+		Log:       "Invoking: echo with {\"__arg1\":\"hi\"} (call_1)\n",
+		ToolID:    "call_1",
+	}}, actions)
+}
@@ -605,7 +605,7 @@ func (c *Constitutional) Call(ctx context.Context, inputs map[string]any, option
 		return nil, ErrNotFound
 	}
 	initialResponse := response
-	inputPrompt, err := c.chain.Prompt.FormatPrompt(inputs)
+	inputPrompt, err := formatPrompt(ctx, c.chain.Prompt, inputs)
 	if err != nil {
 		return nil, err
 	}
@@ -51,7 +51,7 @@ func NewLLMChain(llm llms.Model, prompt prompts.FormatPrompter, opts ...ChainCal
 // directly, use rather the Call or Run function if the prompt only requires one input
 // value.
 func (c LLMChain) Call(ctx context.Context, values map[string]any, options ...ChainCallOption) (map[string]any, error) {
-	promptValue, err := c.Prompt.FormatPrompt(values)
+	promptValue, err := formatPrompt(ctx, c.Prompt, values)
 	if err != nil {
 		return nil, err
 	}
@@ -61,7 +61,12 @@ func (c LLMChain) Call(ctx context.Context, values map[string]any, options ...Ch
 		return nil, err
 	}
 
-	finalOutput, err := c.OutputParser.ParseWithPrompt(result, promptValue)
+	var finalOutput any
+	if parser, ok := c.OutputParser.(outputparser.ContextParser[any]); ok {
+		finalOutput, err = parser.ParseWithPromptContext(ctx, result, promptValue)
+	} else {
+		finalOutput, err = c.OutputParser.ParseWithPrompt(result, promptValue)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -69,6 +74,15 @@ func (c LLMChain) Call(ctx context.Context, values map[string]any, options ...Ch
 	return map[string]any{c.OutputKey: finalOutput}, nil
 }
 
+// formatPrompt formats prompt with the values, passing ctx to it if it is a
+// prompts.ContextFormatPrompter.
+func formatPrompt(ctx context.Context, prompt prompts.FormatPrompter, values map[string]any) (llms.PromptValue, error) { //nolint:lll
+	if p, ok := prompt.(prompts.ContextFormatPrompter); ok {
+		return p.FormatPromptContext(ctx, values)
+	}
+	return prompt.FormatPrompt(values)
+}
+
 // GetMemory returns the memory.
 func (c LLMChain) GetMemory() schema.Memory { //nolint:ireturn
 	return c.Memory //nolint:ireturn
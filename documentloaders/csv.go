@@ -3,7 +3,6 @@ package documentloaders
 import (
 	"context"
 	"encoding/csv"
-	"errors"
 	"fmt"
 	"io"
 	"strings"
@@ -19,7 +18,7 @@ type CSV struct {
 	columns []string
 }
 
-var _ Loader = CSV{}
+var _ LazyLoader = CSV{}
 
 // NewCSV creates a new csv loader with an io.Reader and optional column names for filtering.
 func NewCSV(r io.Reader, columns ...string) CSV {
@@ -29,46 +28,51 @@ func NewCSV(r io.Reader, columns ...string) CSV {
 	}
 }
 
-// Load reads from the io.Reader and returns a single document with the data.
-func (c CSV) Load(_ context.Context) ([]schema.Document, error) {
+// Load reads from the io.Reader and returns a document for each row.
+func (c CSV) Load(ctx context.Context) ([]schema.Document, error) {
+	return Collect(c.LoadLazy(ctx))
+}
+
+// LoadLazy returns an iterator reading the rows one at a time.
+func (c CSV) LoadLazy(ctx context.Context) schema.DocumentIterator {
 	var header []string
-	var docs []schema.Document
 	var rown int
 
 	rd := csv.NewReader(c.r)
-	for {
-		row, err := rd.Read()
-		if errors.Is(err, io.EOF) {
-			break
-		}
-		if err != nil {
-			return nil, err
-		}
-		if len(header) == 0 {
-			header = append(header, row...)
-			continue
-		}
+	return &funcIterator{
+		ctx: ctx,
+		next: func() (schema.Document, error) {
+			for len(header) == 0 {
+				row, err := rd.Read()
+				if err != nil {
+					return schema.Document{}, err
+				}
+				header = append(header, row...)
+			}
 
-		var content []string
-		for i, value := range row {
-			if c.columns != nil &&
-				len(c.columns) > 0 &&
-				!slices.Contains(c.columns, header[i]) {
-				continue
+			row, err := rd.Read()
+			if err != nil {
+				return schema.Document{}, err
 			}
+			var content []string
+			for i, value := range row {
+				if c.columns != nil &&
+					len(c.columns) > 0 &&
+					!slices.Contains(c.columns, header[i]) {
+					continue
+				}
 
-			line := fmt.Sprintf("%s: %s", header[i], value)
-			content = append(content, line)
-		}
+				line := fmt.Sprintf("%s: %s", header[i], value)
+				content = append(content, line)
+			}
 
-		rown++
-		docs = append(docs, schema.Document{
-			PageContent: strings.Join(content, "\n"),
-			Metadata:    map[string]any{"row": rown},
-		})
+			rown++
+			return schema.Document{
+				PageContent: strings.Join(content, "\n"),
+				Metadata:    map[string]any{"row": rown},
+			}, nil
+		},
 	}
-
-	return docs, nil
 }
 
 // LoadAndSplit reads text data from the io.Reader and splits it into multiple
@@ -0,0 +1,366 @@
+package documentloaders
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/textsplitter"
+)
+
+// DefaultMaxConcurrency is the number of files the directory loader loads at
+// the same time when none is given.
+const DefaultMaxConcurrency = 8
+
+// Metadata keys set by the directory loader on every document.
+const (
+	MetadataSource  = "source"
+	MetadataPath    = "path"
+	MetadataSize    = "size"
+	MetadataModTime = "modtime"
+)
+
+// LoaderFunc creates the loader of a file from its content.
+type LoaderFunc func(content []byte) Loader
+
+// DefaultLoaders returns the loaders the directory loader uses by file
+// extension when none is given.
+func DefaultLoaders() map[string]LoaderFunc {
+	text := func(content []byte) Loader { return NewText(bytes.NewReader(content)) }
+	html := func(content []byte) Loader { return NewHTML(bytes.NewReader(content)) }
+	return map[string]LoaderFunc{
+		".txt":  text,
+		".md":   text,
+		".csv":  func(content []byte) Loader { return NewCSV(bytes.NewReader(content)) },
+		".html": html,
+		".htm":  html,
+		".pdf":  func(content []byte) Loader { return NewPDF(bytes.NewReader(content), int64(len(content))) },
+	}
+}
+
+// DefaultMIMELoaders returns the loaders the directory loader uses by sniffed
+// MIME type, for files whose extension has no loader, when none is given.
+func DefaultMIMELoaders() map[string]LoaderFunc {
+	loaders := DefaultLoaders()
+	return map[string]LoaderFunc{
+		"text/plain":      loaders[".txt"],
+		"text/html":       loaders[".html"],
+		"application/pdf": loaders[".pdf"],
+	}
+}
+
+// Directory loads the files of a file system, choosing a loader for each file
+// by its extension or, failing that, by its sniffed MIME type. Files without
+// a loader are skipped.
+type Directory struct {
+	fsys           fs.FS
+	include        []string
+	exclude        []string
+	loaders        map[string]LoaderFunc
+	mimeLoaders    map[string]LoaderFunc
+	defaultLoader  LoaderFunc
+	maxConcurrency int
+}
+
+var _ LazyLoader = Directory{}
+
+// DirectoryOption is a function type that can be used to modify the
+// directory loader.
+type DirectoryOption func(d *Directory)
+
+// WithInclude sets glob patterns selecting the files to load; all files are
+// loaded if none is given. Patterns containing a slash match the path of the
+// file, in which "**" matches any number of directories, and other patterns
+// match its base name, e.g. "docs/**/*.md" or "*.go".
+func WithInclude(patterns ...string) DirectoryOption {
+	return func(d *Directory) {
+		d.include = patterns
+	}
+}
+
+// WithExclude sets glob patterns, in the form of WithInclude, of files not to
+// load. Directories matching them are not walked.
+func WithExclude(patterns ...string) DirectoryOption {
+	return func(d *Directory) {
+		d.exclude = patterns
+	}
+}
+
+// WithLoader sets the loader of the files with the given extension, such as
+// ".md", replacing the default one.
+func WithLoader(extension string, loader LoaderFunc) DirectoryOption {
+	return func(d *Directory) {
+		d.loaders[strings.ToLower(extension)] = loader
+	}
+}
+
+// WithMIMELoader sets the loader of the files of the given MIME type, such as
+// "text/plain", used when the extension of a file has no loader.
+func WithMIMELoader(mimeType string, loader LoaderFunc) DirectoryOption {
+	return func(d *Directory) {
+		d.mimeLoaders[mimeType] = loader
+	}
+}
+
+// WithDefaultLoader sets the loader of the files that have no loader for
+// their extension or MIME type, instead of skipping them.
+func WithDefaultLoader(loader LoaderFunc) DirectoryOption {
+	return func(d *Directory) {
+		d.defaultLoader = loader
+	}
+}
+
+// WithMaxConcurrency sets the number of files loaded at the same time.
+func WithMaxConcurrency(n int) DirectoryOption {
+	return func(d *Directory) {
+		d.maxConcurrency = n
+	}
+}
+
+// NewDirectory creates a new loader of the files of fsys. Use os.DirFS to
+// load a directory of the local file system.
+func NewDirectory(fsys fs.FS, opts ...DirectoryOption) Directory {
+	d := Directory{
+		fsys:           fsys,
+		loaders:        DefaultLoaders(),
+		mimeLoaders:    DefaultMIMELoaders(),
+		maxConcurrency: DefaultMaxConcurrency,
+	}
+	for _, opt := range opts {
+		opt(&d)
+	}
+	return d
+}
+
+// Load loads all the files, in lexical order of their paths, and returns
+// their documents with the path, size and modification time of the file in
+// the metadata.
+func (d Directory) Load(ctx context.Context) ([]schema.Document, error) {
+	return Collect(d.LoadLazy(ctx))
+}
+
+// LoadAndSplit loads all the files and splits their documents using a text
+// splitter.
+func (d Directory) LoadAndSplit(ctx context.Context, splitter textsplitter.TextSplitter) ([]schema.Document, error) {
+	docs, err := d.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return textsplitter.SplitDocuments(splitter, docs)
+}
+
+// LoadLazy returns an iterator over the documents of the files, in the order
+// of Load. Files are loaded concurrently, a few ahead of the document being
+// read, so only those are held in memory. Each of them is read whole before
+// its loader runs, so a large file is held in memory entirely even if its
+// loader is lazy. The iterator must be closed. An
+// error loading a file is returned once by Next, and the next call continues
+// with the next file.
+func (d Directory) LoadLazy(ctx context.Context) schema.DocumentIterator {
+	// Files are loaded with a context canceled by Close as well, while the
+	// walk stops on Close only, so that it can report the cancellation of ctx.
+	loadCtx, cancel := context.WithCancel(ctx)
+	it := &directoryIterator{
+		cancel:  cancel,
+		closed:  make(chan struct{}),
+		pending: make(chan chan fileResult, max(d.maxConcurrency, 1)),
+	}
+	it.wg.Add(1)
+	go func() {
+		defer it.wg.Done()
+		defer close(it.pending)
+		d.walk(ctx, loadCtx, it)
+	}()
+	return it
+}
+
+type fileResult struct {
+	docs []schema.Document
+	err  error
+}
+
+// directoryIterator is an iterator over the documents of a directory loader.
+type directoryIterator struct {
+	cancel    context.CancelFunc
+	closed    chan struct{}
+	closeOnce sync.Once
+	pending   chan chan fileResult
+	wg        sync.WaitGroup
+	docs      []schema.Document
+}
+
+func (it *directoryIterator) Next() (schema.Document, error) {
+	for len(it.docs) == 0 {
+		result, ok := <-it.pending
+		if !ok {
+			return schema.Document{}, io.EOF
+		}
+		r := <-result
+		if r.err != nil {
+			return schema.Document{}, r.err
+		}
+		it.docs = r.docs
+	}
+	doc := it.docs[0]
+	it.docs = it.docs[1:]
+	return doc, nil
+}
+
+// Close stops loading files and waits for the files being loaded.
+func (it *directoryIterator) Close() error {
+	it.closeOnce.Do(func() {
+		it.cancel()
+		close(it.closed)
+	})
+	for range it.pending { //nolint:revive
+		// Drain so that the walk can stop.
+	}
+	it.wg.Wait()
+	it.docs = nil
+	return nil
+}
+
+// walk sends the future result of each file to load to the pending channel,
+// which bounds how far ahead of the reader files are loaded. It stops when ctx
+// is done, sending its error, or when the iterator is closed.
+func (d Directory) walk(ctx, loadCtx context.Context, it *directoryIterator) {
+	send := func(result chan fileResult) bool {
+		select {
+		case it.pending <- result:
+			return true
+		case <-it.closed:
+			return false
+		}
+	}
+
+	err := fs.WalkDir(d.fsys, ".", func(p string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if p != "." && matchAny(d.exclude, p) {
+			if entry.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if entry.IsDir() || !entry.Type().IsRegular() {
+			return nil
+		}
+		if len(d.include) > 0 && !matchAny(d.include, p) {
+			return nil
+		}
+
+		result := make(chan fileResult, 1)
+		if !send(result) {
+			return fs.SkipAll
+		}
+		it.wg.Add(1)
+		go func() {
+			defer it.wg.Done()
+			docs, err := d.loadFile(loadCtx, p, entry)
+			if err != nil {
+				err = fmt.Errorf("loading %s: %w", p, err)
+			}
+			result <- fileResult{docs: docs, err: err}
+		}()
+		return nil
+	})
+	if err != nil {
+		result := make(chan fileResult, 1)
+		result <- fileResult{err: err}
+		send(result)
+	}
+}
+
+func (d Directory) loadFile(ctx context.Context, p string, entry fs.DirEntry) ([]schema.Document, error) {
+	info, err := entry.Info()
+	if err != nil {
+		return nil, err
+	}
+	content, err := fs.ReadFile(d.fsys, p)
+	if err != nil {
+		return nil, err
+	}
+	loader := d.loaderFor(p, content)
+	if loader == nil {
+		return nil, nil
+	}
+
+	docs, err := loader(content).Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range docs {
+		if docs[i].Metadata == nil {
+			docs[i].Metadata = make(map[string]any, 4) //nolint:gomnd
+		}
+		docs[i].Metadata[MetadataSource] = p
+		docs[i].Metadata[MetadataPath] = p
+		docs[i].Metadata[MetadataSize] = info.Size()
+		docs[i].Metadata[MetadataModTime] = info.ModTime().UTC().Format(time.RFC3339)
+	}
+	return docs, nil
+}
+
+func (d Directory) loaderFor(p string, content []byte) LoaderFunc {
+	if loader, ok := d.loaders[strings.ToLower(path.Ext(p))]; ok {
+		return loader
+	}
+	mimeType, _, _ := strings.Cut(http.DetectContentType(content), ";")
+	if loader, ok := d.mimeLoaders[mimeType]; ok {
+		return loader
+	}
+	return d.defaultLoader
+}
+
+func matchAny(patterns []string, p string) bool {
+	for _, pattern := range patterns {
+		if matchGlob(pattern, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchGlob reports whether the slash separated path p matches pattern, as
+// described in WithInclude.
+func matchGlob(pattern, p string) bool {
+	if !strings.Contains(pattern, "/") {
+		ok, _ := path.Match(pattern, path.Base(p))
+		return ok
+	}
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(p, "/"))
+}
+
+func matchSegments(pattern, segments []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(segments); i++ {
+				if matchSegments(pattern[1:], segments[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(segments) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], segments[0]); !ok {
+			return false
+		}
+		pattern, segments = pattern[1:], segments[1:]
+	}
+	return len(segments) == 0
+}
@@ -0,0 +1,165 @@
+package documentloaders
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/schema"
+)
+
+func testFS() fstest.MapFS {
+	modTime := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	return fstest.MapFS{
+		"README.md":             {Data: []byte("# Project"), ModTime: modTime},
+		"docs/guide.txt":        {Data: []byte("Guide"), ModTime: modTime},
+		"docs/api/index.html":   {Data: []byte("<html><body><p>API</p></body></html>"), ModTime: modTime},
+		"docs/api/notes":        {Data: []byte("plain notes"), ModTime: modTime},
+		"data/users.csv":        {Data: []byte("name,age\nann,31\nbob,42\n"), ModTime: modTime},
+		"vendor/lib/readme.txt": {Data: []byte("vendored"), ModTime: modTime},
+		"bin/tool":              {Data: []byte{0x7f, 'E', 'L', 'F', 0, 1, 2}, ModTime: modTime},
+	}
+}
+
+func sources(docs []schema.Document) []string {
+	paths := make([]string, len(docs))
+	for i, doc := range docs {
+		paths[i], _ = doc.Metadata[MetadataSource].(string)
+	}
+	return paths
+}
+
+func TestDirectoryLoader(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	docs, err := NewDirectory(testFS(), WithExclude("vendor")).Load(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"README.md",
+		"data/users.csv",
+		"data/users.csv",
+		"docs/api/index.html",
+		"docs/api/notes",
+		"docs/guide.txt",
+	}, sources(docs))
+
+	require.Equal(t, "# Project", docs[0].PageContent)
+	require.Equal(t, map[string]any{
+		MetadataSource:  "README.md",
+		MetadataPath:    "README.md",
+		MetadataSize:    int64(9),
+		MetadataModTime: "2024-05-01T12:00:00Z",
+	}, docs[0].Metadata)
+	require.Equal(t, "name: bob\nage: 42", docs[2].PageContent)
+	require.Equal(t, 2, docs[2].Metadata["row"])
+	require.Equal(t, "API", docs[3].PageContent)
+	// The file without extension is loaded as text after sniffing.
+	require.Equal(t, "plain notes", docs[4].PageContent)
+}
+
+func TestDirectoryLoaderGlobs(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	docs, err := NewDirectory(testFS(), WithInclude("docs/**/*.html", "*.md")).Load(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{"README.md", "docs/api/index.html"}, sources(docs))
+
+	docs, err = NewDirectory(testFS(),
+		WithInclude("**/*.txt"),
+		WithExclude("vendor/**"),
+		WithLoader(".txt", func(content []byte) Loader {
+			return NewText(bytes.NewReader(bytes.ToUpper(content)))
+		}),
+	).Load(ctx)
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	require.Equal(t, "GUIDE", docs[0].PageContent)
+
+	docs, err = NewDirectory(testFS(),
+		WithInclude("bin/*"),
+		WithDefaultLoader(func(content []byte) Loader {
+			return NewText(bytes.NewReader(content))
+		}),
+	).Load(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{"bin/tool"}, sources(docs))
+}
+
+func TestDirectoryLoaderLazy(t *testing.T) {
+	t.Parallel()
+
+	fsys := testFS()
+	fsys["docs/broken.pdf"] = &fstest.MapFile{Data: []byte("not a pdf")}
+	it := NewDirectory(fsys, WithInclude("docs/**"), WithMaxConcurrency(1)).LoadLazy(context.Background())
+	defer it.Close()
+
+	var paths []string
+	var errs []error
+	for {
+		doc, err := it.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		paths = append(paths, doc.Metadata[MetadataPath].(string))
+	}
+	require.Equal(t, []string{"docs/api/index.html", "docs/api/notes", "docs/guide.txt"}, paths)
+	require.Len(t, errs, 1)
+	require.ErrorContains(t, errs[0], "loading docs/broken.pdf")
+
+	_, err := NewDirectory(fsys).Load(context.Background())
+	require.ErrorContains(t, err, "docs/broken.pdf")
+}
+
+func TestDirectoryIteratorClose(t *testing.T) {
+	t.Parallel()
+
+	it := NewDirectory(testFS(), WithMaxConcurrency(1)).LoadLazy(context.Background())
+	_, err := it.Next()
+	require.NoError(t, err)
+	require.NoError(t, it.Close())
+	_, err = it.Next()
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestDirectoryLoaderCanceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	it := NewDirectory(testFS(), WithMaxConcurrency(1)).LoadLazy(ctx)
+	defer it.Close()
+	_, err := it.Next()
+	require.NoError(t, err)
+
+	// The cancellation is reported, by the files being loaded and the walk,
+	// before the end of the documents.
+	cancel()
+	var errs []error
+	for {
+		_, err = it.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	require.NotEmpty(t, errs)
+	for _, err := range errs {
+		require.ErrorIs(t, err, context.Canceled)
+	}
+
+	docs, err := NewDirectory(testFS()).Load(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Nil(t, docs)
+}
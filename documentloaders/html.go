@@ -16,7 +16,7 @@ type HTML struct {
 	r io.Reader
 }
 
-var _ Loader = HTML{}
+var _ LazyLoader = HTML{}
 
 // NewHTML creates a new html loader with an io.Reader.
 func NewHTML(r io.Reader) HTML {
@@ -48,6 +48,13 @@ func (h HTML) Load(_ context.Context) ([]schema.Document, error) {
 	}, nil
 }
 
+// LoadLazy returns an iterator over the single document of Load. It isn't
+// lazier than Load: the whole page is read and parsed on the first call to
+// Next.
+func (h HTML) LoadLazy(ctx context.Context) schema.DocumentIterator {
+	return loadOnce(ctx, h.Load)
+}
+
 // LoadAndSplit reads text data from the io.Reader and splits it into multiple
 // documents using a text splitter.
 func (h HTML) LoadAndSplit(ctx context.Context, splitter textsplitter.TextSplitter) ([]schema.Document, error) {
@@ -0,0 +1,94 @@
+package documentloaders
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/tmc/langchaingo/schema"
+)
+
+// LazyLoader is an optional interface for loaders that can load documents one
+// at a time, reading the source only as far as the documents consumed.
+type LazyLoader interface {
+	Loader
+	// LoadLazy returns an iterator over the documents of the source. Errors,
+	// including the cancellation of ctx, are returned by its Next method.
+	LoadLazy(ctx context.Context) schema.DocumentIterator
+}
+
+// Collect reads all the documents of it and closes it.
+func Collect(it schema.DocumentIterator) ([]schema.Document, error) {
+	defer it.Close()
+
+	docs := []schema.Document{}
+	for {
+		doc, err := it.Next()
+		if errors.Is(err, io.EOF) {
+			return docs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+}
+
+// funcIterator is an iterator calling next until it returns io.EOF or an
+// error, or ctx is done.
+type funcIterator struct {
+	ctx   context.Context //nolint:containedctx
+	next  func() (schema.Document, error)
+	close func() error
+	done  bool
+}
+
+var _ schema.DocumentIterator = &funcIterator{}
+
+func (it *funcIterator) Next() (schema.Document, error) {
+	if it.done {
+		return schema.Document{}, io.EOF
+	}
+	if err := it.ctx.Err(); err != nil {
+		it.done = true
+		return schema.Document{}, err
+	}
+	doc, err := it.next()
+	if err != nil {
+		it.done = true
+	}
+	return doc, err
+}
+
+func (it *funcIterator) Close() error {
+	it.done = true
+	if it.close == nil {
+		return nil
+	}
+	return it.close()
+}
+
+// loadOnce returns an iterator over the documents of load, which is called on
+// the first call to Next, for sources producing a single document.
+func loadOnce(ctx context.Context, load func(ctx context.Context) ([]schema.Document, error)) schema.DocumentIterator {
+	var docs []schema.Document
+	loaded := false
+	return &funcIterator{
+		ctx: ctx,
+		next: func() (schema.Document, error) {
+			if !loaded {
+				var err error
+				if docs, err = load(ctx); err != nil {
+					return schema.Document{}, err
+				}
+				loaded = true
+			}
+			if len(docs) == 0 {
+				return schema.Document{}, io.EOF
+			}
+			doc := docs[0]
+			docs = docs[1:]
+			return doc, nil
+		},
+	}
+}
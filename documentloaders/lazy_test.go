@@ -0,0 +1,113 @@
+package documentloaders
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/textsplitter"
+	"github.com/tmc/langchaingo/vectorstores"
+)
+
+func TestCSVLoadLazy(t *testing.T) {
+	t.Parallel()
+
+	file, err := os.Open("./testdata/test.csv")
+	require.NoError(t, err)
+	defer file.Close()
+
+	it := NewCSV(file, "name").LoadLazy(context.Background())
+	doc, err := it.Next()
+	require.NoError(t, err)
+	require.Equal(t, schema.Document{PageContent: "name: John Doe", Metadata: map[string]any{"row": 1}}, doc)
+
+	docs, err := Collect(it)
+	require.NoError(t, err)
+	require.Len(t, docs, 19)
+	require.Equal(t, 20, docs[18].Metadata["row"])
+
+	_, err = it.Next()
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestPDFLoadLazy(t *testing.T) {
+	t.Parallel()
+
+	file, err := os.Open("./testdata/sample.pdf")
+	require.NoError(t, err)
+	defer file.Close()
+	info, err := file.Stat()
+	require.NoError(t, err)
+
+	it := NewPDF(file, info.Size()).LoadLazy(context.Background())
+	defer it.Close()
+	doc, err := it.Next()
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"page": 1, "total_pages": 2}, doc.Metadata)
+
+	_, err = NewPDF(strings.NewReader("not a pdf"), 9).LoadLazy(context.Background()).Next()
+	require.Error(t, err)
+	require.NotErrorIs(t, err, io.EOF)
+}
+
+func TestLoadLazyCanceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	it := NewText(strings.NewReader("text")).LoadLazy(ctx)
+	cancel()
+	_, err := it.Next()
+	require.ErrorIs(t, err, context.Canceled)
+	_, err = it.Next()
+	require.ErrorIs(t, err, io.EOF)
+}
+
+// batchStore records the batches of documents added to it.
+type batchStore struct {
+	batches [][]schema.Document
+}
+
+func (s *batchStore) AddDocuments(_ context.Context, docs []schema.Document, _ ...vectorstores.Option) ([]string, error) {
+	s.batches = append(s.batches, docs)
+	ids := make([]string, len(docs))
+	for i, doc := range docs {
+		ids[i] = doc.PageContent
+	}
+	return ids, nil
+}
+
+func (s *batchStore) SimilaritySearch(context.Context, string, int, ...vectorstores.Option) ([]schema.Document, error) {
+	return nil, nil
+}
+
+func TestLazyPipeline(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	csv := "name,notes\nann,one two three four\nbob,five six\n"
+	splitter := textsplitter.NewRecursiveCharacter(
+		textsplitter.WithChunkSize(10),
+		textsplitter.WithChunkOverlap(0),
+		textsplitter.WithSeparators([]string{"\n", " "}),
+	)
+	it := textsplitter.SplitDocumentsLazy(splitter, NewCSV(strings.NewReader(csv)).LoadLazy(ctx))
+	defer it.Close()
+
+	store := &batchStore{}
+	ids, err := vectorstores.AddDocumentsLazy(ctx, store, it, 2)
+	require.NoError(t, err)
+	require.Equal(t, []string{"name: ann", "notes: one", "two three", "four", "name: bob", "notes:", "five six"}, ids)
+	require.Len(t, store.batches, 4)
+	require.Equal(t, 2, store.batches[2][0].Metadata["row"])
+
+	failing := &funcIterator{ctx: ctx, next: func() (schema.Document, error) {
+		return schema.Document{}, errors.New("read error")
+	}}
+	_, err = vectorstores.AddDocumentsLazy(ctx, store, failing, 2)
+	require.EqualError(t, err, "read error")
+}
@@ -16,7 +16,7 @@ type PDF struct {
 	password string
 }
 
-var _ Loader = PDF{}
+var _ LazyLoader = PDF{}
 
 // PDFOptions are options for the PDF loader.
 type PDFOptions func(pdf *PDF)
@@ -51,54 +51,62 @@ func (p *PDF) getPassword() string {
 
 // Load reads from the io.Reader for the PDF data and returns the documents with the data and with
 // metadata attached of the page number and total number of pages of the PDF.
-func (p PDF) Load(_ context.Context) ([]schema.Document, error) {
-	var reader *pdf.Reader
-	var err error
-
-	if p.password != "" {
-		reader, err = pdf.NewReaderEncrypted(p.r, p.s, p.getPassword)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		reader, err = pdf.NewReader(p.r, p.s)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	numPages := reader.NumPage()
-
-	docs := []schema.Document{}
+func (p PDF) Load(ctx context.Context) ([]schema.Document, error) {
+	return Collect(p.LoadLazy(ctx))
+}
 
+// LoadLazy returns an iterator extracting the text of the pages one at a
+// time, with the metadata of Load.
+func (p PDF) LoadLazy(ctx context.Context) schema.DocumentIterator {
+	var reader *pdf.Reader
+	var numPages int
+	page := 0
 	// fonts to be used when getting plain text from pages
 	fonts := make(map[string]*pdf.Font)
-	for i := 1; i < numPages+1; i++ {
-		p := reader.Page(i)
-		// add fonts to map
-		for _, name := range p.Fonts() {
-			// only add the font if we don't already have it
-			if _, ok := fonts[name]; !ok {
-				f := p.Font(name)
-				fonts[name] = &f
+
+	return &funcIterator{
+		ctx: ctx,
+		next: func() (schema.Document, error) {
+			if reader == nil {
+				var err error
+				if p.password != "" {
+					reader, err = pdf.NewReaderEncrypted(p.r, p.s, p.getPassword)
+				} else {
+					reader, err = pdf.NewReader(p.r, p.s)
+				}
+				if err != nil {
+					return schema.Document{}, err
+				}
+				numPages = reader.NumPage()
+			}
+			if page == numPages {
+				return schema.Document{}, io.EOF
+			}
+			page++
+
+			pg := reader.Page(page)
+			// add fonts to map
+			for _, name := range pg.Fonts() {
+				// only add the font if we don't already have it
+				if _, ok := fonts[name]; !ok {
+					f := pg.Font(name)
+					fonts[name] = &f
+				}
+			}
+			text, err := pg.GetPlainText(fonts)
+			if err != nil {
+				return schema.Document{}, err
 			}
-		}
-		text, err := p.GetPlainText(fonts)
-		if err != nil {
-			return nil, err
-		}
 
-		// add the document to the doc list
-		docs = append(docs, schema.Document{
-			PageContent: text,
-			Metadata: map[string]any{
-				"page":        i,
-				"total_pages": numPages,
-			},
-		})
+			return schema.Document{
+				PageContent: text,
+				Metadata: map[string]any{
+					"page":        page,
+					"total_pages": numPages,
+				},
+			}, nil
+		},
 	}
-
-	return docs, nil
 }
 
 // LoadAndSplit reads pdf data from the io.Reader and splits it into multiple
@@ -14,7 +14,7 @@ type Text struct {
 	r io.Reader
 }
 
-var _ Loader = Text{}
+var _ LazyLoader = Text{}
 
 // NewText creates a new text loader with an io.Reader.
 func NewText(r io.Reader) Text {
@@ -39,6 +39,12 @@ func (l Text) Load(_ context.Context) ([]schema.Document, error) {
 	}, nil
 }
 
+// LoadLazy returns an iterator over the single document of Load. It isn't
+// lazier than Load: the whole reader is read on the first call to Next.
+func (l Text) LoadLazy(ctx context.Context) schema.DocumentIterator {
+	return loadOnce(ctx, l.Load)
+}
+
 // LoadAndSplit reads text data from the io.Reader and splits it into multiple
 // documents using a text splitter.
 func (l Text) LoadAndSplit(ctx context.Context, splitter textsplitter.TextSplitter) ([]schema.Document, error) {
@@ -81,3 +81,72 @@ func getNorm(v []float32) float32 {
 
 	return float32(math.Sqrt(float64(sum)))
 }
+
+// CosineSimilarity returns the cosine similarity of a and b, or 0 if either
+// of them is a zero vector.
+func CosineSimilarity(a, b []float32) float32 {
+	if len(a) != len(b) {
+		return 0
+	}
+	var dot float32
+	for i := range a {
+		dot += a[i] * b[i]
+	}
+	norms := getNorm(a) * getNorm(b)
+	if norms == 0 {
+		return 0
+	}
+	return dot / norms
+}
+
+// MaximalMarginalRelevance returns the indexes of the k candidates selected by
+// maximal marginal relevance, in the order they were selected. Each step picks
+// the candidate maximizing
+//
+//	lambda * sim(query, candidate) - (1 - lambda) * max(sim(candidate, selected))
+//
+// so lambda 1 ranks by similarity to the query only and lambda 0 maximizes
+// the diversity of the results.
+func MaximalMarginalRelevance(query []float32, candidates [][]float32, k int, lambda float32) []int {
+	k = min(k, len(candidates))
+	if k <= 0 {
+		return nil
+	}
+	querySimilarity := make([]float32, len(candidates))
+	for i, candidate := range candidates {
+		querySimilarity[i] = CosineSimilarity(query, candidate)
+	}
+
+	selected := make([]int, 0, k)
+	// redundancy is the highest similarity of each candidate to the selected
+	// ones, which may be negative, so it is only used once one is selected.
+	redundancy := make([]float32, len(candidates))
+	for i := range redundancy {
+		redundancy[i] = float32(math.Inf(-1))
+	}
+	isSelected := make([]bool, len(candidates))
+	for len(selected) < k {
+		best := -1
+		var bestScore float32
+		for i := range candidates {
+			if isSelected[i] {
+				continue
+			}
+			score := lambda * querySimilarity[i]
+			if len(selected) > 0 {
+				score -= (1 - lambda) * redundancy[i]
+			}
+			if best == -1 || score > bestScore {
+				best, bestScore = i, score
+			}
+		}
+		selected = append(selected, best)
+		isSelected[best] = true
+		for i, candidate := range candidates {
+			if !isSelected[i] {
+				redundancy[i] = max(redundancy[i], CosineSimilarity(candidate, candidates[best]))
+			}
+		}
+	}
+	return selected
+}
@@ -82,3 +82,34 @@ func TestGetNorm(t *testing.T) {
 		assert.InEpsilon(t, tc.expected, getNorm(tc.vector), 0.0001)
 	}
 }
+
+func TestCosineSimilarity(t *testing.T) {
+	t.Parallel()
+
+	assert.InDelta(t, 1, CosineSimilarity([]float32{1, 2}, []float32{2, 4}), 1e-6)
+	assert.InDelta(t, 0, CosineSimilarity([]float32{1, 0}, []float32{0, 3}), 1e-6)
+	assert.InDelta(t, -1, CosineSimilarity([]float32{1, 0}, []float32{-1, 0}), 1e-6)
+	assert.Zero(t, CosineSimilarity([]float32{0, 0}, []float32{1, 0}))
+}
+
+func TestMaximalMarginalRelevance(t *testing.T) {
+	t.Parallel()
+
+	query := []float32{1, 0.2}
+	candidates := [][]float32{
+		{1, 0.1},     // similar to the query
+		{0.98, 0.15}, // the most similar, and a near duplicate of the first
+		{0.6, 0.8},   // less similar, but different
+	}
+
+	assert.Equal(t, []int{1, 0}, MaximalMarginalRelevance(query, candidates, 2, 1))
+	assert.Equal(t, []int{1, 2}, MaximalMarginalRelevance(query, candidates, 2, 0.5))
+	assert.Equal(t, []int{1, 2, 0}, MaximalMarginalRelevance(query, candidates, 5, 0.5))
+	assert.Empty(t, MaximalMarginalRelevance(query, nil, 2, 0.5))
+	assert.Empty(t, MaximalMarginalRelevance(query, candidates, -1, 0.5))
+
+	// The candidate least similar to the selected one is preferred even when
+	// all the similarities to it are negative.
+	candidates = [][]float32{{-1, -1}, {-1, -0.5}, {0.5, -0.5}}
+	assert.Equal(t, []int{2, 1}, MaximalMarginalRelevance([]float32{1, 0}, candidates, 2, 0.5))
+}
@@ -0,0 +1,23 @@
+// Package indexing adds documents to a vector store incrementally, so that
+// re-running an ingestion job doesn't embed and store the same documents
+// again.
+//
+// Index hashes the content and metadata of each document and keeps a record
+// of the hashes written in a RecordManager. Documents whose hash is already
+// recorded are skipped. With a cleanup mode, documents previously indexed
+// but not seen again are deleted from the vector store:
+//
+//   - CleanupIncremental deletes, for each source indexed, the documents of
+//     that source that weren't seen in this run, as the run goes.
+//   - CleanupFull deletes all the documents not seen in this run when it
+//     ends, so it must be given all the documents.
+//
+// The source of a document is its "source" metadata value, as set by the
+// document loaders, unless WithSourceIDKey says otherwise.
+//
+// IndexIterator does the same with a schema.DocumentIterator, such as the one
+// of a lazy document loader, without holding all the documents in memory.
+//
+// InMemoryRecordManager keeps the records in memory; the sqlite3 sub package
+// persists them.
+package indexing
@@ -0,0 +1,285 @@
+package indexing
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/vectorstores"
+)
+
+var (
+	// ErrMissingSourceID is returned by incremental indexing when a document
+	// has no source.
+	ErrMissingSourceID = errors.New("document has no source id")
+	// ErrInvalidCleanup is returned for an unknown cleanup mode.
+	ErrInvalidCleanup = errors.New("invalid cleanup mode")
+)
+
+// _namespace is the namespace of the ids derived from document hashes.
+var _namespace = uuid.MustParse("6ba7b812-9dad-11d1-80b4-00c04fd430c8") //nolint:gochecknoglobals
+
+// Result counts the documents of an indexing run.
+type Result struct {
+	// NumAdded is the number of documents written to the vector store.
+	NumAdded int
+	// NumSkipped is the number of documents already indexed, including
+	// duplicates within the run.
+	NumSkipped int
+	// NumDeleted is the number of documents deleted by the cleanup.
+	NumDeleted int
+}
+
+// Index writes the documents that aren't indexed yet to store, recording them
+// in recordManager, and deletes stale documents according to the cleanup
+// mode.
+func Index(
+	ctx context.Context,
+	docs []schema.Document,
+	recordManager RecordManager,
+	store vectorstores.VectorStore,
+	opts ...Option,
+) (Result, error) {
+	return IndexIterator(ctx, &sliceIterator{docs: docs}, recordManager, store, opts...)
+}
+
+// IndexIterator does the same as Index with the documents of it, such as a
+// lazy document loader, reading them one batch at a time. It doesn't close
+// it.
+func IndexIterator(
+	ctx context.Context,
+	it schema.DocumentIterator,
+	recordManager RecordManager,
+	store vectorstores.VectorStore,
+	opts ...Option,
+) (Result, error) {
+	o := options{
+		sourceIDKey: DefaultSourceIDKey,
+		batchSize:   DefaultBatchSize,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.batchSize <= 0 {
+		o.batchSize = DefaultBatchSize
+	}
+
+	ix := indexer{recordManager: recordManager, store: store, opts: o, start: time.Now()}
+	switch o.cleanup {
+	case CleanupNone:
+	case CleanupIncremental, CleanupFull:
+		if _, ok := store.(vectorstores.Deleter); !ok {
+			return Result{}, fmt.Errorf("%w: %T does not implement Deleter", vectorstores.ErrUnsupported, store)
+		}
+	default:
+		return Result{}, fmt.Errorf("%w: %q", ErrInvalidCleanup, o.cleanup)
+	}
+
+	batch := make([]schema.Document, 0, o.batchSize)
+	for {
+		doc, err := it.Next()
+		if err != nil && !errors.Is(err, io.EOF) {
+			return ix.result, err
+		}
+		if err == nil {
+			batch = append(batch, doc)
+			if len(batch) < o.batchSize {
+				continue
+			}
+		}
+
+		if len(batch) > 0 {
+			if batchErr := ix.indexBatch(ctx, batch); batchErr != nil {
+				return ix.result, batchErr
+			}
+			batch = make([]schema.Document, 0, o.batchSize)
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	if o.cleanup == CleanupFull {
+		if err := ix.cleanup(ctx, nil); err != nil {
+			return ix.result, err
+		}
+	}
+	return ix.result, nil
+}
+
+type indexer struct {
+	recordManager RecordManager
+	store         vectorstores.VectorStore
+	opts          options
+	start         time.Time
+	result        Result
+}
+
+func (ix *indexer) indexBatch(ctx context.Context, docs []schema.Document) error {
+	keys := make([]string, 0, len(docs))
+	groupIDs := make(map[string]string, len(docs))
+	unique := make([]schema.Document, 0, len(docs))
+	for _, doc := range docs {
+		key, err := hashDocument(doc)
+		if err != nil {
+			return err
+		}
+		if _, seen := groupIDs[key]; seen {
+			ix.result.NumSkipped++
+			continue
+		}
+		groupID, _ := doc.Metadata[ix.opts.sourceIDKey].(string)
+		if groupID == "" && ix.opts.cleanup == CleanupIncremental {
+			return fmt.Errorf("%w: metadata key %q", ErrMissingSourceID, ix.opts.sourceIDKey)
+		}
+		groupIDs[key] = groupID
+		keys = append(keys, key)
+		unique = append(unique, doc)
+	}
+
+	existing, err := ix.recordManager.Get(ctx, keys)
+	if err != nil {
+		return err
+	}
+	var newKeys []string
+	var newDocs []schema.Document
+	for i, key := range keys {
+		if _, ok := existing[key]; ok {
+			continue
+		}
+		newKeys = append(newKeys, key)
+		newDocs = append(newDocs, unique[i])
+	}
+	ids, err := ix.add(ctx, newKeys, newDocs)
+	if err != nil {
+		return err
+	}
+	ix.result.NumAdded += len(newDocs)
+	ix.result.NumSkipped += len(keys) - len(newKeys)
+
+	// Existing records are updated too, which marks them as seen in this run.
+	now := time.Now()
+	records := make([]Record, len(keys))
+	for i, key := range keys {
+		id := existing[key].ID
+		if storeID, ok := ids[key]; ok {
+			id = storeID
+		}
+		records[i] = Record{Key: key, ID: id, GroupID: groupIDs[key], UpdatedAt: now}
+	}
+	if err := ix.recordManager.Update(ctx, records); err != nil {
+		return err
+	}
+
+	if ix.opts.cleanup != CleanupIncremental {
+		return nil
+	}
+	seen := make(map[string]bool)
+	var groups []string
+	for _, groupID := range groupIDs {
+		if !seen[groupID] {
+			seen[groupID] = true
+			groups = append(groups, groupID)
+		}
+	}
+	return ix.cleanup(ctx, groups)
+}
+
+// add writes docs to the vector store and returns their ids by key. Vector
+// stores that can upsert get ids derived from the keys, so that documents
+// written without being recorded, after a failure, are replaced on retry.
+func (ix *indexer) add(ctx context.Context, keys []string, docs []schema.Document) (map[string]string, error) {
+	if len(docs) == 0 {
+		return nil, nil
+	}
+
+	var ids []string
+	var err error
+	if _, ok := ix.store.(vectorstores.Upserter); ok {
+		ids = make([]string, len(keys))
+		for i, key := range keys {
+			ids[i] = uuid.NewSHA1(_namespace, []byte(key)).String()
+		}
+		ids, err = vectorstores.UpsertDocuments(ctx, ix.store, ids, docs, ix.opts.storeOptions...)
+	} else {
+		ids, err = ix.store.AddDocuments(ctx, docs, ix.opts.storeOptions...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) != len(keys) {
+		return nil, fmt.Errorf("%w: %d ids for %d documents", vectorstores.ErrMismatchedIDs, len(ids), len(keys))
+	}
+
+	byKey := make(map[string]string, len(keys))
+	for i, key := range keys {
+		byKey[key] = ids[i]
+	}
+	return byKey, nil
+}
+
+// cleanup deletes the documents of the given groups, or of all groups if
+// groupIDs is nil, that weren't seen in this run.
+func (ix *indexer) cleanup(ctx context.Context, groupIDs []string) error {
+	stale, err := ix.recordManager.List(ctx, ix.start, groupIDs)
+	if err != nil || len(stale) == 0 {
+		return err
+	}
+
+	ids := make([]string, 0, len(stale))
+	keys := make([]string, len(stale))
+	for i, record := range stale {
+		keys[i] = record.Key
+		if record.ID != "" {
+			ids = append(ids, record.ID)
+		}
+	}
+	if len(ids) > 0 {
+		if err := vectorstores.DeleteDocuments(ctx, ix.store, ids, ix.opts.storeOptions...); err != nil {
+			return err
+		}
+	}
+	if err := ix.recordManager.Delete(ctx, keys); err != nil {
+		return err
+	}
+	ix.result.NumDeleted += len(stale)
+	return nil
+}
+
+// hashDocument returns the hash of the content and metadata of doc.
+func hashDocument(doc schema.Document) (string, error) {
+	metadata, err := json.Marshal(doc.Metadata)
+	if err != nil {
+		return "", fmt.Errorf("hashing document metadata: %w", err)
+	}
+	h := sha256.New()
+	h.Write([]byte(doc.PageContent))
+	h.Write([]byte{0})
+	h.Write(metadata)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+type sliceIterator struct {
+	docs []schema.Document
+}
+
+func (it *sliceIterator) Next() (schema.Document, error) {
+	if len(it.docs) == 0 {
+		return schema.Document{}, io.EOF
+	}
+	doc := it.docs[0]
+	it.docs = it.docs[1:]
+	return doc, nil
+}
+
+func (it *sliceIterator) Close() error {
+	it.docs = nil
+	return nil
+}
@@ -0,0 +1,207 @@
+package indexing_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/indexing"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/vectorstores"
+	"github.com/tmc/langchaingo/vectorstores/inmemory"
+)
+
+// countingEmbedder embeds a text as its length and counts the texts embedded.
+type countingEmbedder struct {
+	mu    sync.Mutex
+	texts []string
+}
+
+func (e *countingEmbedder) EmbedDocuments(_ context.Context, texts []string) ([][]float32, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.texts = append(e.texts, texts...)
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		vectors[i] = []float32{float32(len(text)), 1}
+	}
+	return vectors, nil
+}
+
+func (e *countingEmbedder) EmbedQuery(_ context.Context, text string) ([]float32, error) {
+	return []float32{float32(len(text)), 1}, nil
+}
+
+func (e *countingEmbedder) embedded() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	texts := e.texts
+	e.texts = nil
+	return texts
+}
+
+func newStore(t *testing.T) (*inmemory.Store, *countingEmbedder) {
+	t.Helper()
+	embedder := &countingEmbedder{}
+	store, err := inmemory.New(inmemory.WithEmbedder(embedder))
+	require.NoError(t, err)
+	return store, embedder
+}
+
+func doc(content, source string) schema.Document {
+	return schema.Document{PageContent: content, Metadata: map[string]any{"source": source}}
+}
+
+func contents(t *testing.T, store vectorstores.VectorStore) []string {
+	t.Helper()
+	docs, err := store.SimilaritySearch(context.Background(), "", 100)
+	require.NoError(t, err)
+	texts := make([]string, len(docs))
+	for i, d := range docs {
+		texts[i] = d.PageContent
+	}
+	return texts
+}
+
+func TestIndexSkipsIndexedDocuments(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	store, embedder := newStore(t)
+	manager := indexing.NewInMemoryRecordManager()
+
+	docs := []schema.Document{doc("a", "1.txt"), doc("b", "1.txt"), doc("a", "1.txt")}
+	result, err := indexing.Index(ctx, docs, manager, store)
+	require.NoError(t, err)
+	require.Equal(t, indexing.Result{NumAdded: 2, NumSkipped: 1}, result)
+	require.Equal(t, []string{"a", "b"}, embedder.embedded())
+
+	docs = append(docs, doc("c", "2.txt"))
+	result, err = indexing.Index(ctx, docs, manager, store, indexing.WithBatchSize(2))
+	require.NoError(t, err)
+	require.Equal(t, indexing.Result{NumAdded: 1, NumSkipped: 3}, result)
+	require.Equal(t, []string{"c"}, embedder.embedded())
+	require.Equal(t, 3, store.Len(inmemory.DefaultNameSpace))
+
+	// The same content with other metadata is another document.
+	result, err = indexing.Index(ctx, []schema.Document{doc("a", "2.txt")}, manager, store)
+	require.NoError(t, err)
+	require.Equal(t, indexing.Result{NumAdded: 1}, result)
+}
+
+func TestIndexCleanupIncremental(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	store, embedder := newStore(t)
+	manager := indexing.NewInMemoryRecordManager()
+	opts := []indexing.Option{indexing.WithCleanup(indexing.CleanupIncremental)}
+
+	docs := []schema.Document{doc("a1", "a.txt"), doc("a2", "a.txt"), doc("b1", "b.txt")}
+	result, err := indexing.Index(ctx, docs, manager, store, opts...)
+	require.NoError(t, err)
+	require.Equal(t, indexing.Result{NumAdded: 3}, result)
+	embedder.embedded()
+
+	// a.txt changed and b.txt wasn't loaded: only a.txt is cleaned up.
+	docs = []schema.Document{doc("a1", "a.txt"), doc("a3", "a.txt")}
+	result, err = indexing.Index(ctx, docs, manager, store, opts...)
+	require.NoError(t, err)
+	require.Equal(t, indexing.Result{NumAdded: 1, NumSkipped: 1, NumDeleted: 1}, result)
+	require.Equal(t, []string{"a3"}, embedder.embedded())
+	require.ElementsMatch(t, []string{"a1", "a3", "b1"}, contents(t, store))
+
+	_, err = indexing.Index(ctx, []schema.Document{{PageContent: "x"}}, manager, store, opts...)
+	require.ErrorIs(t, err, indexing.ErrMissingSourceID)
+}
+
+func TestIndexCleanupFull(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	store, _ := newStore(t)
+	manager := indexing.NewInMemoryRecordManager()
+	opts := []indexing.Option{
+		indexing.WithCleanup(indexing.CleanupFull),
+		indexing.WithSourceIDKey("file"),
+		indexing.WithVectorStoreOptions(vectorstores.WithNameSpace("docs")),
+	}
+
+	docs := []schema.Document{
+		{PageContent: "a", Metadata: map[string]any{"file": "a.txt"}},
+		{PageContent: "b", Metadata: map[string]any{"file": "b.txt"}},
+		{PageContent: "c"},
+	}
+	result, err := indexing.Index(ctx, docs, manager, store, opts...)
+	require.NoError(t, err)
+	require.Equal(t, indexing.Result{NumAdded: 3}, result)
+	require.Equal(t, 3, store.Len("docs"))
+
+	result, err = indexing.Index(ctx, docs[1:2], manager, store, opts...)
+	require.NoError(t, err)
+	require.Equal(t, indexing.Result{NumSkipped: 1, NumDeleted: 2}, result)
+	require.Equal(t, 1, store.Len("docs"))
+
+	records, err := manager.List(ctx, time.Now(), nil)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+}
+
+type iterator struct {
+	docs   []schema.Document
+	closed bool
+}
+
+func (it *iterator) Next() (schema.Document, error) {
+	if len(it.docs) == 0 {
+		return schema.Document{}, io.EOF
+	}
+	d := it.docs[0]
+	it.docs = it.docs[1:]
+	return d, nil
+}
+
+func (it *iterator) Close() error {
+	it.closed = true
+	return nil
+}
+
+// addOnlyStore is a vector store that can't delete documents.
+type addOnlyStore struct{ vectorstores.VectorStore }
+
+func TestIndexErrors(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	store, _ := newStore(t)
+	manager := indexing.NewInMemoryRecordManager()
+
+	_, err := indexing.Index(ctx, nil, manager, addOnlyStore{store}, indexing.WithCleanup(indexing.CleanupFull))
+	require.ErrorIs(t, err, vectorstores.ErrUnsupported)
+
+	_, err = indexing.Index(ctx, nil, manager, store, indexing.WithCleanup("sometimes"))
+	require.ErrorIs(t, err, indexing.ErrInvalidCleanup)
+
+	// Without cleanup, any vector store works.
+	result, err := indexing.Index(ctx, []schema.Document{doc("a", "a.txt")}, manager, addOnlyStore{store})
+	require.NoError(t, err)
+	require.Equal(t, 1, result.NumAdded)
+}
+
+func TestIndexIterator(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	store, embedder := newStore(t)
+	manager := indexing.NewInMemoryRecordManager()
+
+	docs := []schema.Document{doc("one", "a"), doc("two", "a"), doc("three", "a")}
+	it := &iterator{docs: docs}
+	result, err := indexing.IndexIterator(ctx, it, manager, store,
+		indexing.WithBatchSize(2),
+		indexing.WithCleanup(indexing.CleanupIncremental),
+	)
+	require.NoError(t, err)
+	require.Equal(t, indexing.Result{NumAdded: 3}, result)
+	require.Equal(t, "one two three", strings.Join(embedder.embedded(), " "))
+	require.False(t, it.closed)
+}
@@ -0,0 +1,68 @@
+package indexing
+
+import "github.com/tmc/langchaingo/vectorstores"
+
+const (
+	// DefaultBatchSize is the number of documents written at once when none
+	// is given.
+	DefaultBatchSize = 100
+	// DefaultSourceIDKey is the metadata key of the source of a document
+	// when none is given.
+	DefaultSourceIDKey = "source"
+)
+
+// Cleanup is the way documents that weren't seen again are deleted.
+type Cleanup string
+
+const (
+	// CleanupNone never deletes documents.
+	CleanupNone Cleanup = ""
+	// CleanupIncremental deletes the documents of the sources indexed that
+	// weren't seen in the run, after each batch.
+	CleanupIncremental Cleanup = "incremental"
+	// CleanupFull deletes all the documents that weren't seen in the run, at
+	// its end.
+	CleanupFull Cleanup = "full"
+)
+
+// Option is a function type that can be used to modify the indexing.
+type Option func(o *options)
+
+type options struct {
+	cleanup      Cleanup
+	sourceIDKey  string
+	batchSize    int
+	storeOptions []vectorstores.Option
+}
+
+// WithCleanup is an option for setting the cleanup mode. It requires a vector
+// store implementing vectorstores.Deleter.
+func WithCleanup(cleanup Cleanup) Option {
+	return func(o *options) {
+		o.cleanup = cleanup
+	}
+}
+
+// WithSourceIDKey is an option for setting the metadata key of the source of
+// the documents.
+func WithSourceIDKey(key string) Option {
+	return func(o *options) {
+		o.sourceIDKey = key
+	}
+}
+
+// WithBatchSize is an option for setting the number of documents written to
+// the vector store at once.
+func WithBatchSize(n int) Option {
+	return func(o *options) {
+		o.batchSize = n
+	}
+}
+
+// WithVectorStoreOptions is an option for setting the options of the vector
+// store calls, such as vectorstores.WithNameSpace.
+func WithVectorStoreOptions(opts ...vectorstores.Option) Option {
+	return func(o *options) {
+		o.storeOptions = opts
+	}
+}
@@ -0,0 +1,107 @@
+package indexing
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Record is the record of a document written to a vector store.
+type Record struct {
+	// Key is the hash of the content and metadata of the document.
+	Key string
+	// ID is the id of the document in the vector store.
+	ID string
+	// GroupID is the source of the document, or empty.
+	GroupID string
+	// UpdatedAt is the last time the document was indexed.
+	UpdatedAt time.Time
+}
+
+// RecordManager stores the records of the documents written to a vector
+// store.
+type RecordManager interface {
+	// Get returns the records of the given keys. Keys without a record are
+	// missing from the map.
+	Get(ctx context.Context, keys []string) (map[string]Record, error)
+	// Update creates the records, or replaces the records with the same keys.
+	Update(ctx context.Context, records []Record) error
+	// List returns the records updated before the given time, of the given
+	// groups or of all groups if groupIDs is nil.
+	List(ctx context.Context, before time.Time, groupIDs []string) ([]Record, error)
+	// Delete deletes the records of the given keys.
+	Delete(ctx context.Context, keys []string) error
+}
+
+// InMemoryRecordManager is a RecordManager keeping the records in memory. It
+// is safe for concurrent use.
+type InMemoryRecordManager struct {
+	mu      sync.RWMutex
+	records map[string]Record
+}
+
+var _ RecordManager = &InMemoryRecordManager{}
+
+// NewInMemoryRecordManager creates an empty in-memory record manager.
+func NewInMemoryRecordManager() *InMemoryRecordManager {
+	return &InMemoryRecordManager{records: make(map[string]Record)}
+}
+
+// Get implements the RecordManager interface.
+func (m *InMemoryRecordManager) Get(_ context.Context, keys []string) (map[string]Record, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	records := make(map[string]Record, len(keys))
+	for _, key := range keys {
+		if record, ok := m.records[key]; ok {
+			records[key] = record
+		}
+	}
+	return records, nil
+}
+
+// Update implements the RecordManager interface.
+func (m *InMemoryRecordManager) Update(_ context.Context, records []Record) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, record := range records {
+		m.records[record.Key] = record
+	}
+	return nil
+}
+
+// List implements the RecordManager interface. Records are sorted by key.
+func (m *InMemoryRecordManager) List(_ context.Context, before time.Time, groupIDs []string) ([]Record, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var records []Record
+	for _, record := range m.records {
+		if !record.UpdatedAt.Before(before) {
+			continue
+		}
+		if groupIDs != nil && !slices.Contains(groupIDs, record.GroupID) {
+			continue
+		}
+		records = append(records, record)
+	}
+	slices.SortFunc(records, func(a, b Record) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+	return records, nil
+}
+
+// Delete implements the RecordManager interface.
+func (m *InMemoryRecordManager) Delete(_ context.Context, keys []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, key := range keys {
+		delete(m.records, key)
+	}
+	return nil
+}
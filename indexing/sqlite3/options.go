@@ -0,0 +1,26 @@
+package sqlite3
+
+const (
+	// DefaultTableName is the name of the records table when none is given.
+	DefaultTableName = "langchaingo_records"
+	// DefaultNamespace is the namespace of the records when none is given.
+	DefaultNamespace = "default"
+)
+
+// Option is a function type that can be used to modify the record manager.
+type Option func(m *RecordManager)
+
+// WithTableName is an option for setting the name of the records table.
+func WithTableName(name string) Option {
+	return func(m *RecordManager) {
+		m.tableName = name
+	}
+}
+
+// WithNamespace is an option for setting the namespace of the records, so that
+// the records of several vector stores or collections can share a table.
+func WithNamespace(namespace string) Option {
+	return func(m *RecordManager) {
+		m.namespace = namespace
+	}
+}
@@ -0,0 +1,193 @@
+// Package sqlite3 adds support for keeping the records of indexed documents
+// in sqlite3.
+package sqlite3
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3" // sqlite3 driver.
+	"github.com/tmc/langchaingo/indexing"
+)
+
+const schema = `CREATE TABLE IF NOT EXISTS %[1]s (
+	namespace TEXT NOT NULL,
+	key TEXT NOT NULL,
+	id TEXT NOT NULL,
+	group_id TEXT NOT NULL,
+	updated_at INTEGER NOT NULL,
+	PRIMARY KEY (namespace, key)
+);
+CREATE INDEX IF NOT EXISTS idx_%[1]s_group ON %[1]s (namespace, group_id, updated_at);`
+
+// maxParams is the number of keys bound in a single query, below the default
+// sqlite3 limit of 999 variables.
+const maxParams = 500
+
+// RecordManager is an indexing.RecordManager keeping the records in a sqlite3
+// table.
+type RecordManager struct {
+	db        *sql.DB
+	tableName string
+	namespace string
+}
+
+var _ indexing.RecordManager = &RecordManager{}
+
+// New creates a new record manager using db, creating its table if it doesn't
+// exist.
+func New(ctx context.Context, db *sql.DB, opts ...Option) (*RecordManager, error) {
+	m := &RecordManager{
+		db:        db,
+		tableName: DefaultTableName,
+		namespace: DefaultNamespace,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	if _, err := db.ExecContext(ctx, fmt.Sprintf(schema, m.tableName)); err != nil {
+		return nil, fmt.Errorf("creating records table: %w", err)
+	}
+	return m, nil
+}
+
+// Get returns the records of the given keys.
+func (m *RecordManager) Get(ctx context.Context, keys []string) (map[string]indexing.Record, error) {
+	records := make(map[string]indexing.Record, len(keys))
+	for _, chunk := range chunks(keys) {
+		query := fmt.Sprintf(
+			"SELECT key, id, group_id, updated_at FROM %s WHERE namespace = ? AND key IN (%s)",
+			m.tableName, placeholders(len(chunk)),
+		)
+		found, err := m.query(ctx, query, append([]any{m.namespace}, toArgs(chunk)...)...)
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range found {
+			records[record.Key] = record
+		}
+	}
+	return records, nil
+}
+
+// Update creates the records, or replaces the records with the same keys.
+func (m *RecordManager) Update(ctx context.Context, records []indexing.Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	stmt, err := tx.PrepareContext(ctx, fmt.Sprintf(
+		"INSERT OR REPLACE INTO %s (namespace, key, id, group_id, updated_at) VALUES (?, ?, ?, ?, ?)",
+		m.tableName,
+	))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, record := range records {
+		_, err := stmt.ExecContext(ctx, m.namespace, record.Key, record.ID, record.GroupID, record.UpdatedAt.UnixNano())
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// List returns the records updated before the given time, of the given groups
+// or of all groups if groupIDs is nil, ordered by key.
+func (m *RecordManager) List(ctx context.Context, before time.Time, groupIDs []string) ([]indexing.Record, error) {
+	query := fmt.Sprintf(
+		"SELECT key, id, group_id, updated_at FROM %s WHERE namespace = ? AND updated_at < ?",
+		m.tableName,
+	)
+	args := []any{m.namespace, before.UnixNano()}
+	if groupIDs == nil {
+		return m.query(ctx, query+" ORDER BY key", args...)
+	}
+
+	var records []indexing.Record
+	for _, chunk := range chunks(groupIDs) {
+		found, err := m.query(ctx,
+			query+fmt.Sprintf(" AND group_id IN (%s) ORDER BY key", placeholders(len(chunk))),
+			append(args, toArgs(chunk)...)...,
+		)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, found...)
+	}
+	slices.SortFunc(records, func(a, b indexing.Record) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+	return records, nil
+}
+
+// Delete deletes the records of the given keys.
+func (m *RecordManager) Delete(ctx context.Context, keys []string) error {
+	for _, chunk := range chunks(keys) {
+		query := fmt.Sprintf(
+			"DELETE FROM %s WHERE namespace = ? AND key IN (%s)",
+			m.tableName, placeholders(len(chunk)),
+		)
+		if _, err := m.db.ExecContext(ctx, query, append([]any{m.namespace}, toArgs(chunk)...)...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *RecordManager) query(ctx context.Context, query string, args ...any) ([]indexing.Record, error) {
+	rows, err := m.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []indexing.Record
+	for rows.Next() {
+		var record indexing.Record
+		var updatedAt int64
+		if err := rows.Scan(&record.Key, &record.ID, &record.GroupID, &updatedAt); err != nil {
+			return nil, err
+		}
+		record.UpdatedAt = time.Unix(0, updatedAt)
+		records = append(records, record)
+	}
+	return records, rows.Err()
+}
+
+func chunks(values []string) [][]string {
+	var result [][]string
+	for len(values) > maxParams {
+		result = append(result, values[:maxParams])
+		values = values[maxParams:]
+	}
+	if len(values) > 0 {
+		result = append(result, values)
+	}
+	return result
+}
+
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+func toArgs(values []string) []any {
+	args := make([]any, len(values))
+	for i, v := range values {
+		args[i] = v
+	}
+	return args
+}
@@ -0,0 +1,101 @@
+package sqlite3_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/indexing"
+	"github.com/tmc/langchaingo/indexing/sqlite3"
+)
+
+func newTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "records.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestRecordManager(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	db := newTestDB(t)
+
+	m, err := sqlite3.New(ctx, db)
+	require.NoError(t, err)
+	other, err := sqlite3.New(ctx, db, sqlite3.WithNamespace("other"))
+	require.NoError(t, err)
+
+	then := time.Unix(100, 0)
+	now := time.Unix(200, 0)
+	require.NoError(t, m.Update(ctx, []indexing.Record{
+		{Key: "k1", ID: "id1", GroupID: "a", UpdatedAt: then},
+		{Key: "k2", ID: "id2", GroupID: "b", UpdatedAt: then},
+		{Key: "k3", ID: "id3", GroupID: "a", UpdatedAt: now},
+	}))
+	require.NoError(t, other.Update(ctx, []indexing.Record{{Key: "k1", ID: "x", GroupID: "a", UpdatedAt: then}}))
+
+	records, err := m.Get(ctx, []string{"k1", "k3", "missing"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]indexing.Record{
+		"k1": {Key: "k1", ID: "id1", GroupID: "a", UpdatedAt: then},
+		"k3": {Key: "k3", ID: "id3", GroupID: "a", UpdatedAt: now},
+	}, records)
+
+	list, err := m.List(ctx, now, nil)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	require.Equal(t, "k1", list[0].Key)
+	list, err = m.List(ctx, now, []string{"b"})
+	require.NoError(t, err)
+	require.Equal(t, []indexing.Record{{Key: "k2", ID: "id2", GroupID: "b", UpdatedAt: then}}, list)
+
+	// Updating a record replaces it.
+	require.NoError(t, m.Update(ctx, []indexing.Record{{Key: "k1", ID: "id1", GroupID: "a", UpdatedAt: now}}))
+	list, err = m.List(ctx, now, []string{"a"})
+	require.NoError(t, err)
+	require.Empty(t, list)
+
+	require.NoError(t, m.Delete(ctx, []string{"k1", "k2"}))
+	records, err = m.Get(ctx, []string{"k1", "k2", "k3"})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+
+	// Other namespaces are unaffected.
+	records, err = other.Get(ctx, []string{"k1"})
+	require.NoError(t, err)
+	require.Equal(t, "x", records["k1"].ID)
+}
+
+func TestRecordManagerManyKeys(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	m, err := sqlite3.New(ctx, newTestDB(t), sqlite3.WithTableName("records"))
+	require.NoError(t, err)
+
+	keys := make([]string, 1200)
+	records := make([]indexing.Record, len(keys))
+	for i := range keys {
+		keys[i] = fmt.Sprintf("k%04d", i)
+		records[i] = indexing.Record{Key: keys[i], GroupID: keys[i], UpdatedAt: time.Unix(1, 0)}
+	}
+	require.NoError(t, m.Update(ctx, records))
+
+	found, err := m.Get(ctx, keys)
+	require.NoError(t, err)
+	require.Len(t, found, len(keys))
+	list, err := m.List(ctx, time.Now(), keys)
+	require.NoError(t, err)
+	require.Len(t, list, len(keys))
+
+	require.NoError(t, m.Delete(ctx, keys))
+	list, err = m.List(ctx, time.Now(), nil)
+	require.NoError(t, err)
+	require.Empty(t, list)
+}
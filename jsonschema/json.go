@@ -4,7 +4,10 @@
 // and/or pass in the schema in []byte format.
 package jsonschema
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"strconv"
+)
 
 type DataType string
 
@@ -25,8 +28,11 @@ type Definition struct {
 	Type DataType `json:"type,omitempty"`
 	// Description is the description of the schema.
 	Description string `json:"description,omitempty"`
+	// Format is the format of a string, such as "date-time".
+	Format string `json:"format,omitempty"`
 	// Enum is used to restrict a value to a fixed set of values. It must be an array with at least
-	// one element, where each element is unique. You will probably only use this with strings.
+	// one element, where each element is unique. Values of Integer, Number and Boolean schemas
+	// are encoded as JSON numbers and booleans.
 	Enum []string `json:"enum,omitempty"`
 	// Properties describes the properties of an object, if the schema type is Object.
 	Properties map[string]Definition `json:"properties"`
@@ -43,7 +49,59 @@ func (d Definition) MarshalJSON() ([]byte, error) {
 	type Alias Definition
 	return json.Marshal(struct {
 		Alias
+		Enum []json.RawMessage `json:"enum,omitempty"`
 	}{
 		Alias: (Alias)(d),
+		Enum:  d.enumJSON(),
 	})
 }
+
+func (d *Definition) UnmarshalJSON(data []byte) error {
+	type Alias Definition
+	var v struct {
+		*Alias
+		Enum []json.RawMessage `json:"enum,omitempty"`
+	}
+	v.Alias = (*Alias)(d)
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	d.Enum = nil
+	for _, raw := range v.Enum {
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			s = string(raw)
+		}
+		d.Enum = append(d.Enum, s)
+	}
+	return nil
+}
+
+// enumJSON returns the JSON encoding of the enum values, which are numbers and
+// booleans for schemas of those types and strings otherwise.
+func (d Definition) enumJSON() []json.RawMessage {
+	if len(d.Enum) == 0 {
+		return nil
+	}
+	values := make([]json.RawMessage, len(d.Enum))
+	for i, value := range d.Enum {
+		if d.isLiteral(value) {
+			values[i] = json.RawMessage(value)
+			continue
+		}
+		values[i], _ = json.Marshal(value)
+	}
+	return values
+}
+
+// isLiteral reports whether the enum value is encoded as a JSON literal.
+func (d Definition) isLiteral(value string) bool {
+	switch d.Type {
+	case Integer, Number:
+		_, err := strconv.ParseFloat(value, 64)
+		return err == nil && json.Valid([]byte(value))
+	case Boolean:
+		return value == "true" || value == "false"
+	}
+	return false
+}
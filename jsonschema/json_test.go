@@ -167,6 +167,33 @@ func TestDefinition_MarshalJSON(t *testing.T) { //nolint:funlen
          "properties":{}
       }
    }
+}`,
+		},
+		{
+			name: "Test with string enum",
+			def:  jsonschema.Definition{Type: jsonschema.String, Enum: []string{"1", "true", "red"}},
+			want: `{"type":"string","enum":["1","true","red"],"properties":{}}`,
+		},
+		{
+			name: "Test with integer enum",
+			def:  jsonschema.Definition{Type: jsonschema.Integer, Enum: []string{"1", "-2", "x"}},
+			want: `{"type":"integer","enum":[1,-2,"x"],"properties":{}}`,
+		},
+		{
+			name: "Test with number and boolean enums",
+			def: jsonschema.Definition{
+				Type: jsonschema.Object,
+				Properties: map[string]jsonschema.Definition{
+					"ratio": {Type: jsonschema.Number, Enum: []string{"0.5", "1e3", "NaN"}},
+					"done":  {Type: jsonschema.Boolean, Enum: []string{"true", "no"}},
+				},
+			},
+			want: `{
+   "type":"object",
+   "properties":{
+      "ratio":{"type":"number","enum":[0.5,1e3,"NaN"],"properties":{}},
+      "done":{"type":"boolean","enum":[true,"no"],"properties":{}}
+   }
 }`,
 		},
 	}
@@ -195,6 +222,49 @@ func TestDefinition_MarshalJSON(t *testing.T) { //nolint:funlen
 	}
 }
 
+func TestDefinition_UnmarshalJSON(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		data string
+		want jsonschema.Definition
+	}{
+		{
+			name: "string enum",
+			data: `{"type":"string","enum":["1","red"]}`,
+			want: jsonschema.Definition{Type: jsonschema.String, Enum: []string{"1", "red"}},
+		},
+		{
+			name: "integer enum",
+			data: `{"type":"integer","enum":[1,-2,"x"]}`,
+			want: jsonschema.Definition{Type: jsonschema.Integer, Enum: []string{"1", "-2", "x"}},
+		},
+		{
+			name: "boolean enum in property",
+			data: `{"type":"object","properties":{"done":{"type":"boolean","enum":[true]}}}`,
+			want: jsonschema.Definition{
+				Type: jsonschema.Object,
+				Properties: map[string]jsonschema.Definition{
+					"done": {Type: jsonschema.Boolean, Enum: []string{"true"}},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var got jsonschema.Definition
+			if err := json.Unmarshal([]byte(tt.data), &got); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UnmarshalJSON() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func structToMap(t *testing.T, v any) map[string]any {
 	t.Helper()
 	gotBytes, err := json.Marshal(v)
@@ -0,0 +1,269 @@
+package jsonschema
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrUnsupportedType is returned by Reflect when a type can't be described by a Definition.
+var ErrUnsupportedType = errors.New("unsupported type")
+
+var (
+	rawMessageType    = reflect.TypeOf(json.RawMessage{})
+	timeType          = reflect.TypeOf(time.Time{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// Reflect returns the Definition of the JSON encoding of v's type.
+//
+// Struct fields are named after their json tag and are required unless they are pointers or
+// tagged with omitempty. The fields of embedded structs without a json tag are promoted, as
+// encoding/json does. The `description` tag sets the description of a field and the `enum`
+// tag a comma separated list of allowed values, parsed as values of the field's kind.
+//
+// time.Time is a string with the date-time format. Other types implementing json.Marshaler
+// allow any value, and those implementing encoding.TextMarshaler are strings.
+func Reflect(v any) (Definition, error) {
+	return ReflectType(reflect.TypeOf(v))
+}
+
+// ReflectType returns the Definition of the JSON encoding of values of type t.
+func ReflectType(t reflect.Type) (Definition, error) {
+	if t == nil {
+		return Definition{}, fmt.Errorf("%w: nil", ErrUnsupportedType)
+	}
+	return reflectType(t, map[reflect.Type]bool{})
+}
+
+func reflectType(t reflect.Type, seen map[reflect.Type]bool) (Definition, error) { //nolint:cyclop
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch {
+	case t == rawMessageType:
+		return Definition{}, nil
+	case t == timeType:
+		return Definition{Type: String, Format: "date-time"}, nil
+	case implements(t, jsonMarshalerType):
+		return Definition{}, nil
+	case implements(t, textMarshalerType):
+		return Definition{Type: String}, nil
+	}
+
+	switch t.Kind() { //nolint:exhaustive
+	case reflect.String:
+		return Definition{Type: String}, nil
+	case reflect.Bool:
+		return Definition{Type: Boolean}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Definition{Type: Integer}, nil
+	case reflect.Float32, reflect.Float64:
+		return Definition{Type: Number}, nil
+	case reflect.Slice, reflect.Array:
+		items, err := reflectType(t.Elem(), seen)
+		if err != nil {
+			return Definition{}, err
+		}
+		return Definition{Type: Array, Items: &items}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return Definition{}, fmt.Errorf("%w: map key %s", ErrUnsupportedType, t.Key())
+		}
+		return Definition{Type: Object}, nil
+	case reflect.Interface:
+		return Definition{}, nil
+	case reflect.Struct:
+		return reflectStruct(t, seen)
+	default:
+		return Definition{}, fmt.Errorf("%w: %s", ErrUnsupportedType, t)
+	}
+}
+
+// implements reports whether t or a pointer to t implements iface.
+func implements(t, iface reflect.Type) bool {
+	return t.Implements(iface) || reflect.PointerTo(t).Implements(iface)
+}
+
+func reflectStruct(t reflect.Type, seen map[reflect.Type]bool) (Definition, error) {
+	if seen[t] {
+		return Definition{}, fmt.Errorf("%w: recursive type %s", ErrUnsupportedType, t)
+	}
+	seen[t] = true
+	defer delete(seen, t)
+
+	fields, err := structFields(t, seen)
+	if err != nil {
+		return Definition{}, err
+	}
+
+	def := Definition{Type: Object, Properties: map[string]Definition{}}
+	for _, f := range fields {
+		prop, err := reflectType(f.field.Type, seen)
+		if err != nil {
+			return Definition{}, fmt.Errorf("field %s: %w", f.field.Name, err)
+		}
+		prop.Description = f.field.Tag.Get("description")
+		if enum := f.field.Tag.Get("enum"); enum != "" {
+			if err := setEnum(&prop, f.field.Type, enum); err != nil {
+				return Definition{}, fmt.Errorf("field %s: %w", f.field.Name, err)
+			}
+		}
+		def.Properties[f.name] = prop
+
+		if f.required {
+			def.Required = append(def.Required, f.name)
+		}
+	}
+	return def, nil
+}
+
+// structField is a field of the JSON encoding of a struct.
+type structField struct {
+	name     string
+	field    reflect.StructField
+	required bool
+	tagged   bool
+	depth    int
+}
+
+// structFields returns the fields of the JSON encoding of t, including the
+// fields promoted from embedded structs. As in encoding/json, a field hides
+// the fields of the same name deeper in embedded structs, and fields of the
+// same name at the same depth hide each other unless exactly one is tagged.
+func structFields(t reflect.Type, seen map[reflect.Type]bool) ([]structField, error) {
+	var all []structField
+	var walk func(t reflect.Type, depth int, viaPointer bool) error
+	walk = func(t reflect.Type, depth int, viaPointer bool) error {
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name, omitEmpty, skip := parseJSONTag(field)
+			if skip {
+				continue
+			}
+
+			ft := field.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if field.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+				if seen[ft] {
+					return fmt.Errorf("%w: recursive type %s", ErrUnsupportedType, ft)
+				}
+				seen[ft] = true
+				err := walk(ft, depth+1, viaPointer || field.Type.Kind() == reflect.Pointer)
+				delete(seen, ft)
+				if err != nil {
+					return err
+				}
+				continue
+			}
+			if !field.IsExported() {
+				continue
+			}
+
+			f := structField{
+				name:     name,
+				field:    field,
+				required: !omitEmpty && !viaPointer && field.Type.Kind() != reflect.Pointer,
+				tagged:   name != "",
+				depth:    depth,
+			}
+			if !f.tagged {
+				f.name = field.Name
+			}
+			all = append(all, f)
+		}
+		return nil
+	}
+	if err := walk(t, 0, false); err != nil {
+		return nil, err
+	}
+
+	fields := make([]structField, 0, len(all))
+	for i, f := range all {
+		if dominant(i, all) {
+			fields = append(fields, f)
+		}
+	}
+	return fields, nil
+}
+
+// dominant reports whether all[i] isn't hidden by another field of the same name.
+func dominant(i int, all []structField) bool {
+	f := all[i]
+	for j, other := range all {
+		if j == i || other.name != f.name || other.depth > f.depth {
+			continue
+		}
+		if other.depth < f.depth || other.tagged == f.tagged || other.tagged {
+			return false
+		}
+	}
+	return true
+}
+
+// setEnum sets the enum of the definition of a field of type t, or of its
+// items if t is a slice, to values parsed as values of its kind.
+func setEnum(def *Definition, t reflect.Type, enum string) error {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && def.Items != nil {
+		return setEnum(def.Items, t.Elem(), enum)
+	}
+
+	values := strings.Split(enum, ",")
+	for i, value := range values {
+		value = strings.TrimSpace(value)
+		var err error
+		switch t.Kind() { //nolint:exhaustive
+		case reflect.String:
+		case reflect.Bool:
+			var b bool
+			b, err = strconv.ParseBool(value)
+			value = strconv.FormatBool(b)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			var n int64
+			n, err = strconv.ParseInt(value, 10, t.Bits())
+			value = strconv.FormatInt(n, 10)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			var n uint64
+			n, err = strconv.ParseUint(value, 10, t.Bits())
+			value = strconv.FormatUint(n, 10)
+		case reflect.Float32, reflect.Float64:
+			var f float64
+			f, err = strconv.ParseFloat(value, t.Bits())
+			value = strconv.FormatFloat(f, 'g', -1, t.Bits())
+		default:
+			return fmt.Errorf("%w: enum of %s", ErrUnsupportedType, t)
+		}
+		if err != nil {
+			return fmt.Errorf("%w: enum value %q of %s", ErrUnsupportedType, value, t)
+		}
+		values[i] = value
+	}
+	def.Enum = values
+	return nil
+}
+
+func parseJSONTag(field reflect.StructField) (name string, omitEmpty, skip bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false, true
+	}
+	name, opts, _ := strings.Cut(tag, ",")
+	for _, opt := range strings.Split(opts, ",") {
+		if opt == "omitempty" {
+			omitEmpty = true
+		}
+	}
+	return name, omitEmpty, false
+}
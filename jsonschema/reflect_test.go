@@ -0,0 +1,104 @@
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/jsonschema"
+)
+
+func TestReflect(t *testing.T) {
+	t.Parallel()
+
+	type location struct {
+		City    string `json:"city" description:"name of the city"`
+		Country string `json:"country,omitempty"`
+	}
+	type args struct {
+		Location location `json:"location"`
+		Unit     string   `json:"unit" enum:"celsius, fahrenheit"`
+		Days     *int     `json:"days"`
+		Tags     []string `json:"tags,omitempty"`
+		Ignored  string   `json:"-"`
+		private  string   //nolint:unused
+	}
+
+	def, err := jsonschema.Reflect(args{})
+	require.NoError(t, err)
+	require.Equal(t, jsonschema.Definition{
+		Type: jsonschema.Object,
+		Properties: map[string]jsonschema.Definition{
+			"location": {
+				Type: jsonschema.Object,
+				Properties: map[string]jsonschema.Definition{
+					"city":    {Type: jsonschema.String, Description: "name of the city"},
+					"country": {Type: jsonschema.String},
+				},
+				Required: []string{"city"},
+			},
+			"unit": {Type: jsonschema.String, Enum: []string{"celsius", "fahrenheit"}},
+			"days": {Type: jsonschema.Integer},
+			"tags": {Type: jsonschema.Array, Items: &jsonschema.Definition{Type: jsonschema.String}},
+		},
+		Required: []string{"location", "unit"},
+	}, def)
+
+	_, err = jsonschema.Reflect(struct{ C chan int }{})
+	require.ErrorIs(t, err, jsonschema.ErrUnsupportedType)
+	_, err = jsonschema.Reflect(struct {
+		N int `enum:"1,two"`
+	}{})
+	require.ErrorIs(t, err, jsonschema.ErrUnsupportedType)
+}
+
+type Base struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Created time.Time
+}
+
+type Audit struct {
+	By string `json:"by"`
+}
+
+func TestReflectEmbeddedAndTypedEnums(t *testing.T) {
+	t.Parallel()
+
+	type event struct {
+		Base
+		*Audit
+		Name     string  `json:"name"`
+		Priority int     `json:"priority" enum:"1, 2, 3"`
+		Scores   []uint8 `json:"scores" enum:"0,10"`
+		Done     bool    `json:"done,omitempty" enum:"true"`
+	}
+
+	def, err := jsonschema.Reflect(event{})
+	require.NoError(t, err)
+	require.Equal(t, jsonschema.Definition{
+		Type: jsonschema.Object,
+		Properties: map[string]jsonschema.Definition{
+			"id":       {Type: jsonschema.String},
+			"Created":  {Type: jsonschema.String, Format: "date-time"},
+			"by":       {Type: jsonschema.String},
+			"name":     {Type: jsonschema.String},
+			"priority": {Type: jsonschema.Integer, Enum: []string{"1", "2", "3"}},
+			"scores": {
+				Type:  jsonschema.Array,
+				Items: &jsonschema.Definition{Type: jsonschema.Integer, Enum: []string{"0", "10"}},
+			},
+			"done": {Type: jsonschema.Boolean, Enum: []string{"true"}},
+		},
+		Required: []string{"id", "Created", "name", "priority", "scores"},
+	}, def)
+
+	data, err := json.Marshal(def.Properties["priority"])
+	require.NoError(t, err)
+	require.JSONEq(t, `{"type":"integer","enum":[1,2,3],"properties":{}}`, string(data))
+
+	var decoded jsonschema.Definition
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, []string{"1", "2", "3"}, decoded.Enum)
+}
@@ -0,0 +1,109 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// ErrValidation is returned by Validate when a value doesn't match a Definition.
+var ErrValidation = errors.New("value does not match schema")
+
+// Validate checks that the JSON encoded data matches the definition. It checks the type,
+// enum, date-time format, required properties, properties and items of the definition;
+// other JSON schema keywords are not supported by Definition.
+func (d Definition) Validate(data []byte) error {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return fmt.Errorf("%w: %w", ErrValidation, err)
+	}
+	return d.validate(v, "$")
+}
+
+func (d Definition) validate(v any, path string) error { //nolint:cyclop
+	if d.Type != "" && !d.hasType(v) {
+		return fmt.Errorf("%w: %s must be of type %s", ErrValidation, path, d.Type)
+	}
+	if len(d.Enum) > 0 && !slices.ContainsFunc(d.Enum, func(value string) bool { return d.enumEqual(value, v) }) {
+		return fmt.Errorf("%w: %s must be one of %v", ErrValidation, path, d.Enum)
+	}
+	if d.Format == "date-time" {
+		if s, ok := v.(string); ok {
+			if _, err := time.Parse(time.RFC3339, s); err != nil {
+				return fmt.Errorf("%w: %s must be a date-time: %w", ErrValidation, path, err)
+			}
+		}
+	}
+
+	switch v := v.(type) {
+	case map[string]any:
+		for _, name := range d.Required {
+			if _, ok := v[name]; !ok {
+				return fmt.Errorf("%w: %s is missing required property %q", ErrValidation, path, name)
+			}
+		}
+		for name, property := range d.Properties {
+			value, ok := v[name]
+			if !ok {
+				continue
+			}
+			if err := property.validate(value, path+"."+name); err != nil {
+				return err
+			}
+		}
+	case []any:
+		if d.Items == nil {
+			return nil
+		}
+		for i, item := range v {
+			if err := d.Items.validate(item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// enumEqual reports whether v is the decoded JSON value of the enum value.
+func (d Definition) enumEqual(value string, v any) bool {
+	switch v := v.(type) {
+	case string:
+		return !d.isLiteral(value) && v == value
+	case float64:
+		f, err := strconv.ParseFloat(value, 64)
+		return d.isLiteral(value) && err == nil && f == v
+	case bool:
+		return d.isLiteral(value) && strconv.FormatBool(v) == value
+	}
+	return false
+}
+
+func (d Definition) hasType(v any) bool {
+	switch d.Type {
+	case Object:
+		_, ok := v.(map[string]any)
+		return ok
+	case Array:
+		_, ok := v.([]any)
+		return ok
+	case String:
+		_, ok := v.(string)
+		return ok
+	case Number:
+		_, ok := v.(float64)
+		return ok
+	case Integer:
+		f, ok := v.(float64)
+		return ok && f == math.Trunc(f)
+	case Boolean:
+		_, ok := v.(bool)
+		return ok
+	case Null:
+		return v == nil
+	}
+	return true
+}
@@ -0,0 +1,57 @@
+package jsonschema_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/jsonschema"
+)
+
+func TestDefinition_Validate(t *testing.T) {
+	t.Parallel()
+
+	def := jsonschema.Definition{
+		Type: jsonschema.Object,
+		Properties: map[string]jsonschema.Definition{
+			"name":  {Type: jsonschema.String},
+			"age":   {Type: jsonschema.Integer},
+			"color": {Type: jsonschema.String, Enum: []string{"red", "blue"}},
+			"tags":  {Type: jsonschema.Array, Items: &jsonschema.Definition{Type: jsonschema.String}},
+			"level": {Type: jsonschema.Integer, Enum: []string{"1", "2"}},
+			"born":  {Type: jsonschema.String, Format: "date-time"},
+		},
+		Required: []string{"name", "age"},
+	}
+
+	tests := []struct {
+		name  string
+		data  string
+		valid bool
+	}{
+		{name: "valid", data: `{"name":"Ann","age":3,"color":"red","tags":["a"]}`, valid: true},
+		{name: "optional missing", data: `{"name":"Ann","age":3}`, valid: true},
+		{name: "required missing", data: `{"name":"Ann"}`},
+		{name: "wrong type", data: `{"name":"Ann","age":"3"}`},
+		{name: "not an integer", data: `{"name":"Ann","age":3.5}`},
+		{name: "not in enum", data: `{"name":"Ann","age":3,"color":"green"}`},
+		{name: "integer in enum", data: `{"name":"Ann","age":3,"level":2}`, valid: true},
+		{name: "integer not in enum", data: `{"name":"Ann","age":3,"level":3}`},
+		{name: "string for integer enum", data: `{"name":"Ann","age":3,"level":"2"}`},
+		{name: "date-time", data: `{"name":"Ann","age":3,"born":"2020-01-02T03:04:05Z"}`, valid: true},
+		{name: "invalid date-time", data: `{"name":"Ann","age":3,"born":"yesterday"}`},
+		{name: "wrong item type", data: `{"name":"Ann","age":3,"tags":[1]}`},
+		{name: "not an object", data: `[]`},
+		{name: "invalid json", data: `{"name":`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := def.Validate([]byte(tt.data))
+			if tt.valid {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, jsonschema.ErrValidation)
+		})
+	}
+}
@@ -31,7 +31,10 @@ type LLM struct {
 	client           *anthropicclient.Client
 }
 
-var _ llms.Model = (*LLM)(nil)
+var (
+	_ llms.Model                   = (*LLM)(nil)
+	_ llms.ResponseSchemaSupporter = (*LLM)(nil)
+)
 
 // New returns a new Anthropic LLM.
 func New(opts ...Option) (*LLM, error) {
@@ -67,6 +70,13 @@ func (o *LLM) Call(ctx context.Context, prompt string, options ...llms.CallOptio
 	return llms.GenerateFromSinglePrompt(ctx, o, prompt, options...)
 }
 
+// SupportsResponseSchema implements llms.ResponseSchemaSupporter. The
+// Messages API honors response schemas by forcing the use of a tool with the
+// schema as its input.
+func (o *LLM) SupportsResponseSchema() bool {
+	return o.client != nil && !o.client.UseLegacyTextCompletionsAPI
+}
+
 // GenerateContent implements the Model interface.
 func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) {
 	if o.CallbacksHandler != nil {
@@ -125,16 +135,27 @@ func generateMessagesContent(ctx context.Context, o *LLM, messages []llms.Messag
 	}
 
 	tools := toolsToTools(opts.Tools)
+	var toolChoice *anthropicclient.ToolChoice
+	if schema := opts.ResponseSchema; schema != nil {
+		tools = append(tools, anthropicclient.Tool{
+			Name:        schema.Name,
+			Description: schema.Description,
+			InputSchema: schema.Schema,
+		})
+		toolChoice = &anthropicclient.ToolChoice{Type: "tool", Name: schema.Name}
+	}
 	result, err := o.client.CreateMessage(ctx, &anthropicclient.MessageRequest{
-		Model:         opts.Model,
-		Messages:      chatMessages,
-		System:        systemPrompt,
-		MaxTokens:     opts.MaxTokens,
-		StopWords:     opts.StopWords,
-		Temperature:   opts.Temperature,
-		TopP:          opts.TopP,
-		Tools:         tools,
-		StreamingFunc: opts.StreamingFunc,
+		Model:              opts.Model,
+		Messages:           chatMessages,
+		System:             systemPrompt,
+		MaxTokens:          opts.MaxTokens,
+		StopWords:          opts.StopWords,
+		Temperature:        opts.Temperature,
+		TopP:               opts.TopP,
+		Tools:              tools,
+		ToolChoice:         toolChoice,
+		StreamingFunc:      opts.StreamingFunc,
+		StreamingEventFunc: opts.StreamingEventFunc,
 	})
 	if err != nil {
 		if o.CallbacksHandler != nil {
@@ -165,6 +186,18 @@ func generateMessagesContent(ctx context.Context, o *LLM, messages []llms.Messag
 				if err != nil {
 					return nil, err
 				}
+				// The input of the forced response schema tool is the response.
+				if opts.ResponseSchema != nil && toolUseContent.Name == opts.ResponseSchema.Name {
+					choices[i] = &llms.ContentChoice{
+						Content:    string(argumentsJSON),
+						StopReason: result.StopReason,
+						GenerationInfo: map[string]any{
+							"InputTokens":  result.Usage.InputTokens,
+							"OutputTokens": result.Usage.OutputTokens,
+						},
+					}
+					continue
+				}
 				choices[i] = &llms.ContentChoice{
 					ToolCalls: []llms.ToolCall{
 						{
@@ -191,10 +224,23 @@ func generateMessagesContent(ctx context.Context, o *LLM, messages []llms.Messag
 
 	resp := &llms.ContentResponse{
 		Choices: choices,
+		Usage:   messagesUsage(result),
 	}
 	return resp, nil
 }
 
+// messagesUsage converts the usage reported by the Messages API. Anthropic
+// reports cache reads and writes separately from input_tokens, so they are
+// folded into PromptTokens here.
+func messagesUsage(result *anthropicclient.MessageResponsePayload) llms.Usage {
+	promptTokens := result.Usage.InputTokens +
+		result.Usage.CacheCreationInputTokens +
+		result.Usage.CacheReadInputTokens
+	usage := llms.NewUsage(promptTokens, result.Usage.OutputTokens)
+	usage.CachedTokens = result.Usage.CacheReadInputTokens
+	return usage
+}
+
 func toolsToTools(tools []llms.Tool) []anthropicclient.Tool {
 	toolReq := make([]anthropicclient.Tool, len(tools))
 	for i, tool := range tools {
@@ -51,6 +51,7 @@ func WithHTTPClient(client anthropicclient.Doer) Option {
 }
 
 // WithLegacyTextCompletionsAPI enables the use of the legacy text completions API.
+// This API doesn't report token usage, so the Usage of responses is zero.
 func WithLegacyTextCompletionsAPI() Option {
 	return func(opts *options) {
 		opts.useLegacyTextCompletionsAPI = true
@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+
+	"github.com/tmc/langchaingo/llms"
 )
 
 const (
@@ -121,25 +123,29 @@ type MessageRequest struct {
 	MaxTokens   int           `json:"max_tokens,omitempty"`
 	TopP        float64       `json:"top_p,omitempty"`
 	Tools       []Tool        `json:"tools,omitempty"`
+	ToolChoice  *ToolChoice   `json:"tool_choice,omitempty"`
 	StopWords   []string      `json:"stop_sequences,omitempty"`
 	Stream      bool          `json:"stream,omitempty"`
 
-	StreamingFunc func(ctx context.Context, chunk []byte) error `json:"-"`
+	StreamingFunc      func(ctx context.Context, chunk []byte) error `json:"-"`
+	StreamingEventFunc llms.StreamEventFunc                          `json:"-"`
 }
 
 // CreateMessage creates message for the messages api.
 func (c *Client) CreateMessage(ctx context.Context, r *MessageRequest) (*MessageResponsePayload, error) {
 	resp, err := c.createMessage(ctx, &messagePayload{
-		Model:         r.Model,
-		Messages:      r.Messages,
-		System:        r.System,
-		Temperature:   r.Temperature,
-		MaxTokens:     r.MaxTokens,
-		StopWords:     r.StopWords,
-		TopP:          r.TopP,
-		Tools:         r.Tools,
-		Stream:        r.Stream,
-		StreamingFunc: r.StreamingFunc,
+		Model:              r.Model,
+		Messages:           r.Messages,
+		System:             r.System,
+		Temperature:        r.Temperature,
+		MaxTokens:          r.MaxTokens,
+		StopWords:          r.StopWords,
+		TopP:               r.TopP,
+		Tools:              r.Tools,
+		ToolChoice:         r.ToolChoice,
+		Stream:             r.Stream,
+		StreamingFunc:      r.StreamingFunc,
+		StreamingEventFunc: r.StreamingEventFunc,
 	})
 	if err != nil {
 		return nil, err
@@ -187,7 +193,7 @@ func (c *Client) decodeError(resp *http.Response) error {
 
 	var errResp errorMessage
 	if err := json.NewDecoder(resp.Body).Decode(&errResp); err != nil {
-		return errors.New(msg) // nolint:goerr113
+		return llms.NewStatusError(resp, msg)
 	}
-	return fmt.Errorf("%s: %s", msg, errResp.Error.Message) // nolint:goerr113
+	return llms.NewStatusError(resp, fmt.Sprintf("%s: %s", msg, errResp.Error.Message))
 }
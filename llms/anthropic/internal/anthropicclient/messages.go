@@ -10,6 +10,8 @@ import (
 	"log"
 	"net/http"
 	"strings"
+
+	"github.com/tmc/langchaingo/llms"
 )
 
 type ChatMessage struct {
@@ -26,9 +28,11 @@ type messagePayload struct {
 	Stream      bool          `json:"stream,omitempty"`
 	Temperature float64       `json:"temperature"`
 	Tools       []Tool        `json:"tools,omitempty"`
+	ToolChoice  *ToolChoice   `json:"tool_choice,omitempty"`
 	TopP        float64       `json:"top_p,omitempty"`
 
-	StreamingFunc func(ctx context.Context, chunk []byte) error `json:"-"`
+	StreamingFunc      func(ctx context.Context, chunk []byte) error `json:"-"`
+	StreamingEventFunc llms.StreamEventFunc                          `json:"-"`
 }
 
 // Tool used for the request message payload.
@@ -38,6 +42,14 @@ type Tool struct {
 	InputSchema any    `json:"input_schema,omitempty"`
 }
 
+// ToolChoice controls how the model uses the tools of the request.
+type ToolChoice struct {
+	// Type is one of "auto", "any" or "tool".
+	Type string `json:"type"`
+	// Name is the name of the tool to use when Type is "tool".
+	Name string `json:"name,omitempty"`
+}
+
 // Content can be TextContent or ToolUseContent depending on the type.
 type Content interface {
 	GetType() string
@@ -72,8 +84,10 @@ type MessageResponsePayload struct {
 	StopSequence string    `json:"stop_sequence"`
 	Type         string    `json:"type"`
 	Usage        struct {
-		InputTokens  int `json:"input_tokens"`
-		OutputTokens int `json:"output_tokens"`
+		InputTokens              int `json:"input_tokens"`
+		OutputTokens             int `json:"output_tokens"`
+		CacheCreationInputTokens int `json:"cache_creation_input_tokens"`
+		CacheReadInputTokens     int `json:"cache_read_input_tokens"`
 	} `json:"usage"`
 }
 
@@ -139,7 +153,7 @@ func (c *Client) setMessageDefaults(payload *messagePayload) {
 	default:
 		payload.Model = defaultModel
 	}
-	if payload.StreamingFunc != nil {
+	if payload.StreamingFunc != nil || payload.StreamingEventFunc != nil {
 		payload.Stream = true
 	}
 }
@@ -162,7 +176,7 @@ func (c *Client) createMessage(ctx context.Context, payload *messagePayload) (*M
 		return nil, c.decodeError(resp)
 	}
 
-	if payload.StreamingFunc != nil {
+	if payload.Stream {
 		return parseStreamingMessageResponse(ctx, resp, payload)
 	}
 
@@ -186,6 +200,7 @@ func parseStreamingMessageResponse(ctx context.Context, r *http.Response, payloa
 	go func() {
 		defer close(eventChan)
 		var response MessageResponsePayload
+		state := newStreamState(payload)
 		for scanner.Scan() {
 			line := scanner.Text()
 			if line == "" || !strings.HasPrefix(line, "data:") {
@@ -197,7 +212,7 @@ func parseStreamingMessageResponse(ctx context.Context, r *http.Response, payloa
 				eventChan <- MessageEvent{Response: nil, Err: fmt.Errorf("failed to parse stream event: %w", err)}
 				return
 			}
-			response, err = processStreamEvent(ctx, event, payload, response, eventChan)
+			response, err = processStreamEvent(ctx, event, payload, state, response, eventChan)
 			if err != nil {
 				eventChan <- MessageEvent{Response: nil, Err: fmt.Errorf("failed to process stream event: %w", err)}
 				return
@@ -224,7 +239,36 @@ func parseStreamEvent(data string) (map[string]interface{}, error) {
 	return event, err
 }
 
-func processStreamEvent(ctx context.Context, event map[string]interface{}, payload *messagePayload, response MessageResponsePayload, eventChan chan<- MessageEvent) (MessageResponsePayload, error) {
+// streamState is the state of a streaming response that is not part of the
+// response payload.
+type streamState struct {
+	events llms.StreamEventFunc
+	// toolInputs holds the partial JSON input of the tool use blocks by
+	// content block index.
+	toolInputs map[int]*strings.Builder
+	// toolIndexes maps content block indexes to tool call positions.
+	toolIndexes map[int]int
+}
+
+func newStreamState(payload *messagePayload) *streamState {
+	return &streamState{
+		events:      payload.StreamingEventFunc,
+		toolInputs:  map[int]*strings.Builder{},
+		toolIndexes: map[int]int{},
+	}
+}
+
+func (s *streamState) send(ctx context.Context, event llms.StreamEvent) error {
+	if s.events == nil {
+		return nil
+	}
+	if err := s.events(ctx, event); err != nil {
+		return fmt.Errorf("streaming event func returned an error: %w", err)
+	}
+	return nil
+}
+
+func processStreamEvent(ctx context.Context, event map[string]interface{}, payload *messagePayload, state *streamState, response MessageResponsePayload, eventChan chan<- MessageEvent) (MessageResponsePayload, error) {
 	eventType, ok := event["type"].(string)
 	if !ok {
 		return response, errors.New("invalid event type field type")
@@ -233,13 +277,13 @@ func processStreamEvent(ctx context.Context, event map[string]interface{}, paylo
 	case "message_start":
 		return handleMessageStartEvent(event, response)
 	case "content_block_start":
-		return handleContentBlockStartEvent(event, response)
+		return handleContentBlockStartEvent(ctx, event, state, response)
 	case "content_block_delta":
-		return handleContentBlockDeltaEvent(ctx, event, response, payload)
+		return handleContentBlockDeltaEvent(ctx, event, response, payload, state)
 	case "content_block_stop":
-		// Nothing to do here
+		return handleContentBlockStopEvent(ctx, event, state, response)
 	case "message_delta":
-		return handleMessageDeltaEvent(event, response)
+		return handleMessageDeltaEvent(ctx, event, state, response)
 	case "message_stop":
 		eventChan <- MessageEvent{Response: &response, Err: nil}
 	case "ping":
@@ -271,24 +315,53 @@ func handleMessageStartEvent(event map[string]interface{}, response MessageRespo
 	response.Role = getString(message, "role")
 	response.Type = getString(message, "type")
 	response.Usage.InputTokens = int(inputTokens)
+	if cacheCreation, ok := usage["cache_creation_input_tokens"].(float64); ok {
+		response.Usage.CacheCreationInputTokens = int(cacheCreation)
+	}
+	if cacheRead, ok := usage["cache_read_input_tokens"].(float64); ok {
+		response.Usage.CacheReadInputTokens = int(cacheRead)
+	}
 
 	return response, nil
 }
 
-func handleContentBlockStartEvent(event map[string]interface{}, response MessageResponsePayload) (MessageResponsePayload, error) {
+func handleContentBlockStartEvent(ctx context.Context, event map[string]interface{}, state *streamState, response MessageResponsePayload) (MessageResponsePayload, error) {
 	indexValue, ok := event["index"].(float64)
 	if !ok {
 		return response, errors.New("invalid index field type")
 	}
 	index := int(indexValue)
+	if len(response.Content) > index {
+		return response, nil
+	}
 
-	if len(response.Content) <= index {
-		response.Content = append(response.Content, TextContent{})
+	block, _ := event["content_block"].(map[string]interface{})
+	blockType := getString(block, "type")
+	if blockType != "tool_use" {
+		response.Content = append(response.Content, TextContent{
+			Type: blockType,
+			Text: getString(block, "text"),
+		})
+		return response, nil
 	}
-	return response, nil
+
+	toolUse := ToolUseContent{
+		Type: blockType,
+		ID:   getString(block, "id"),
+		Name: getString(block, "name"),
+	}
+	response.Content = append(response.Content, toolUse)
+	state.toolInputs[index] = &strings.Builder{}
+	state.toolIndexes[index] = len(state.toolIndexes)
+
+	return response, state.send(ctx, llms.StreamEvent{
+		Type:     llms.StreamEventToolCallStart,
+		Index:    state.toolIndexes[index],
+		ToolCall: llmsToolCall(toolUse, ""),
+	})
 }
 
-func handleContentBlockDeltaEvent(ctx context.Context, event map[string]interface{}, response MessageResponsePayload, payload *messagePayload) (MessageResponsePayload, error) {
+func handleContentBlockDeltaEvent(ctx context.Context, event map[string]interface{}, response MessageResponsePayload, payload *messagePayload, state *streamState) (MessageResponsePayload, error) {
 	indexValue, ok := event["index"].(float64)
 	if !ok {
 		return response, errors.New("invalid index field type")
@@ -303,45 +376,97 @@ func handleContentBlockDeltaEvent(ctx context.Context, event map[string]interfac
 	if !ok {
 		return response, errors.New("invalid delta type field type")
 	}
+	if len(response.Content) <= index {
+		return response, errors.New("content index out of range")
+	}
 
-	if deltaType == "text_delta" {
+	switch deltaType {
+	case "text_delta":
 		text, ok := delta["text"].(string)
 		if !ok {
 			return response, errors.New("invalid delta text field type")
 		}
-		if len(response.Content) <= index {
-			return response, errors.New("content index out of range")
-		}
-		if response.Content[index].GetType() != "text" {
+		textContent, ok := response.Content[index].(TextContent)
+		if !ok || textContent.GetType() != "text" {
 			return response, errors.New("invalid content type")
 		}
-		textContent, ok := response.Content[index].(*TextContent)
-		if !ok {
-			return response, errors.New("failed to cast content to TextContent")
-		}
 		textContent.Text += text
-	}
+		response.Content[index] = textContent
 
-	if payload.StreamingFunc != nil {
-		text, ok := delta["text"].(string)
+		if payload.StreamingFunc != nil {
+			err := payload.StreamingFunc(ctx, []byte(text))
+			if err != nil {
+				return response, fmt.Errorf("streaming func returned an error: %w", err)
+			}
+		}
+		return response, state.send(ctx, llms.StreamEvent{Type: llms.StreamEventTextDelta, Text: text})
+	case "thinking_delta":
+		return response, state.send(ctx, llms.StreamEvent{
+			Type: llms.StreamEventReasoningDelta,
+			Text: getString(delta, "thinking"),
+		})
+	case "input_json_delta":
+		toolUse, ok := response.Content[index].(ToolUseContent)
 		if !ok {
-			return response, errors.New("invalid delta text field type")
+			return response, errors.New("invalid content type")
 		}
-		err := payload.StreamingFunc(ctx, []byte(text))
-		if err != nil {
-			return response, fmt.Errorf("streaming func returned an error: %w", err)
+		partial := getString(delta, "partial_json")
+		state.toolInputs[index].WriteString(partial)
+		if partial == "" {
+			return response, nil
 		}
+		return response, state.send(ctx, llms.StreamEvent{
+			Type:     llms.StreamEventToolCallDelta,
+			Index:    state.toolIndexes[index],
+			ToolCall: llmsToolCall(toolUse, partial),
+		})
 	}
 	return response, nil
 }
 
-func handleMessageDeltaEvent(event map[string]interface{}, response MessageResponsePayload) (MessageResponsePayload, error) {
+func handleContentBlockStopEvent(ctx context.Context, event map[string]interface{}, state *streamState, response MessageResponsePayload) (MessageResponsePayload, error) {
+	indexValue, ok := event["index"].(float64)
+	if !ok {
+		return response, errors.New("invalid index field type")
+	}
+	index := int(indexValue)
+
+	input, ok := state.toolInputs[index]
+	if !ok || len(response.Content) <= index {
+		return response, nil
+	}
+	toolUse, ok := response.Content[index].(ToolUseContent)
+	if !ok {
+		return response, errors.New("invalid content type")
+	}
+
+	arguments := input.String()
+	if arguments == "" {
+		arguments = "{}"
+	}
+	if err := json.Unmarshal([]byte(arguments), &toolUse.Input); err != nil {
+		return response, fmt.Errorf("invalid tool input: %w", err)
+	}
+	response.Content[index] = toolUse
+
+	return response, state.send(ctx, llms.StreamEvent{
+		Type:     llms.StreamEventToolCallEnd,
+		Index:    state.toolIndexes[index],
+		ToolCall: llmsToolCall(toolUse, arguments),
+	})
+}
+
+func handleMessageDeltaEvent(ctx context.Context, event map[string]interface{}, state *streamState, response MessageResponsePayload) (MessageResponsePayload, error) {
 	delta, ok := event["delta"].(map[string]interface{})
 	if !ok {
 		return response, errors.New("invalid delta field type")
 	}
 	if stopReason, ok := delta["stop_reason"].(string); ok {
 		response.StopReason = stopReason
+		err := state.send(ctx, llms.StreamEvent{Type: llms.StreamEventStop, StopReason: stopReason})
+		if err != nil {
+			return response, err
+		}
 	}
 
 	usage, ok := event["usage"].(map[string]interface{})
@@ -351,7 +476,21 @@ func handleMessageDeltaEvent(event map[string]interface{}, response MessageRespo
 	if outputTokens, ok := usage["output_tokens"].(float64); ok {
 		response.Usage.OutputTokens = int(outputTokens)
 	}
-	return response, nil
+
+	promptTokens := response.Usage.InputTokens +
+		response.Usage.CacheCreationInputTokens +
+		response.Usage.CacheReadInputTokens
+	streamUsage := llms.NewUsage(promptTokens, response.Usage.OutputTokens)
+	streamUsage.CachedTokens = response.Usage.CacheReadInputTokens
+	return response, state.send(ctx, llms.StreamEvent{Type: llms.StreamEventUsage, Usage: &streamUsage})
+}
+
+func llmsToolCall(toolUse ToolUseContent, arguments string) *llms.ToolCall {
+	return &llms.ToolCall{
+		ID:           toolUse.ID,
+		Type:         "function",
+		FunctionCall: &llms.FunctionCall{Name: toolUse.Name, Arguments: arguments},
+	}
 }
 
 func getString(m map[string]interface{}, key string) string {
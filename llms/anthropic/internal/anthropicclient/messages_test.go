@@ -0,0 +1,109 @@
+package anthropicclient
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/llms"
+)
+
+func TestParseStreamingMessageResponse_Events(t *testing.T) {
+	t.Parallel()
+	// The text block 0 and the tool use blocks 1 and 2 are interleaved.
+	mockBody := `data: {"type":"message_start","message":{"id":"msg_1","type":"message","role":"assistant","model":"claude","usage":{"input_tokens":10,"cache_creation_input_tokens":2,"cache_read_input_tokens":3}}}
+data: {"type":"content_block_start","index":0,"content_block":{"type":"text","text":""}}
+data: {"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"Checking "}}
+data: {"type":"content_block_start","index":1,"content_block":{"type":"tool_use","id":"toolu_1","name":"weather"}}
+data: {"type":"content_block_delta","index":1,"delta":{"type":"input_json_delta","partial_json":"{\"city\":"}}
+data: {"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"both."}}
+data: {"type":"content_block_start","index":2,"content_block":{"type":"tool_use","id":"toolu_2","name":"time"}}
+data: {"type":"content_block_delta","index":1,"delta":{"type":"input_json_delta","partial_json":"\"Paris\"}"}}
+data: {"type":"content_block_stop","index":0}
+data: {"type":"content_block_stop","index":2}
+data: {"type":"content_block_stop","index":1}
+data: {"type":"ping"}
+data: {"type":"message_delta","delta":{},"usage":{"output_tokens":4}}
+data: {"type":"message_delta","delta":{"stop_reason":"tool_use"},"usage":{"output_tokens":7}}
+data: {"type":"message_stop"}`
+	r := &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString(mockBody)),
+	}
+
+	var events []llms.StreamEvent
+	var chunks []string
+	payload := &messagePayload{
+		Stream: true,
+		StreamingFunc: func(_ context.Context, chunk []byte) error {
+			chunks = append(chunks, string(chunk))
+			return nil
+		},
+		StreamingEventFunc: func(_ context.Context, event llms.StreamEvent) error {
+			events = append(events, event)
+			return nil
+		},
+	}
+
+	resp, err := parseStreamingMessageResponse(context.Background(), r, payload)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Checking ", "both."}, chunks)
+
+	toolCall := func(id, name, arguments string) *llms.ToolCall {
+		return &llms.ToolCall{ID: id, Type: "function", FunctionCall: &llms.FunctionCall{Name: name, Arguments: arguments}}
+	}
+	firstUsage := llms.Usage{PromptTokens: 15, CompletionTokens: 4, TotalTokens: 19, CachedTokens: 3}
+	lastUsage := llms.Usage{PromptTokens: 15, CompletionTokens: 7, TotalTokens: 22, CachedTokens: 3}
+	require.Equal(t, []llms.StreamEvent{
+		{Type: llms.StreamEventTextDelta, Text: "Checking "},
+		{Type: llms.StreamEventToolCallStart, Index: 0, ToolCall: toolCall("toolu_1", "weather", "")},
+		{Type: llms.StreamEventToolCallDelta, Index: 0, ToolCall: toolCall("toolu_1", "weather", `{"city":`)},
+		{Type: llms.StreamEventTextDelta, Text: "both."},
+		{Type: llms.StreamEventToolCallStart, Index: 1, ToolCall: toolCall("toolu_2", "time", "")},
+		{Type: llms.StreamEventToolCallDelta, Index: 0, ToolCall: toolCall("toolu_1", "weather", `"Paris"}`)},
+		{Type: llms.StreamEventToolCallEnd, Index: 1, ToolCall: toolCall("toolu_2", "time", "{}")},
+		{Type: llms.StreamEventToolCallEnd, Index: 0, ToolCall: toolCall("toolu_1", "weather", `{"city":"Paris"}`)},
+		{Type: llms.StreamEventUsage, Usage: &firstUsage},
+		{Type: llms.StreamEventStop, StopReason: "tool_use"},
+		{Type: llms.StreamEventUsage, Usage: &lastUsage},
+	}, events)
+
+	require.Equal(t, "tool_use", resp.StopReason)
+	require.Equal(t, 7, resp.Usage.OutputTokens)
+	require.Len(t, resp.Content, 3)
+	require.Equal(t, TextContent{Type: "text", Text: "Checking both."}, resp.Content[0])
+	require.Equal(t, ToolUseContent{
+		Type: "tool_use", ID: "toolu_1", Name: "weather", Input: map[string]any{"city": "Paris"},
+	}, resp.Content[1])
+	require.Equal(t, ToolUseContent{Type: "tool_use", ID: "toolu_2", Name: "time", Input: map[string]any{}}, resp.Content[2])
+}
+
+func TestParseStreamingMessageResponse_EventFuncOnly(t *testing.T) {
+	t.Parallel()
+	mockBody := `data: {"type":"message_start","message":{"usage":{"input_tokens":1}}}
+data: {"type":"content_block_start","index":0,"content_block":{"type":"text","text":""}}
+data: {"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"hi"}}
+data: {"type":"message_delta","delta":{"stop_reason":"end_turn"},"usage":{"output_tokens":1}}
+data: {"type":"message_stop"}`
+	r := &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString(mockBody)),
+	}
+
+	var types []llms.StreamEventType
+	payload := &messagePayload{
+		Stream: true,
+		StreamingEventFunc: func(_ context.Context, event llms.StreamEvent) error {
+			types = append(types, event.Type)
+			return nil
+		},
+	}
+
+	resp, err := parseStreamingMessageResponse(context.Background(), r, payload)
+	require.NoError(t, err)
+	require.Equal(t, []llms.StreamEventType{llms.StreamEventTextDelta, llms.StreamEventStop, llms.StreamEventUsage}, types)
+	require.Equal(t, "end_turn", resp.StopReason)
+}
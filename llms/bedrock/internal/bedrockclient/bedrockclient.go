@@ -3,9 +3,13 @@ package bedrockclient
 import (
 	"context"
 	"errors"
+	"strconv"
 	"strings"
 
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
 	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
 	"github.com/tmc/langchaingo/llms"
 )
 
@@ -63,6 +67,19 @@ func (c *Client) CreateCompletion(ctx context.Context,
 	}
 }
 
+// usageFromMetadata reads the token counts Bedrock reports in the
+// X-Amzn-Bedrock-*-Token-Count response headers. It is used for models that
+// don't include usage in the response body.
+func usageFromMetadata(metadata middleware.Metadata) llms.Usage {
+	rawResponse, ok := awsmiddleware.GetRawResponse(metadata).(*smithyhttp.Response)
+	if !ok {
+		return llms.Usage{}
+	}
+	inputTokens, _ := strconv.Atoi(rawResponse.Header.Get("X-Amzn-Bedrock-Input-Token-Count"))
+	outputTokens, _ := strconv.Atoi(rawResponse.Header.Get("X-Amzn-Bedrock-Output-Token-Count"))
+	return llms.NewUsage(inputTokens, outputTokens)
+}
+
 // Helper function to process input text chat
 // messages as a single string.
 func processInputMessagesGeneric(messages []Message) string {
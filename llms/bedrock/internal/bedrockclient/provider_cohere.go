@@ -117,5 +117,6 @@ func createCohereCompletion(ctx context.Context,
 
 	return &llms.ContentResponse{
 		Choices: choices,
+		Usage:   usageFromMetadata(resp.ResultMetadata),
 	}, nil
 }
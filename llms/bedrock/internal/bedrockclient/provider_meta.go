@@ -94,5 +94,6 @@ func createMetaCompletion(ctx context.Context,
 				},
 			},
 		},
+		Usage: llms.NewUsage(output.PromptTokenCount, output.GenerationTokenCount),
 	}, nil
 }
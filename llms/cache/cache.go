@@ -35,6 +35,12 @@ func New(llm llms.Model, backend Backend) *Cacher {
 	}
 }
 
+// SupportsResponseSchema implements llms.ResponseSchemaSupporter, reporting
+// whether the wrapped model supports response schemas.
+func (c *Cacher) SupportsResponseSchema() bool {
+	return llms.SupportsResponseSchema(c.llm)
+}
+
 // Call is a simplified interface for a text-only Model, generating a single
 // string response from a single string prompt.
 //
@@ -67,6 +73,13 @@ func (c *Cacher) GenerateContent(ctx context.Context, messages []llms.MessageCon
 				return nil, err
 			}
 		}
+		if opts.StreamingEventFunc != nil && len(response.Choices) > 0 {
+			for _, event := range streamEvents(response) {
+				if err := opts.StreamingEventFunc(ctx, event); err != nil {
+					return nil, err
+				}
+			}
+		}
 
 		return response, nil
 	}
@@ -81,6 +94,36 @@ func (c *Cacher) GenerateContent(ctx context.Context, messages []llms.MessageCon
 	return response, nil
 }
 
+// streamEvents returns the events replaying the first choice of a cached
+// response: its text, its tool calls, the usage and the stop reason. Reasoning
+// isn't part of responses, so it isn't replayed.
+func streamEvents(response *llms.ContentResponse) []llms.StreamEvent {
+	choice := response.Choices[0]
+	var events []llms.StreamEvent
+	if choice.Content != "" {
+		events = append(events, llms.StreamEvent{Type: llms.StreamEventTextDelta, Text: choice.Content})
+	}
+	for i, toolCall := range choice.ToolCalls {
+		start := toolCall
+		if toolCall.FunctionCall != nil {
+			start.FunctionCall = &llms.FunctionCall{Name: toolCall.FunctionCall.Name}
+		}
+		events = append(events, llms.StreamEvent{Type: llms.StreamEventToolCallStart, Index: i, ToolCall: &start})
+		if toolCall.FunctionCall != nil && toolCall.FunctionCall.Arguments != "" {
+			events = append(events, llms.StreamEvent{Type: llms.StreamEventToolCallDelta, Index: i, ToolCall: &toolCall})
+		}
+		events = append(events, llms.StreamEvent{Type: llms.StreamEventToolCallEnd, Index: i, ToolCall: &toolCall})
+	}
+	if !response.Usage.IsZero() {
+		usage := response.Usage
+		events = append(events, llms.StreamEvent{Type: llms.StreamEventUsage, Usage: &usage})
+	}
+	if choice.StopReason != "" {
+		events = append(events, llms.StreamEvent{Type: llms.StreamEventStop, StopReason: choice.StopReason})
+	}
+	return events
+}
+
 // hashKeyForCache is a helper function that generates a unique key for a given
 // set of messages and call options.
 func hashKeyForCache(messages []llms.MessageContent, opts llms.CallOptions) (string, error) {
@@ -172,3 +172,48 @@ func TestCache_Call_Streaming(t *testing.T) {
 	rq.True(mockCache.hit)
 	rq.True(stream)
 }
+
+func TestCache_StreamingEvents(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	rq := require.New(t)
+
+	toolCall := llms.ToolCall{
+		ID:           "call_1",
+		Type:         "function",
+		FunctionCall: &llms.FunctionCall{Name: "weather", Arguments: `{"city":"Paris"}`},
+	}
+	exp := &llms.ContentResponse{
+		Choices: []*llms.ContentChoice{{
+			Content:    "Checking.",
+			StopReason: "tool_use",
+			ToolCalls:  []llms.ToolCall{toolCall},
+		}},
+		Usage: llms.NewUsage(10, 5),
+	}
+	llm := New(newMockLLM(exp, nil), newMockCache())
+
+	_, err := llm.GenerateContent(ctx, []llms.MessageContent{llms.TextParts(llms.ChatMessageTypeHuman, "hi")})
+	rq.NoError(err)
+
+	var events []llms.StreamEvent
+	_, err = llm.GenerateContent(ctx, []llms.MessageContent{llms.TextParts(llms.ChatMessageTypeHuman, "hi")},
+		llms.WithStreamingEventFunc(func(_ context.Context, event llms.StreamEvent) error {
+			events = append(events, event)
+			return nil
+		}))
+	rq.NoError(err)
+
+	usage := exp.Usage
+	rq.Equal([]llms.StreamEvent{
+		{Type: llms.StreamEventTextDelta, Text: "Checking."},
+		{Type: llms.StreamEventToolCallStart, ToolCall: &llms.ToolCall{
+			ID: "call_1", Type: "function", FunctionCall: &llms.FunctionCall{Name: "weather"},
+		}},
+		{Type: llms.StreamEventToolCallDelta, ToolCall: &toolCall},
+		{Type: llms.StreamEventToolCallEnd, ToolCall: &toolCall},
+		{Type: llms.StreamEventUsage, Usage: &usage},
+		{Type: llms.StreamEventStop, StopReason: "tool_use"},
+	}, events)
+}
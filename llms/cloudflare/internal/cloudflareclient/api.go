@@ -9,6 +9,8 @@ import (
 	"io"
 	"net/http"
 	"strings"
+
+	"github.com/tmc/langchaingo/llms"
 )
 
 // CreateEmbedding creates an embedding from the given texts.
@@ -38,7 +40,7 @@ func (c *Client) CreateEmbedding(ctx context.Context, texts *CreateEmbeddingRequ
 	}
 
 	if resp.StatusCode > 299 {
-		return nil, fmt.Errorf("error: %s", body)
+		return nil, llms.NewStatusError(resp, fmt.Sprintf("error: %s", body))
 	}
 
 	var createEmbeddingResponse CreateEmbeddingResponse
@@ -82,7 +84,7 @@ func (c *Client) GenerateContent(ctx context.Context, request *GenerateContentRe
 		}
 
 		if response.StatusCode > 299 {
-			return nil, fmt.Errorf("error: %s", body)
+			return nil, llms.NewStatusError(response, fmt.Sprintf("error: %s", body))
 		}
 
 		var generateResponse GenerateContentResponse
@@ -98,6 +100,7 @@ func (c *Client) GenerateContent(ctx context.Context, request *GenerateContentRe
 	// increase the buffer size to avoid running out of space
 	scanBuf := make([]byte, 0, maxBufferSize)
 	scanner.Buffer(scanBuf, maxBufferSize)
+	var streamed GenerateContentResponse
 	for scanner.Scan() {
 		var streamingResponse StreamingResponse
 
@@ -134,9 +137,13 @@ func (c *Client) GenerateContent(ctx context.Context, request *GenerateContentRe
 		if err = request.StreamingFunc(ctx, bts); err != nil {
 			return nil, err
 		}
+
+		if streamingResponse.Usage != nil {
+			streamed.Result.Usage = streamingResponse.Usage
+		}
 	}
 
-	return &GenerateContentResponse{}, nil
+	return &streamed, nil
 }
 
 // Summarize summarizes the given input text.
@@ -166,7 +173,7 @@ func (c *Client) Summarize(ctx context.Context, inputText string, maxLength int)
 	}
 
 	if resp.StatusCode > 299 {
-		return nil, fmt.Errorf("error: %s", body)
+		return nil, llms.NewStatusError(resp, fmt.Sprintf("error: %s", body))
 	}
 
 	var summarizeResponse SummarizeResponse
@@ -67,9 +67,7 @@ func TestClient_GenerateContent(t *testing.T) { // nolint:funlen
 				},
 			},
 			want: &GenerateContentResponse{
-				Result: struct {
-					Response string `json:"response"`
-				}{
+				Result: GenerateContentResult{
 					Response: "response",
 				},
 			},
@@ -106,9 +104,7 @@ func TestClient_GenerateContent(t *testing.T) { // nolint:funlen
 				},
 			},
 			want: &GenerateContentResponse{
-				Result: struct {
-					Response string `json:"response"`
-				}{
+				Result: GenerateContentResult{
 					Response: "",
 				},
 			},
@@ -17,17 +17,28 @@ type Message struct {
 }
 
 type GenerateContentResponse struct {
-	Errors   []APIError `json:"errors"`
-	Messages []string   `json:"messages"`
-	Result   struct {
-		Response string `json:"response"`
-	} `json:"result"`
-	Success bool `json:"success"`
+	Errors   []APIError            `json:"errors"`
+	Messages []string              `json:"messages"`
+	Result   GenerateContentResult `json:"result"`
+	Success  bool                  `json:"success"`
+}
+
+type GenerateContentResult struct {
+	Response string `json:"response"`
+	Usage    *Usage `json:"usage,omitempty"`
 }
 
 type StreamingResponse struct {
 	Response string `json:"response"`
 	P        string `json:"p"`
+	Usage    *Usage `json:"usage,omitempty"`
+}
+
+// Usage is the token usage reported by models that support it.
+type Usage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
 }
 
 type APIError struct {
@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/cohere-ai/tokenizer"
+	"github.com/tmc/langchaingo/llms"
 )
 
 var (
@@ -69,7 +70,9 @@ type GenerationRequest struct {
 }
 
 type Generation struct {
-	Text string `json:"text"`
+	Text         string `json:"text"`
+	InputTokens  int    `json:"input_tokens"`
+	OutputTokens int    `json:"output_tokens"`
 }
 
 type generateRequestPayload struct {
@@ -84,6 +87,12 @@ type generateResponsePayload struct {
 		ID   string `json:"id,omitempty"`
 		Text string `json:"text,omitempty"`
 	} `json:"generations,omitempty"`
+	Meta struct {
+		BilledUnits struct {
+			InputTokens  int `json:"input_tokens"`
+			OutputTokens int `json:"output_tokens"`
+		} `json:"billed_units"`
+	} `json:"meta"`
 }
 
 func (c *Client) CreateGeneration(ctx context.Context, r *GenerationRequest) (*Generation, error) {
@@ -122,6 +131,9 @@ func (c *Client) CreateGeneration(ctx context.Context, r *GenerationRequest) (*G
 
 	var response generateResponsePayload
 	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		if res.StatusCode >= http.StatusBadRequest {
+			return nil, llms.NewStatusError(res, fmt.Sprintf("API returned unexpected status code: %d", res.StatusCode))
+		}
 		return nil, fmt.Errorf("parse response: %w", err)
 	}
 
@@ -129,11 +141,17 @@ func (c *Client) CreateGeneration(ctx context.Context, r *GenerationRequest) (*G
 		if strings.HasPrefix(response.Message, "model not found") {
 			return nil, ErrModelNotFound
 		}
+		if res.StatusCode >= http.StatusBadRequest {
+			return nil, llms.NewStatusError(res, fmt.Sprintf("API returned unexpected status code: %d: %s",
+				res.StatusCode, response.Message))
+		}
 		return nil, ErrEmptyResponse
 	}
 
 	var generation Generation
 	generation.Text = response.Generations[0].Text
+	generation.InputTokens = response.Meta.BilledUnits.InputTokens
+	generation.OutputTokens = response.Meta.BilledUnits.OutputTokens
 
 	return &generation, nil
 }
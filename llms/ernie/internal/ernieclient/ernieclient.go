@@ -9,8 +9,11 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
+
+	"github.com/tmc/langchaingo/llms"
 )
 
 var (
@@ -206,7 +209,7 @@ func (c *Client) CreateCompletion(ctx context.Context, modelPath ModelPath, r *C
 
 	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("%w: %d", ErrCompletionCode, resp.StatusCode)
+		return nil, fmt.Errorf("%w: %w", ErrCompletionCode, statusError(resp))
 	}
 
 	if r.Stream {
@@ -242,7 +245,7 @@ func (c *Client) CreateEmbedding(ctx context.Context, texts []string) (*Embeddin
 
 	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("%w: %d", ErrEmbeddingCode, resp.StatusCode)
+		return nil, fmt.Errorf("%w: %w", ErrEmbeddingCode, statusError(resp))
 	}
 
 	var response EmbeddingResponse
@@ -267,7 +270,7 @@ func (c *Client) getAccessToken(ctx context.Context) (*authResponse, error) {
 
 	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("%w: %d", ErrAccessTokenCode, resp.StatusCode)
+		return nil, fmt.Errorf("%w: %w", ErrAccessTokenCode, statusError(resp))
 	}
 
 	var response authResponse
@@ -354,3 +357,8 @@ func (c *Client) buildURL(modelpath ModelPath) string {
 func (c *Client) setHeaders(req *http.Request) {
 	req.Header.Set("Content-Type", "application/json")
 }
+
+// statusError returns an llms.StatusError with the status code of resp as message.
+func statusError(resp *http.Response) error {
+	return llms.NewStatusError(resp, strconv.Itoa(resp.StatusCode))
+}
@@ -0,0 +1,49 @@
+package llms
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// StatusError is returned by model clients when the provider's API responds
+// with an unexpected HTTP status. It lets callers such as llms/retry decide
+// whether a failed call is worth retrying.
+type StatusError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// RetryAfter is the delay requested by the Retry-After header of the
+	// response, or zero if there was none.
+	RetryAfter time.Duration
+	// Message describes the error.
+	Message string
+}
+
+// NewStatusError creates a StatusError with message from the status code and
+// Retry-After header of resp.
+func NewStatusError(resp *http.Response, message string) *StatusError {
+	return &StatusError{
+		StatusCode: resp.StatusCode,
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+		Message:    message,
+	}
+}
+
+func (e *StatusError) Error() string {
+	return e.Message
+}
+
+// parseRetryAfter parses a Retry-After header value, which is either a number
+// of seconds or an HTTP date.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
@@ -122,6 +122,61 @@ func (ToolCallResponse) isPart() {}
 // It can potentially return multiple content choices.
 type ContentResponse struct {
 	Choices []*ContentChoice
+
+	// Usage is the token usage reported by the provider for the whole
+	// request. It is the zero value if the provider doesn't report usage,
+	// which is the case of anthropic with WithLegacyTextCompletionsAPI,
+	// huggingface and local.
+	Usage Usage
+}
+
+// Usage is the number of tokens consumed by a GenerateContent call.
+type Usage struct {
+	// PromptTokens is the number of tokens in the input, including any
+	// cached tokens.
+	PromptTokens int
+
+	// CompletionTokens is the number of tokens generated by the model,
+	// including any reasoning tokens.
+	CompletionTokens int
+
+	// TotalTokens is the total number of tokens billed for the request.
+	TotalTokens int
+
+	// CachedTokens is the number of prompt tokens served from the
+	// provider's prompt cache.
+	CachedTokens int
+
+	// ReasoningTokens is the number of completion tokens the model spent on
+	// internal reasoning that is not part of the returned content.
+	ReasoningTokens int
+}
+
+// NewUsage creates a Usage from prompt and completion token counts, filling in
+// TotalTokens.
+func NewUsage(promptTokens, completionTokens int) Usage {
+	return Usage{
+		PromptTokens:     promptTokens,
+		CompletionTokens: completionTokens,
+		TotalTokens:      promptTokens + completionTokens,
+	}
+}
+
+// Add returns the sum of u and other. It is useful for accumulating usage
+// across several calls, e.g. in an agent loop.
+func (u Usage) Add(other Usage) Usage {
+	return Usage{
+		PromptTokens:     u.PromptTokens + other.PromptTokens,
+		CompletionTokens: u.CompletionTokens + other.CompletionTokens,
+		TotalTokens:      u.TotalTokens + other.TotalTokens,
+		CachedTokens:     u.CachedTokens + other.CachedTokens,
+		ReasoningTokens:  u.ReasoningTokens + other.ReasoningTokens,
+	}
+}
+
+// IsZero reports whether no usage was recorded.
+func (u Usage) IsZero() bool {
+	return u == Usage{}
 }
 
 // ContentChoice is one of the response choices returned by GenerateContent
@@ -34,3 +34,18 @@ func TestTextParts(t *testing.T) {
 		})
 	}
 }
+
+func TestUsageAdd(t *testing.T) {
+	t.Parallel()
+	a := NewUsage(10, 5)
+	b := Usage{PromptTokens: 3, CompletionTokens: 2, TotalTokens: 5, CachedTokens: 1, ReasoningTokens: 1}
+
+	got := a.Add(b)
+	want := Usage{PromptTokens: 13, CompletionTokens: 7, TotalTokens: 20, CachedTokens: 1, ReasoningTokens: 1}
+	if got != want {
+		t.Errorf("Usage.Add() = %+v, want %+v", got, want)
+	}
+	if !(Usage{}).IsZero() || got.IsZero() {
+		t.Errorf("Usage.IsZero() returned unexpected result")
+	}
+}
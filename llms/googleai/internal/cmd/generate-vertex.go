@@ -30,12 +30,15 @@ func main() {
 		case *ast.ImportSpec:
 			rewriteImport(x)
 
+		case *ast.GenDecl:
+			rewriteSystemRoleDecl(fset, x)
+
 		case *ast.FuncDecl:
 			if x.Recv != nil && len(x.Recv.List) == 1 {
 				rewriteReceiverName(x)
 			}
-			addCastToTopK(x)
 			removeTokenCount(x)
+			rejectSystemRole(fset, x)
 		}
 
 		return true
@@ -56,28 +59,78 @@ func rewriteImport(x *ast.ImportSpec) {
 	}
 }
 
+// rewriteReceiverName renames the GoogleAI receiver type to Vertex; methods
+// of other types, like streamEvents, keep their receiver.
 func rewriteReceiverName(fun *ast.FuncDecl) {
 	recv := fun.Recv.List[0]
-	ty := recv.Type.(*ast.StarExpr)
-	tyName := ty.X.(*ast.Ident)
-	tyName.Name = "Vertex"
+	ty, ok := recv.Type.(*ast.StarExpr)
+	if !ok {
+		return
+	}
+	if tyName, ok := ty.X.(*ast.Ident); ok && tyName.Name == "GoogleAI" {
+		tyName.Name = "Vertex"
+	}
 }
 
-func addCastToTopK(fun *ast.FuncDecl) {
+// rewriteSystemRoleDecl replaces the RoleSystem constant, which Vertex doesn't
+// support yet, with the ErrSystemRoleNotSupported error.
+func rewriteSystemRoleDecl(fset *token.FileSet, decl *ast.GenDecl) {
+	switch decl.Tok {
+	case token.CONST:
+		specs := decl.Specs[:0]
+		for _, spec := range decl.Specs {
+			if vs, ok := spec.(*ast.ValueSpec); ok && len(vs.Names) == 1 && vs.Names[0].Name == "RoleSystem" {
+				deleteLines(fset, vs)
+				continue
+			}
+			specs = append(specs, spec)
+		}
+		decl.Specs = specs
+
+	case token.VAR:
+		for _, spec := range decl.Specs {
+			if vs, ok := spec.(*ast.ValueSpec); ok && len(vs.Names) == 1 && vs.Names[0].Name == "ErrInvalidMimeType" {
+				value, err := parser.ParseExpr(`errors.New("system role isn't supported yet")`)
+				if err != nil {
+					log.Fatal(err)
+				}
+				decl.Specs = append(decl.Specs, &ast.ValueSpec{
+					Names:  []*ast.Ident{ast.NewIdent("ErrSystemRoleNotSupported")},
+					Values: []ast.Expr{value},
+				})
+				return
+			}
+		}
+	}
+}
+
+// rejectSystemRole makes the conversion of system messages return
+// ErrSystemRoleNotSupported, and removes the system instruction they set.
+func rejectSystemRole(fset *token.FileSet, fun *ast.FuncDecl) {
 	ast.Inspect(fun, func(n ast.Node) bool {
 		switch x := n.(type) {
-		case *ast.CallExpr:
-			if getIdentName(x.Fun) == "int32" && len(x.Args) == 1 {
-				arg0 := x.Args[0]
-				if sel, ok := arg0.(*ast.SelectorExpr); ok {
-					if getIdentName(sel.X) == "opts" {
-						if getIdentName(sel.Sel) == "TopK" {
-							funcId := x.Fun.(*ast.Ident)
-							funcId.Name = "float32"
-						}
+		case *ast.CaseClause:
+			for i, stmt := range x.Body {
+				if assign, ok := stmt.(*ast.AssignStmt); ok && getIdentName(assign.Rhs[0]) == "RoleSystem" {
+					x.Body[i] = &ast.ReturnStmt{
+						Return:  assign.Pos(),
+						Results: []ast.Expr{ast.NewIdent("nil"), ast.NewIdent("ErrSystemRoleNotSupported")},
 					}
 				}
 			}
+
+		case *ast.BlockStmt:
+			list := x.List[:0]
+			for _, stmt := range x.List {
+				if ifStmt, ok := stmt.(*ast.IfStmt); ok {
+					if cond, ok := ifStmt.Cond.(*ast.BinaryExpr); ok && getIdentName(cond.Y) == "RoleSystem" {
+						deleteLines(fset, ifStmt)
+						continue
+					}
+				}
+				list = append(list, stmt)
+			}
+			x.List = list
 		}
 		return true
 	})
@@ -105,6 +158,16 @@ func removeTokenCount(fun *ast.FuncDecl) {
 	})
 }
 
+// deleteLines removes the lines of a node removed from the tree, so that no
+// blank line is left in its place.
+func deleteLines(fset *token.FileSet, n ast.Node) {
+	file := fset.File(n.Pos())
+	line, end := file.Line(n.Pos()), file.Line(n.End())
+	for i := line; i <= end; i++ {
+		file.MergeLine(line)
+	}
+}
+
 // getIdentName returns the identifier name from ast.Ident expressions; for
 // other expressions, returns an empty string.
 func getIdentName(x ast.Expr) string {
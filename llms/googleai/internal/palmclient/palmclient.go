@@ -83,8 +83,17 @@ type Completion struct {
 	Text string `json:"text"`
 }
 
+// CompletionResponse is the response to a CompletionRequest.
+type CompletionResponse struct {
+	Completions []*Completion
+	// InputTokens and OutputTokens are the numbers of tokens of all the
+	// prompts and completions, or zero if the API doesn't report them.
+	InputTokens  int
+	OutputTokens int
+}
+
 // CreateCompletion creates a completion.
-func (c *PaLMClient) CreateCompletion(ctx context.Context, r *CompletionRequest) ([]*Completion, error) {
+func (c *PaLMClient) CreateCompletion(ctx context.Context, r *CompletionRequest) (*CompletionResponse, error) {
 	params := map[string]interface{}{
 		"maxOutputTokens": r.MaxTokens,
 		"temperature":     r.Temperature,
@@ -92,12 +101,12 @@ func (c *PaLMClient) CreateCompletion(ctx context.Context, r *CompletionRequest)
 		"top_k":           r.TopK,
 		"stopSequences":   convertArray(r.StopSequences),
 	}
-	predictions, err := c.batchPredict(ctx, TextModelName, r.Prompts, params)
+	resp, err := c.batchPredict(ctx, TextModelName, r.Prompts, params)
 	if err != nil {
 		return nil, err
 	}
 	completions := []*Completion{}
-	for _, p := range predictions {
+	for _, p := range resp.GetPredictions() {
 		value := p.GetStructValue().AsMap()
 		text, ok := value["content"].(string)
 		if !ok {
@@ -107,7 +116,24 @@ func (c *PaLMClient) CreateCompletion(ctx context.Context, r *CompletionRequest)
 			Text: text,
 		})
 	}
-	return completions, nil
+	inputTokens, outputTokens := tokenCounts(resp.GetMetadata())
+	return &CompletionResponse{
+		Completions:  completions,
+		InputTokens:  inputTokens,
+		OutputTokens: outputTokens,
+	}, nil
+}
+
+// tokenCounts returns the input and output token counts of the metadata of a
+// text prediction response, which are zero if missing.
+func tokenCounts(metadata *structpb.Value) (int, int) {
+	tokenMetadata, _ := metadata.GetStructValue().AsMap()["tokenMetadata"].(map[string]interface{})
+	totalTokens := func(key string) int {
+		count, _ := tokenMetadata[key].(map[string]interface{})
+		total, _ := count["totalTokens"].(float64)
+		return int(total)
+	}
+	return totalTokens("inputTokenCount"), totalTokens("outputTokenCount")
 }
 
 // EmbeddingRequest is a request to create an embedding.
@@ -118,13 +144,13 @@ type EmbeddingRequest struct {
 // CreateEmbedding creates embeddings.
 func (c *PaLMClient) CreateEmbedding(ctx context.Context, r *EmbeddingRequest) ([][]float32, error) {
 	params := map[string]interface{}{}
-	responses, err := c.batchPredict(ctx, embeddingModelName, r.Input, params)
+	resp, err := c.batchPredict(ctx, embeddingModelName, r.Input, params)
 	if err != nil {
 		return nil, err
 	}
 
 	embeddings := [][]float32{}
-	for _, res := range responses {
+	for _, res := range resp.GetPredictions() {
 		value := res.GetStructValue().AsMap()
 		embedding, ok := value["embeddings"].(map[string]interface{})
 		if !ok {
@@ -272,7 +298,7 @@ func convertArray(value []string) interface{} {
 	return newArray
 }
 
-func (c *PaLMClient) batchPredict(ctx context.Context, model string, prompts []string, params map[string]interface{}) ([]*structpb.Value, error) { //nolint:lll
+func (c *PaLMClient) batchPredict(ctx context.Context, model string, prompts []string, params map[string]interface{}) (*aiplatformpb.PredictResponse, error) { //nolint:lll
 	mergedParams := mergeParams(defaultParameters, params)
 	instances := []*structpb.Value{}
 	for _, prompt := range prompts {
@@ -292,7 +318,7 @@ func (c *PaLMClient) batchPredict(ctx context.Context, model string, prompts []s
 	if len(resp.GetPredictions()) == 0 {
 		return nil, ErrEmptyResponse
 	}
-	return resp.GetPredictions(), nil
+	return resp, nil
 }
 
 func (c *PaLMClient) chat(ctx context.Context, r *ChatRequest) ([]*structpb.Value, error) {
@@ -0,0 +1,27 @@
+package palmclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestTokenCounts(t *testing.T) {
+	t.Parallel()
+
+	metadata, err := structpb.NewValue(map[string]any{
+		"tokenMetadata": map[string]any{
+			"inputTokenCount":  map[string]any{"totalTokens": 12, "totalBillableCharacters": 40},
+			"outputTokenCount": map[string]any{"totalTokens": 5},
+		},
+	})
+	require.NoError(t, err)
+	inputTokens, outputTokens := tokenCounts(metadata)
+	require.Equal(t, 12, inputTokens)
+	require.Equal(t, 5, outputTokens)
+
+	inputTokens, outputTokens = tokenCounts(nil)
+	require.Zero(t, inputTokens)
+	require.Zero(t, outputTokens)
+}
@@ -64,9 +64,10 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 	resp := &llms.ContentResponse{
 		Choices: []*llms.ContentChoice{
 			{
-				Content: results[0].Text,
+				Content: results.Completions[0].Text,
 			},
 		},
+		Usage: llms.NewUsage(results.InputTokens, results.OutputTokens),
 	}
 	if o.CallbacksHandler != nil {
 		o.CallbacksHandler.HandleLLMGenerateContentEnd(ctx, resp)
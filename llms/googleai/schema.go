@@ -0,0 +1,42 @@
+package googleai
+
+import (
+	"github.com/google/generative-ai-go/genai"
+	"github.com/tmc/langchaingo/jsonschema"
+	"github.com/tmc/langchaingo/llms"
+)
+
+var _ llms.ResponseSchemaSupporter = &GoogleAI{}
+
+// SupportsResponseSchema implements the [llms.ResponseSchemaSupporter]
+// interface. Response schemas are sent as the response schema of the model.
+func (g *GoogleAI) SupportsResponseSchema() bool {
+	return true
+}
+
+// setResponseSchema makes model respond with JSON matching schema.
+func setResponseSchema(model *genai.GenerativeModel, schema *llms.ResponseSchema) {
+	model.ResponseMIMEType = "application/json"
+	model.ResponseSchema = convertSchema(schema.Schema)
+}
+
+// convertSchema converts a JSON schema definition to a genai.Schema.
+func convertSchema(def jsonschema.Definition) *genai.Schema {
+	schema := &genai.Schema{
+		Type:        convertToolSchemaType(string(def.Type)),
+		Description: def.Description,
+		Format:      def.Format,
+		Enum:        def.Enum,
+		Required:    def.Required,
+	}
+	if len(def.Properties) > 0 {
+		schema.Properties = make(map[string]*genai.Schema, len(def.Properties))
+		for name, property := range def.Properties {
+			schema.Properties[name] = convertSchema(property)
+		}
+	}
+	if def.Items != nil {
+		schema.Items = convertSchema(*def.Items)
+	}
+	return schema
+}
@@ -0,0 +1,21 @@
+package vertex
+
+import (
+	"cloud.google.com/go/vertexai/genai"
+	"github.com/tmc/langchaingo/llms"
+)
+
+var _ llms.ResponseSchemaSupporter = &Vertex{}
+
+// SupportsResponseSchema implements the [llms.ResponseSchemaSupporter]
+// interface. This version of the Vertex AI SDK has no response schema, so it
+// returns false and callers describe the schema in the prompt instead.
+func (g *Vertex) SupportsResponseSchema() bool {
+	return false
+}
+
+// setResponseSchema makes model respond with JSON. The schema itself can't
+// be sent with this version of the Vertex AI SDK.
+func setResponseSchema(model *genai.GenerativeModel, _ *llms.ResponseSchema) {
+	model.ResponseMIMEType = "application/json"
+}
@@ -89,6 +89,9 @@ func (g *Vertex) GenerateContent(
 	if model.Tools, err = convertTools(opts.Tools); err != nil {
 		return nil, err
 	}
+	if opts.ResponseSchema != nil {
+		setResponseSchema(model, opts.ResponseSchema)
+	}
 
 	var response *llms.ContentResponse
 
@@ -113,10 +116,18 @@ func (g *Vertex) GenerateContent(
 }
 
 // convertCandidates converts a sequence of genai.Candidate to a response.
-func convertCandidates(candidates []*genai.Candidate) (*llms.ContentResponse, error) {
+func convertCandidates(candidates []*genai.Candidate, usage *genai.UsageMetadata) (*llms.ContentResponse, error) {
 	var contentResponse llms.ContentResponse
 	var toolCalls []llms.ToolCall
 
+	if usage != nil {
+		contentResponse.Usage = llms.Usage{
+			PromptTokens:     int(usage.PromptTokenCount),
+			CompletionTokens: int(usage.CandidatesTokenCount),
+			TotalTokens:      int(usage.TotalTokenCount),
+		}
+	}
+
 	for _, candidate := range candidates {
 		buf := strings.Builder{}
 
@@ -246,7 +257,7 @@ func generateFromSingleMessage(
 		return nil, err
 	}
 
-	if opts.StreamingFunc == nil {
+	if opts.StreamingFunc == nil && opts.StreamingEventFunc == nil {
 		// When no streaming is requested, just call GenerateContent and return
 		// the complete response with a list of candidates.
 		resp, err := model.GenerateContent(ctx, convertedParts...)
@@ -257,7 +268,7 @@ func generateFromSingleMessage(
 		if len(resp.Candidates) == 0 {
 			return nil, ErrNoContentInResponse
 		}
-		return convertCandidates(resp.Candidates)
+		return convertCandidates(resp.Candidates, resp.UsageMetadata)
 	}
 	iter := model.GenerateContentStream(ctx, convertedParts...)
 	return convertAndStreamFromIterator(ctx, iter, opts)
@@ -287,7 +298,7 @@ func generateFromMessages(
 	session := model.StartChat()
 	session.History = history
 
-	if opts.StreamingFunc == nil {
+	if opts.StreamingFunc == nil && opts.StreamingEventFunc == nil {
 		resp, err := session.SendMessage(ctx, reqContent.Parts...)
 		if err != nil {
 			return nil, err
@@ -296,7 +307,7 @@ func generateFromMessages(
 		if len(resp.Candidates) == 0 {
 			return nil, ErrNoContentInResponse
 		}
-		return convertCandidates(resp.Candidates)
+		return convertCandidates(resp.Candidates, resp.UsageMetadata)
 	}
 	iter := session.SendMessageStream(ctx, reqContent.Parts...)
 	return convertAndStreamFromIterator(ctx, iter, opts)
@@ -315,6 +326,10 @@ func convertAndStreamFromIterator(
 	candidate := &genai.Candidate{
 		Content: &genai.Content{},
 	}
+	// Each streamed response carries the cumulative usage so far, so only the
+	// last one is kept.
+	var usage *genai.UsageMetadata
+	events := streamEvents{fn: opts.StreamingEventFunc}
 DoStream:
 	for {
 		resp, err := iter.Next()
@@ -325,6 +340,10 @@ DoStream:
 			return nil, fmt.Errorf("error in stream mode: %w", err)
 		}
 
+		if resp.UsageMetadata != nil {
+			usage = resp.UsageMetadata
+		}
+
 		if len(resp.Candidates) != 1 {
 			return nil, fmt.Errorf("expect single candidate in stream mode; got %v", len(resp.Candidates))
 		}
@@ -340,15 +359,79 @@ DoStream:
 		candidate.CitationMetadata = respCandidate.CitationMetadata
 
 		for _, part := range respCandidate.Content.Parts {
-			if text, ok := part.(genai.Text); ok {
-				if opts.StreamingFunc(ctx, []byte(text)) != nil {
-					break DoStream
+			if text, ok := part.(genai.Text); ok && opts.StreamingFunc != nil {
+				if err := opts.StreamingFunc(ctx, []byte(text)); err != nil {
+					return nil, fmt.Errorf("streaming func returned an error: %w", err)
 				}
 			}
+			if err := events.part(ctx, part); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := events.end(ctx, candidate, usage); err != nil {
+		return nil, err
+	}
+	return convertCandidates([]*genai.Candidate{candidate}, usage)
+}
+
+// streamEvents sends the typed events of a streamed response.
+type streamEvents struct {
+	fn        llms.StreamEventFunc
+	toolCalls int
+}
+
+func (e *streamEvents) send(ctx context.Context, event llms.StreamEvent) error {
+	if e.fn == nil {
+		return nil
+	}
+	if err := e.fn(ctx, event); err != nil {
+		return fmt.Errorf("streaming event func returned an error: %w", err)
+	}
+	return nil
+}
+
+// part sends the events of a streamed part. Function calls are streamed
+// complete, so each one is sent as a start, delta and end event.
+func (e *streamEvents) part(ctx context.Context, part genai.Part) error {
+	switch v := part.(type) {
+	case genai.Text:
+		if v == "" {
+			return nil
+		}
+		return e.send(ctx, llms.StreamEvent{Type: llms.StreamEventTextDelta, Text: string(v)})
+	case genai.FunctionCall:
+		b, err := json.Marshal(v.Args)
+		if err != nil {
+			return err
+		}
+		index := e.toolCalls
+		e.toolCalls++
+		call := func(arguments string) *llms.ToolCall {
+			return &llms.ToolCall{FunctionCall: &llms.FunctionCall{Name: v.Name, Arguments: arguments}}
+		}
+		for _, event := range []llms.StreamEvent{
+			{Type: llms.StreamEventToolCallStart, Index: index, ToolCall: call("")},
+			{Type: llms.StreamEventToolCallDelta, Index: index, ToolCall: call(string(b))},
+			{Type: llms.StreamEventToolCallEnd, Index: index, ToolCall: call(string(b))},
+		} {
+			if err := e.send(ctx, event); err != nil {
+				return err
+			}
 		}
 	}
+	return nil
+}
 
-	return convertCandidates([]*genai.Candidate{candidate})
+// end sends the stop and usage events at the end of the stream.
+func (e *streamEvents) end(ctx context.Context, candidate *genai.Candidate, usage *genai.UsageMetadata) error {
+	err := e.send(ctx, llms.StreamEvent{Type: llms.StreamEventStop, StopReason: candidate.FinishReason.String()})
+	if err != nil || usage == nil {
+		return err
+	}
+	streamUsage := llms.NewUsage(int(usage.PromptTokenCount), int(usage.CandidatesTokenCount))
+	return e.send(ctx, llms.StreamEvent{Type: llms.StreamEventUsage, Usage: &streamUsage})
 }
 
 // convertTools converts from a list of langchaingo tools to a list of genai
@@ -368,10 +451,14 @@ func convertTools(tools []llms.Tool) ([]*genai.Tool, error) {
 		}
 
 		// Expect the Parameters field to be a map[string]any, from which we will
-		// extract properties to populate the schema.
+		// extract properties to populate the schema. Other types, such as
+		// jsonschema.Definition, are converted to one through their JSON encoding.
 		params, ok := tool.Function.Parameters.(map[string]any)
 		if !ok {
-			return nil, fmt.Errorf("tool [%d]: unsupported type %T of Parameters", i, tool.Function.Parameters)
+			var err error
+			if params, err = parametersToMap(tool.Function.Parameters); err != nil {
+				return nil, fmt.Errorf("tool [%d]: unsupported type %T of Parameters: %w", i, tool.Function.Parameters, err)
+			}
 		}
 
 		schema := &genai.Schema{}
@@ -448,6 +535,8 @@ func convertToolSchemaType(ty string) genai.Type {
 		return genai.TypeInteger
 	case "boolean":
 		return genai.TypeBoolean
+	case "array":
+		return genai.TypeArray
 	default:
 		return genai.TypeUnspecified
 	}
@@ -475,3 +564,17 @@ func showContent(w io.Writer, cs []*genai.Content) {
 		}
 	}
 }
+
+// parametersToMap converts function parameters to a map through their JSON
+// encoding.
+func parametersToMap(parameters any) (map[string]any, error) {
+	b, err := json.Marshal(parameters)
+	if err != nil {
+		return nil, err
+	}
+	var params map[string]any
+	if err := json.Unmarshal(b, &params); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/llms"
 )
 
 const (
@@ -44,6 +45,10 @@ func TestRunInference(t *testing.T) {
 			assert.Equal(t, tc.expected, resp)
 			if tc.wantErr != "" {
 				require.ErrorContains(t, err, tc.wantErr)
+				require.ErrorIs(t, err, ErrUnexpectedStatusCode)
+				var statusErr *llms.StatusError
+				require.ErrorAs(t, err, &statusErr)
+				require.Equal(t, http.StatusBadRequest, statusErr.StatusCode)
 			}
 		})
 	}
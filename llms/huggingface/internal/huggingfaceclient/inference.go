@@ -8,6 +8,9 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+
+	"github.com/tmc/langchaingo/llms"
 )
 
 var ErrUnexpectedStatusCode = errors.New("unexpected status code")
@@ -76,12 +79,11 @@ func (c *Client) runInference(ctx context.Context, payload *inferencePayload) (i
 			return nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 
+		msg := strconv.Itoa(r.StatusCode)
 		if len(b) > 0 {
-			err = fmt.Errorf("%w: %d, body: %s", ErrUnexpectedStatusCode, r.StatusCode, string(b))
-		} else {
-			err = fmt.Errorf("%w: %d", ErrUnexpectedStatusCode, r.StatusCode)
+			msg = fmt.Sprintf("%d, body: %s", r.StatusCode, string(b))
 		}
-		return nil, err
+		return nil, fmt.Errorf("%w: %w", ErrUnexpectedStatusCode, llms.NewStatusError(r, msg))
 	}
 
 	// debug print the http response with httputil:
@@ -98,6 +98,7 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 	req = makeLlamaOptionsFromOptions(req, opts)
 
 	streamedResponse := ""
+	var usage llms.Usage
 	fn := func(response llamafileclient.ChatResponse) error {
 		if opts.StreamingFunc != nil && response.Content != "" {
 			if err := opts.StreamingFunc(ctx, []byte(response.Content)); err != nil {
@@ -107,6 +108,10 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 		if response.Content != "" {
 			streamedResponse += response.Content
 		}
+		// Token counts are only reported on the final response.
+		if response.Stop {
+			usage = llms.NewUsage(response.TokensEvaluated, response.TokensPredicted)
+		}
 
 		return nil
 	}
@@ -125,6 +130,7 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 				Content: streamedResponse,
 			},
 		},
+		Usage: usage,
 	}, nil
 }
 
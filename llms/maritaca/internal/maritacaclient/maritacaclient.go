@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+
+	"github.com/tmc/langchaingo/llms"
 )
 
 const defaultURL = "https://chat.maritaca.ai/api"
@@ -70,11 +72,11 @@ func (c *Client) stream(ctx context.Context, method, path string, data any, fn f
 			return err
 		}
 
-		return StatusError{
+		return llms.NewStatusError(response, StatusError{
 			StatusCode:   response.StatusCode,
 			Status:       response.Status,
 			ErrorMessage: errorResponse.Error,
-		}
+		}.Error())
 	}
 
 	scanner := bufio.NewScanner(response.Body)
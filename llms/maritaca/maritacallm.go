@@ -129,6 +129,7 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 			streamedResponse += response.Text
 		case "end":
 			resp.Answer = streamedResponse
+			resp.Metrics = response.Metrics
 		case "nostream":
 			resp = response
 		}
@@ -146,7 +147,14 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 
 	choices := createChoice(resp)
 
-	response := &llms.ContentResponse{Choices: choices}
+	response := &llms.ContentResponse{
+		Choices: choices,
+		Usage: llms.Usage{
+			PromptTokens:     resp.Metrics.Usage.PromptTokens,
+			CompletionTokens: resp.Metrics.Usage.CompletionTokens,
+			TotalTokens:      resp.Metrics.Usage.TotalTokens,
+		},
+	}
 
 	if o.CallbacksHandler != nil {
 		o.CallbacksHandler.HandleLLMGenerateContentEnd(ctx, response)
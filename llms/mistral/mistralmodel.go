@@ -78,7 +78,7 @@ func (m *Model) GenerateContent(ctx context.Context, langchainMessages []llms.Me
 		return nil, err
 	}
 
-	if callOptions.StreamingFunc != nil {
+	if callOptions.StreamingFunc != nil || callOptions.StreamingEventFunc != nil {
 		return generateStreamingContent(ctx, m, callOptions, messages, chatOpts)
 	}
 	return generateNonStreamingContent(ctx, m, callOptions, messages, chatOpts)
@@ -145,6 +145,7 @@ func generateNonStreamingContent(ctx context.Context, m *Model, callOptions *llm
 
 	langchainContentResponse := &llms.ContentResponse{
 		Choices: make([]*llms.ContentChoice, 0),
+		Usage:   usageFromMistralUsage(res.Usage),
 	}
 	for idx, choice := range res.Choices {
 		langchainContentResponse.Choices = append(langchainContentResponse.Choices, &llms.ContentChoice{
@@ -180,32 +181,88 @@ func generateStreamingContent(ctx context.Context, m *Model, callOptions *llms.C
 		GenerationInfo: map[string]any{},
 	}
 
+	toolCalls := 0
 	for chatResChunk := range chatResChan {
+		if chatResChunk.Error != nil {
+			return langchainContentResponse, chatResChunk.Error
+		}
 		chunkStr := ""
 		langchainContentResponse.Choices[0].GenerationInfo["created"] = chatResChunk.Created
 		langchainContentResponse.Choices[0].GenerationInfo["model"] = chatResChunk.Model
 		langchainContentResponse.Choices[0].GenerationInfo["usage"] = chatResChunk.Usage
-		if chatResChunk.Error == nil {
-			for _, choice := range chatResChunk.Choices {
-				chunkStr += choice.Delta.Content
-				langchainContentResponse.Choices[0].Content += choice.Delta.Content
-				langchainContentResponse.Choices[0].StopReason = string(choice.FinishReason)
-				if len(choice.Delta.ToolCalls) > 0 {
-					langchainContentResponse.Choices[0].FuncCall = (*llms.FunctionCall)(&choice.Delta.ToolCalls[0].Function)
-				}
+		var events []llms.StreamEvent
+		for _, choice := range chatResChunk.Choices {
+			chunkStr += choice.Delta.Content
+			langchainContentResponse.Choices[0].Content += choice.Delta.Content
+			langchainContentResponse.Choices[0].StopReason = string(choice.FinishReason)
+			if len(choice.Delta.ToolCalls) > 0 {
+				langchainContentResponse.Choices[0].FuncCall = (*llms.FunctionCall)(&choice.Delta.ToolCalls[0].Function)
+			}
+			if choice.Delta.Content != "" {
+				events = append(events, llms.StreamEvent{Type: llms.StreamEventTextDelta, Text: choice.Delta.Content})
+			}
+			// Mistral sends tool calls complete, in a single chunk.
+			for _, toolCall := range choice.Delta.ToolCalls {
+				events = append(events, toolCallEvents(toolCalls, toolCall)...)
+				toolCalls++
+			}
+			if choice.FinishReason != "" {
+				events = append(events, llms.StreamEvent{Type: llms.StreamEventStop, StopReason: string(choice.FinishReason)})
 			}
+		}
+		// Usage is only reported on the final chunk of the stream.
+		if chatResChunk.Usage.TotalTokens > 0 {
+			langchainContentResponse.Usage = usageFromMistralUsage(chatResChunk.Usage)
+			usage := langchainContentResponse.Usage
+			events = append(events, llms.StreamEvent{Type: llms.StreamEventUsage, Usage: &usage})
+		}
+		if callOptions.StreamingFunc != nil {
 			err := callOptions.StreamingFunc(ctx, []byte(chunkStr))
 			if err != nil {
 				return langchainContentResponse, err
 			}
-		} else {
-			return langchainContentResponse, chatResChunk.Error
+		}
+		if callOptions.StreamingEventFunc != nil {
+			for _, event := range events {
+				if err := callOptions.StreamingEventFunc(ctx, event); err != nil {
+					return langchainContentResponse, err
+				}
+			}
 		}
 	}
 
 	return langchainContentResponse, nil
 }
 
+// toolCallEvents returns the start, delta and end events of a complete tool
+// call.
+func toolCallEvents(index int, toolCall sdk.ToolCall) []llms.StreamEvent {
+	call := func(arguments string) *llms.ToolCall {
+		return &llms.ToolCall{
+			ID:           toolCall.Id,
+			Type:         string(toolCall.Type),
+			FunctionCall: &llms.FunctionCall{Name: toolCall.Function.Name, Arguments: arguments},
+		}
+	}
+	events := []llms.StreamEvent{{Type: llms.StreamEventToolCallStart, Index: index, ToolCall: call("")}}
+	if toolCall.Function.Arguments != "" {
+		events = append(events, llms.StreamEvent{
+			Type: llms.StreamEventToolCallDelta, Index: index, ToolCall: call(toolCall.Function.Arguments),
+		})
+	}
+	return append(events, llms.StreamEvent{
+		Type: llms.StreamEventToolCallEnd, Index: index, ToolCall: call(toolCall.Function.Arguments),
+	})
+}
+
+func usageFromMistralUsage(usage sdk.UsageInfo) llms.Usage {
+	return llms.Usage{
+		PromptTokens:     usage.PromptTokens,
+		CompletionTokens: usage.CompletionTokens,
+		TotalTokens:      usage.TotalTokens,
+	}
+}
+
 func convertToMistralChatMessages(langchainMessages []llms.MessageContent) ([]sdk.ChatMessage, error) {
 	messages := make([]sdk.ChatMessage, 0)
 	for _, msg := range langchainMessages {
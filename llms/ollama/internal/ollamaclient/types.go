@@ -50,7 +50,7 @@ type ChatRequest struct {
 	Model     string     `json:"model"`
 	Messages  []*Message `json:"messages"`
 	Stream    *bool      `json:"stream,omitempty"`
-	Format    string     `json:"format"`
+	Format    any        `json:"format,omitempty"` // "json" or a JSON schema
 	KeepAlive string     `json:"keep_alive,omitempty"`
 
 	Options Options `json:"options"`
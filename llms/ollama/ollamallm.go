@@ -21,7 +21,10 @@ type LLM struct {
 	options          options
 }
 
-var _ llms.Model = (*LLM)(nil)
+var (
+	_ llms.Model                   = (*LLM)(nil)
+	_ llms.ResponseSchemaSupporter = (*LLM)(nil)
+)
 
 // New creates a new ollama LLM implementation.
 func New(opts ...Option) (*LLM, error) {
@@ -38,6 +41,12 @@ func New(opts ...Option) (*LLM, error) {
 	return &LLM{client: client, options: o}, nil
 }
 
+// SupportsResponseSchema implements llms.ResponseSchemaSupporter. Response
+// schemas are sent as the format of the request.
+func (o *LLM) SupportsResponseSchema() bool {
+	return true
+}
+
 // Call Implement the call interface for LLM.
 func (o *LLM) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
 	return llms.GenerateFromSinglePrompt(ctx, o, prompt, options...)
@@ -96,10 +105,16 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 		chatMsgs = append(chatMsgs, msg)
 	}
 
-	format := o.options.format
+	var format any
+	if o.options.format != "" {
+		format = o.options.format
+	}
 	if opts.JSONMode {
 		format = "json"
 	}
+	if opts.ResponseSchema != nil {
+		format = opts.ResponseSchema.Schema
+	}
 
 	// Get our ollamaOptions from llms.CallOptions
 	ollamaOptions := makeOllamaOptionsFromOptions(o.options.ollamaOptions, opts)
@@ -108,7 +123,7 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 		Format:   format,
 		Messages: chatMsgs,
 		Options:  ollamaOptions,
-		Stream:   func(b bool) *bool { return &b }(opts.StreamingFunc != nil),
+		Stream:   func(b bool) *bool { return &b }(opts.StreamingFunc != nil || opts.StreamingEventFunc != nil),
 	}
 
 	keepAlive := o.options.keepAlive
@@ -126,6 +141,9 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 				return err
 			}
 		}
+		if err := sendStreamEvents(ctx, opts.StreamingEventFunc, response); err != nil {
+			return err
+		}
 		if response.Message != nil {
 			streamedResponse += response.Message.Content
 		}
@@ -158,7 +176,10 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 		},
 	}
 
-	response := &llms.ContentResponse{Choices: choices}
+	response := &llms.ContentResponse{
+		Choices: choices,
+		Usage:   llms.NewUsage(resp.PromptEvalCount, resp.EvalCount),
+	}
 
 	if o.CallbacksHandler != nil {
 		o.CallbacksHandler.HandleLLMGenerateContentEnd(ctx, response)
@@ -167,6 +188,27 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 	return response, nil
 }
 
+// sendStreamEvents sends the typed events of a chat response chunk to fn.
+func sendStreamEvents(ctx context.Context, fn llms.StreamEventFunc, response ollamaclient.ChatResponse) error {
+	if fn == nil {
+		return nil
+	}
+	if response.Message != nil && response.Message.Content != "" {
+		err := fn(ctx, llms.StreamEvent{Type: llms.StreamEventTextDelta, Text: response.Message.Content})
+		if err != nil {
+			return err
+		}
+	}
+	if !response.Done {
+		return nil
+	}
+	if err := fn(ctx, llms.StreamEvent{Type: llms.StreamEventStop, StopReason: "stop"}); err != nil {
+		return err
+	}
+	usage := llms.NewUsage(response.PromptEvalCount, response.EvalCount)
+	return fn(ctx, llms.StreamEvent{Type: llms.StreamEventUsage, Usage: &usage})
+}
+
 func (o *LLM) CreateEmbedding(ctx context.Context, inputTexts []string) ([][]float32, error) {
 	embeddings := [][]float32{}
 
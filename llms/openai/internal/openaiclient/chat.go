@@ -64,6 +64,9 @@ type ChatRequest struct {
 	// StreamingFunc is a function to be called for each chunk of a streaming response.
 	// Return an error to stop streaming early.
 	StreamingFunc func(ctx context.Context, chunk []byte) error `json:"-"`
+	// StreamingEventFunc is a function to be called for each typed event of a
+	// streaming response.
+	StreamingEventFunc llms.StreamEventFunc `json:"-"`
 
 	// Deprecated: use Tools instead.
 	Functions []FunctionDefinition `json:"functions,omitempty"`
@@ -109,6 +112,16 @@ type ToolCall struct {
 // ResponseFormat is the format of the response.
 type ResponseFormat struct {
 	Type string `json:"type"`
+	// JSONSchema is the schema of the response when Type is "json_schema".
+	JSONSchema *ResponseFormatJSONSchema `json:"json_schema,omitempty"`
+}
+
+// ResponseFormatJSONSchema is the JSON schema of a structured response.
+type ResponseFormatJSONSchema struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Schema      any    `json:"schema"`
+	Strict      bool   `json:"strict,omitempty"`
 }
 
 // ChatMessage is a message in a chat request.
@@ -260,9 +273,22 @@ type ChatCompletionChoice struct {
 
 // ChatUsage is the usage of a chat completion request.
 type ChatUsage struct {
-	PromptTokens     int `json:"prompt_tokens"`
-	CompletionTokens int `json:"completion_tokens"`
-	TotalTokens      int `json:"total_tokens"`
+	PromptTokens            int                     `json:"prompt_tokens"`
+	CompletionTokens        int                     `json:"completion_tokens"`
+	TotalTokens             int                     `json:"total_tokens"`
+	PromptTokensDetails     PromptTokensDetails     `json:"prompt_tokens_details"`
+	CompletionTokensDetails CompletionTokensDetails `json:"completion_tokens_details"`
+}
+
+// PromptTokensDetails is the breakdown of the prompt tokens of a request.
+type PromptTokensDetails struct {
+	CachedTokens int `json:"cached_tokens"`
+}
+
+// CompletionTokensDetails is the breakdown of the completion tokens of a
+// request.
+type CompletionTokensDetails struct {
+	ReasoningTokens int `json:"reasoning_tokens"`
 }
 
 // ChatCompletionResponse is a response to a chat request.
@@ -277,9 +303,11 @@ type ChatCompletionResponse struct {
 }
 
 type Usage struct {
-	PromptTokens     int `json:"prompt_tokens"`
-	CompletionTokens int `json:"completion_tokens"`
-	TotalTokens      int `json:"total_tokens"`
+	PromptTokens            int                     `json:"prompt_tokens"`
+	CompletionTokens        int                     `json:"completion_tokens"`
+	TotalTokens             int                     `json:"total_tokens"`
+	PromptTokensDetails     PromptTokensDetails     `json:"prompt_tokens_details"`
+	CompletionTokensDetails CompletionTokensDetails `json:"completion_tokens_details"`
 }
 
 // StreamedChatResponsePayload is a chunk from the stream.
@@ -291,9 +319,12 @@ type StreamedChatResponsePayload struct {
 	Choices []struct {
 		Index float64 `json:"index,omitempty"`
 		Delta struct {
-			Role         string        `json:"role,omitempty"`
-			Content      string        `json:"content,omitempty"`
-			FunctionCall *FunctionCall `json:"function_call,omitempty"`
+			Role    string `json:"role,omitempty"`
+			Content string `json:"content,omitempty"`
+			// ReasoningContent is streamed by OpenAI compatible APIs that
+			// expose the reasoning of the model, such as DeepSeek.
+			ReasoningContent string        `json:"reasoning_content,omitempty"`
+			FunctionCall     *FunctionCall `json:"function_call,omitempty"`
 			// ToolCalls is a list of tools that were called in the message.
 			ToolCalls []*ToolCall `json:"tool_calls,omitempty"`
 		} `json:"delta,omitempty"`
@@ -338,7 +369,7 @@ type FunctionCall struct {
 }
 
 func (c *Client) createChat(ctx context.Context, payload *ChatRequest) (*ChatCompletionResponse, error) {
-	if payload.StreamingFunc != nil {
+	if payload.StreamingFunc != nil || payload.StreamingEventFunc != nil {
 		payload.Stream = true
 		if payload.StreamOptions == nil {
 			payload.StreamOptions = &StreamOptions{IncludeUsage: true}
@@ -377,12 +408,12 @@ func (c *Client) createChat(ctx context.Context, payload *ChatRequest) (*ChatCom
 		// status code.
 		var errResp errorMessage
 		if err := json.NewDecoder(r.Body).Decode(&errResp); err != nil {
-			return nil, errors.New(msg) // nolint:goerr113
+			return nil, llms.NewStatusError(r, msg)
 		}
 
-		return nil, fmt.Errorf("%s: %s", msg, errResp.Error.Message) // nolint:goerr113
+		return nil, llms.NewStatusError(r, fmt.Sprintf("%s: %s", msg, errResp.Error.Message))
 	}
-	if payload.StreamingFunc != nil {
+	if payload.Stream {
 		return parseStreamingChatResponse(ctx, r, payload)
 	}
 	// Parse response
@@ -437,6 +468,7 @@ func combineStreamingChatResponse(
 		},
 	}
 
+	events := streamEvents{fn: payload.StreamingEventFunc}
 	for streamResponse := range responseChan {
 		if streamResponse.Error != nil {
 			return nil, streamResponse.Error
@@ -446,9 +478,19 @@ func combineStreamingChatResponse(
 			response.Usage.CompletionTokens = streamResponse.Usage.CompletionTokens
 			response.Usage.PromptTokens = streamResponse.Usage.PromptTokens
 			response.Usage.TotalTokens = streamResponse.Usage.TotalTokens
+			response.Usage.PromptTokensDetails = streamResponse.Usage.PromptTokensDetails
+			response.Usage.CompletionTokensDetails = streamResponse.Usage.CompletionTokensDetails
+
+			usage := llms.NewUsage(response.Usage.PromptTokens, response.Usage.CompletionTokens)
+			usage.CachedTokens = response.Usage.PromptTokensDetails.CachedTokens
+			usage.ReasoningTokens = response.Usage.CompletionTokensDetails.ReasoningTokens
+			events.send(ctx, llms.StreamEvent{Type: llms.StreamEventUsage, Usage: &usage})
 		}
 
 		if len(streamResponse.Choices) == 0 {
+			if events.err != nil {
+				return nil, events.err
+			}
 			continue
 		}
 		choice := streamResponse.Choices[0]
@@ -456,13 +498,30 @@ func combineStreamingChatResponse(
 		response.Choices[0].Message.Content += choice.Delta.Content
 		response.Choices[0].FinishReason = choice.FinishReason
 
+		if choice.Delta.ReasoningContent != "" {
+			events.send(ctx, llms.StreamEvent{Type: llms.StreamEventReasoningDelta, Text: choice.Delta.ReasoningContent})
+		}
+		if choice.Delta.Content != "" {
+			events.send(ctx, llms.StreamEvent{Type: llms.StreamEventTextDelta, Text: choice.Delta.Content})
+		}
+
 		if choice.Delta.FunctionCall != nil {
 			chunk = updateFunctionCall(response.Choices[0].Message, choice.Delta.FunctionCall)
 		}
 
 		if len(choice.Delta.ToolCalls) > 0 {
+			previous := len(response.Choices[0].Message.ToolCalls)
 			chunk, response.Choices[0].Message.ToolCalls = updateToolCalls(response.Choices[0].Message.ToolCalls,
 				choice.Delta.ToolCalls)
+			events.toolCallDeltas(ctx, previous, response.Choices[0].Message.ToolCalls, choice.Delta.ToolCalls)
+		}
+
+		if choice.FinishReason != "" {
+			events.toolCallsEnd(ctx, response.Choices[0].Message.ToolCalls)
+			events.send(ctx, llms.StreamEvent{Type: llms.StreamEventStop, StopReason: string(choice.FinishReason)})
+		}
+		if events.err != nil {
+			return nil, events.err
 		}
 
 		if payload.StreamingFunc != nil {
@@ -475,6 +534,68 @@ func combineStreamingChatResponse(
 	return &response, nil
 }
 
+// streamEvents sends the typed events of a streaming response, remembering
+// the first error returned by fn.
+type streamEvents struct {
+	fn  llms.StreamEventFunc
+	err error
+}
+
+func (e *streamEvents) send(ctx context.Context, event llms.StreamEvent) {
+	if e.fn == nil || e.err != nil {
+		return
+	}
+	if err := e.fn(ctx, event); err != nil {
+		e.err = fmt.Errorf("streaming event func returned an error: %w", err)
+	}
+}
+
+// toolCallDeltas sends the events for the tool call deltas of a chunk, given
+// the number of tool calls before the chunk and the merged tool calls.
+func (e *streamEvents) toolCallDeltas(ctx context.Context, previous int, tools []ToolCall, delta []*ToolCall) {
+	index := previous - 1
+	for _, t := range delta {
+		if t.Type == `` && t.Function.Arguments != `` {
+			if index < 0 {
+				continue
+			}
+		} else {
+			index++
+			e.send(ctx, llms.StreamEvent{
+				Type:     llms.StreamEventToolCallStart,
+				Index:    index,
+				ToolCall: llmsToolCall(tools[index].ID, tools[index].Function.Name, ""),
+			})
+		}
+		if t.Function.Arguments != "" {
+			e.send(ctx, llms.StreamEvent{
+				Type:     llms.StreamEventToolCallDelta,
+				Index:    index,
+				ToolCall: llmsToolCall(tools[index].ID, tools[index].Function.Name, t.Function.Arguments),
+			})
+		}
+	}
+}
+
+// toolCallsEnd sends the end events of the complete tool calls.
+func (e *streamEvents) toolCallsEnd(ctx context.Context, tools []ToolCall) {
+	for i, t := range tools {
+		e.send(ctx, llms.StreamEvent{
+			Type:     llms.StreamEventToolCallEnd,
+			Index:    i,
+			ToolCall: llmsToolCall(t.ID, t.Function.Name, t.Function.Arguments),
+		})
+	}
+}
+
+func llmsToolCall(id, name, arguments string) *llms.ToolCall {
+	return &llms.ToolCall{
+		ID:           id,
+		Type:         string(ToolTypeFunction),
+		FunctionCall: &llms.FunctionCall{Name: name, Arguments: arguments},
+	}
+}
+
 func updateFunctionCall(message ChatMessage, functionCall *FunctionCall) []byte {
 	if message.FunctionCall == nil {
 		message.FunctionCall = functionCall
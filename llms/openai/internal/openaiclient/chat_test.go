@@ -10,6 +10,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/llms"
 )
 
 func TestParseStreamingChatResponse_FinishReason(t *testing.T) {
@@ -33,6 +34,45 @@ func TestParseStreamingChatResponse_FinishReason(t *testing.T) {
 	assert.Equal(t, FinishReason("stop"), resp.Choices[0].FinishReason)
 }
 
+func TestParseStreamingChatResponse_Events(t *testing.T) {
+	t.Parallel()
+	mockBody := `data: {"choices":[{"index":0,"delta":{"role":"assistant","content":"Let me"}}]}
+data: {"choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"id":"call_1","type":"function","function":{"name":"add","arguments":""}}]}}]}
+data: {"choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"function":{"arguments":"{\"a\":1}"}}]}}]}
+data: {"choices":[{"index":0,"delta":{},"finish_reason":"tool_calls"}]}
+data: {"choices":[],"usage":{"prompt_tokens":10,"completion_tokens":5,"total_tokens":15}}
+data: [DONE]`
+	r := &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString(mockBody)),
+	}
+
+	var events []llms.StreamEvent
+	req := &ChatRequest{
+		Stream: true,
+		StreamingEventFunc: func(_ context.Context, event llms.StreamEvent) error {
+			events = append(events, event)
+			return nil
+		},
+	}
+
+	_, err := parseStreamingChatResponse(context.Background(), r, req)
+	require.NoError(t, err)
+
+	call := func(args string) *llms.ToolCall {
+		return &llms.ToolCall{ID: "call_1", Type: "function", FunctionCall: &llms.FunctionCall{Name: "add", Arguments: args}}
+	}
+	usage := llms.NewUsage(10, 5)
+	require.Equal(t, []llms.StreamEvent{
+		{Type: llms.StreamEventTextDelta, Text: "Let me"},
+		{Type: llms.StreamEventToolCallStart, ToolCall: call("")},
+		{Type: llms.StreamEventToolCallDelta, ToolCall: call(`{"a":1}`)},
+		{Type: llms.StreamEventToolCallEnd, ToolCall: call(`{"a":1}`)},
+		{Type: llms.StreamEventStop, StopReason: "tool_calls"},
+		{Type: llms.StreamEventUsage, Usage: &usage},
+	}, events)
+}
+
 func TestChatMessage_MarshalUnmarshal(t *testing.T) {
 	t.Parallel()
 	msg := ChatMessage{
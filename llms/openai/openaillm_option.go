@@ -48,6 +48,9 @@ type Option func(*options)
 // ResponseFormat is the response format for the OpenAI client.
 type ResponseFormat = openaiclient.ResponseFormat
 
+// ResponseFormatJSONSchema is the schema of a json_schema response format.
+type ResponseFormatJSONSchema = openaiclient.ResponseFormatJSONSchema
+
 // ResponseFormatJSON is the JSON response format.
 var ResponseFormatJSON = &ResponseFormat{Type: "json_object"} //nolint:gochecknoglobals
 
@@ -0,0 +1,57 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/jsonschema"
+	"github.com/tmc/langchaingo/llms"
+)
+
+func TestResponseSchemaStrict(t *testing.T) {
+	t.Parallel()
+
+	schema := jsonschema.Definition{
+		Type:       jsonschema.Object,
+		Properties: map[string]jsonschema.Definition{"name": {Type: jsonschema.String}},
+		Required:   []string{"name"},
+	}
+	for _, tc := range []struct {
+		option llms.CallOption
+		strict bool
+	}{
+		{option: llms.WithResponseSchema("person", schema)},
+		{option: llms.WithStrictResponseSchema("person", schema), strict: true},
+	} {
+		var request struct {
+			ResponseFormat struct {
+				Type       string         `json:"type"`
+				JSONSchema map[string]any `json:"json_schema"`
+			} `json:"response_format"`
+		}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"{\"name\":\"Ann\"}"}}]}`))
+		}))
+		defer server.Close()
+
+		llm, err := New(WithToken("test"), WithBaseURL(server.URL))
+		require.NoError(t, err)
+		_, err = llm.GenerateContent(context.Background(),
+			[]llms.MessageContent{llms.TextParts(llms.ChatMessageTypeHuman, "hi")}, tc.option)
+		require.NoError(t, err)
+
+		require.Equal(t, "json_schema", request.ResponseFormat.Type)
+		require.Equal(t, "person", request.ResponseFormat.JSONSchema["name"])
+		if tc.strict {
+			require.Equal(t, true, request.ResponseFormat.JSONSchema["strict"])
+		} else {
+			require.NotContains(t, request.ResponseFormat.JSONSchema, "strict")
+		}
+	}
+}
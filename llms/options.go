@@ -1,6 +1,10 @@
 package llms
 
-import "context"
+import (
+	"context"
+
+	"github.com/tmc/langchaingo/jsonschema"
+)
 
 // CallOption is a function that configures a CallOptions.
 type CallOption func(*CallOptions)
@@ -21,6 +25,10 @@ type CallOptions struct {
 	// StreamingFunc is a function to be called for each chunk of a streaming response.
 	// Return an error to stop streaming early.
 	StreamingFunc func(ctx context.Context, chunk []byte) error `json:"-"`
+	// StreamingEventFunc is a function to be called for each typed event of a
+	// streaming response. It can be used together with StreamingFunc.
+	// Return an error to stop streaming early.
+	StreamingEventFunc StreamEventFunc `json:"-"`
 	// TopK is the number of tokens to consider for top-k sampling.
 	TopK int `json:"top_k"`
 	// TopP is the cumulative probability for top-p sampling.
@@ -42,6 +50,8 @@ type CallOptions struct {
 
 	// JSONMode is a flag to enable JSON mode.
 	JSONMode bool `json:"json"`
+	// ResponseSchema is the schema of the JSON the model must respond with.
+	ResponseSchema *ResponseSchema `json:"response_schema,omitempty"`
 
 	// Tools is a list of tools to use. Each tool can be a specific tool or a function.
 	Tools []Tool `json:"tools,omitempty"`
@@ -155,6 +165,16 @@ func WithStreamingFunc(streamingFunc func(ctx context.Context, chunk []byte) err
 	}
 }
 
+// WithStreamingEventFunc will add an option to set a function to be called for
+// each typed event of a streaming response, such as text deltas and the start,
+// arguments and end of tool calls. It is supported by the openai, anthropic,
+// mistral, ollama and googleai models.
+func WithStreamingEventFunc(streamingEventFunc StreamEventFunc) CallOption {
+	return func(o *CallOptions) {
+		o.StreamingEventFunc = streamingEventFunc
+	}
+}
+
 // WithTopK will add an option to use top-k sampling.
 func WithTopK(topK int) CallOption {
 	return func(o *CallOptions) {
@@ -258,6 +278,24 @@ func WithJSONMode() CallOption {
 	}
 }
 
+// WithResponseSchema will add an option to constrain the response of the model
+// to JSON matching schema, using the native structured output feature of the
+// model. Use GenerateStructured to fall back to instructions in the prompt for
+// models without one and to decode the response into a Go value.
+func WithResponseSchema(name string, schema jsonschema.Definition) CallOption {
+	return func(o *CallOptions) {
+		o.ResponseSchema = &ResponseSchema{Name: name, Schema: schema}
+	}
+}
+
+// WithStrictResponseSchema is like WithResponseSchema, but also asks the
+// models that support it to follow the schema exactly.
+func WithStrictResponseSchema(name string, schema jsonschema.Definition) CallOption {
+	return func(o *CallOptions) {
+		o.ResponseSchema = &ResponseSchema{Name: name, Schema: schema, Strict: true}
+	}
+}
+
 // WithMetadata will add an option to set metadata to include in the request.
 // The meaning of this field is specific to the backend in use.
 func WithMetadata(metadata map[string]interface{}) CallOption {
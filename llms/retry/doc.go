@@ -0,0 +1,10 @@
+// Package retry provides an llms.Model wrapper that retries failed calls with
+// exponential backoff and jitter, and optionally limits the rate of requests
+// and tokens sent to the wrapped model.
+//
+// Errors are classified with IsRetryable by default: rate limits (429),
+// server errors (5xx), timeouts and temporary network errors are retried,
+// and the Retry-After delay of an llms.StatusError is honored. A Limiter can
+// be shared by several wrappers, eg: for different models of the same
+// account, and is safe for concurrent use.
+package retry
@@ -0,0 +1,112 @@
+package retry
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Limiter limits the number of requests and tokens per minute sent to a
+// model. It is safe for concurrent use and can be shared by several
+// Retriers.
+type Limiter struct {
+	mu       sync.Mutex
+	requests *bucket
+	tokens   *bucket
+}
+
+// NewLimiter creates a Limiter allowing requestsPerMinute requests and
+// tokensPerMinute tokens per minute. A limit of zero or less disables it.
+// Both limits allow bursts of up to a minute worth of usage.
+func NewLimiter(requestsPerMinute, tokensPerMinute int) *Limiter {
+	now := time.Now()
+	return &Limiter{
+		requests: newBucket(requestsPerMinute, now),
+		tokens:   newBucket(tokensPerMinute, now),
+	}
+}
+
+// Wait blocks until a request using the given number of tokens is allowed,
+// or ctx is done.
+func (l *Limiter) Wait(ctx context.Context, tokens int) error {
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		wait := max(l.requests.wait(1, now), l.tokens.wait(tokens, now))
+		if wait == 0 {
+			l.requests.take(1)
+			l.tokens.take(tokens)
+			l.mu.Unlock()
+			return nil
+		}
+		l.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Adjust corrects the number of tokens taken by Wait once the actual usage
+// of a request is known. A positive value takes more tokens, a negative one
+// gives tokens back.
+func (l *Limiter) Adjust(tokens int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tokens.refill(time.Now())
+	l.tokens.take(tokens)
+}
+
+// bucket is a token bucket refilled continuously at its per minute rate. A
+// nil bucket never limits.
+type bucket struct {
+	capacity  float64
+	available float64
+	perSecond float64
+	last      time.Time
+}
+
+func newBucket(perMinute int, now time.Time) *bucket {
+	if perMinute <= 0 {
+		return nil
+	}
+	return &bucket{
+		capacity:  float64(perMinute),
+		available: float64(perMinute),
+		perSecond: float64(perMinute) / 60,
+		last:      now,
+	}
+}
+
+func (b *bucket) refill(now time.Time) {
+	if b == nil {
+		return
+	}
+	b.available = min(b.capacity, b.available+now.Sub(b.last).Seconds()*b.perSecond)
+	b.last = now
+}
+
+// wait returns how long to wait until n units are available. Requests larger
+// than the capacity only wait for a full bucket.
+func (b *bucket) wait(n int, now time.Time) time.Duration {
+	if b == nil {
+		return 0
+	}
+	b.refill(now)
+	needed := min(float64(n), b.capacity) - b.available
+	if needed <= 0 {
+		return 0
+	}
+	return time.Duration(needed / b.perSecond * float64(time.Second))
+}
+
+func (b *bucket) take(n int) {
+	if b == nil {
+		return
+	}
+	b.available = min(b.capacity, b.available-float64(n))
+}
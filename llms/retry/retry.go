@@ -0,0 +1,251 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"regexp"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/tmc/langchaingo/llms"
+)
+
+const (
+	_defaultMaxRetries     = 3
+	_defaultInitialBackoff = 500 * time.Millisecond
+	_defaultMaxBackoff     = 30 * time.Second
+	_tokenApproximation    = 4
+)
+
+// Retrier is an LLM wrapper that retries failed calls to the LLM.
+type Retrier struct {
+	llm            llms.Model
+	maxRetries     int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	isRetryable    func(error) bool
+	limiter        *Limiter
+}
+
+// assert that `Retrier` implements the `llms.Model` interface.
+var _ llms.Model = (*Retrier)(nil)
+
+// Option is a function that configures a Retrier.
+type Option func(*Retrier)
+
+// WithMaxRetries sets the number of times a failed call is retried. The
+// default is 3.
+func WithMaxRetries(n int) Option {
+	return func(r *Retrier) {
+		r.maxRetries = n
+	}
+}
+
+// WithBackoff sets the delay before the first retry and the maximum delay
+// between retries. The delay doubles with each retry and is randomized
+// between half and all of its value. The defaults are 500ms and 30s.
+func WithBackoff(initial, maxBackoff time.Duration) Option {
+	return func(r *Retrier) {
+		r.initialBackoff = initial
+		r.maxBackoff = maxBackoff
+	}
+}
+
+// WithRetryable sets the function deciding which errors are retried. The
+// default is IsRetryable.
+func WithRetryable(isRetryable func(error) bool) Option {
+	return func(r *Retrier) {
+		r.isRetryable = isRetryable
+	}
+}
+
+// WithLimiter makes the Retrier wait for the limiter before every call,
+// including retries.
+func WithLimiter(limiter *Limiter) Option {
+	return func(r *Retrier) {
+		r.limiter = limiter
+	}
+}
+
+// New wraps a Model and retries its failed calls.
+//
+// The anthropic, cloudflare, cohere, ernie, huggingface, llamafile, maritaca,
+// ollama and openai models return an llms.StatusError for failed HTTP calls,
+// so their status codes and Retry-After delays are used by default. The
+// bedrock, googleai, local, mistral and watsonx models return the errors of
+// their SDKs or commands, which IsRetryable only classifies as timeouts,
+// dropped connections or from a status code in the message; use
+// WithRetryable to classify them further.
+func New(llm llms.Model, opts ...Option) *Retrier {
+	r := &Retrier{
+		llm:            llm,
+		maxRetries:     _defaultMaxRetries,
+		initialBackoff: _defaultInitialBackoff,
+		maxBackoff:     _defaultMaxBackoff,
+		isRetryable:    IsRetryable,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// SupportsResponseSchema implements llms.ResponseSchemaSupporter, reporting
+// whether the wrapped model supports response schemas.
+func (r *Retrier) SupportsResponseSchema() bool {
+	return llms.SupportsResponseSchema(r.llm)
+}
+
+// Call is a simplified interface for a text-only Model, generating a single
+// string response from a single string prompt.
+//
+// Deprecated: this method is retained for backwards compatibility. Use the
+// more general [GenerateContent] instead. You can also use
+// the [GenerateFromSinglePrompt] function which provides a similar capability
+// to Call and is built on top of the new interface.
+func (r *Retrier) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, r, prompt, options...)
+}
+
+// GenerateContent calls the wrapped model, retrying retryable errors. Calls
+// that already streamed part of their response are not retried, so the
+// streaming function never sees the same content twice.
+func (r *Retrier) GenerateContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) { //nolint:lll
+	var opts llms.CallOptions
+	for _, opt := range options {
+		opt(&opts)
+	}
+	tokens := estimateTokens(messages, opts)
+
+	for attempt := 0; ; attempt++ {
+		if r.limiter != nil {
+			if err := r.limiter.Wait(ctx, tokens); err != nil {
+				return nil, err
+			}
+		}
+
+		streamed := false
+		callOptions := options
+		if opts.StreamingFunc != nil {
+			callOptions = append(options[:len(options):len(options)],
+				llms.WithStreamingFunc(func(ctx context.Context, chunk []byte) error {
+					streamed = true
+					return opts.StreamingFunc(ctx, chunk)
+				}))
+		}
+		if opts.StreamingEventFunc != nil {
+			callOptions = append(callOptions[:len(callOptions):len(callOptions)],
+				llms.WithStreamingEventFunc(func(ctx context.Context, event llms.StreamEvent) error {
+					streamed = true
+					return opts.StreamingEventFunc(ctx, event)
+				}))
+		}
+
+		resp, err := r.llm.GenerateContent(ctx, messages, callOptions...)
+		if err == nil {
+			if r.limiter != nil && resp != nil && !resp.Usage.IsZero() {
+				r.limiter.Adjust(resp.Usage.TotalTokens - tokens)
+			}
+			return resp, nil
+		}
+
+		if attempt >= r.maxRetries || streamed || ctx.Err() != nil || !r.isRetryable(err) {
+			return nil, err
+		}
+
+		timer := time.NewTimer(r.delay(attempt, err))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// delay returns how long to wait before retrying after the given attempt
+// failed with err.
+func (r *Retrier) delay(attempt int, err error) time.Duration {
+	backoff := r.initialBackoff
+	for i := 0; i < attempt && backoff < r.maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > r.maxBackoff {
+		backoff = r.maxBackoff
+	}
+	if backoff > 1 {
+		backoff = backoff/2 + time.Duration(rand.Int63n(int64(backoff/2))) //nolint:gosec
+	}
+
+	var statusErr *llms.StatusError
+	if errors.As(err, &statusErr) && statusErr.RetryAfter > backoff {
+		return statusErr.RetryAfter
+	}
+	return backoff
+}
+
+var statusCodeRegexp = regexp.MustCompile(`status code:? (\d{3})`)
+
+// IsRetryable reports whether err is likely to be temporary: rate limits,
+// server errors, timeouts and dropped connections. Status codes are taken
+// from an llms.StatusError or, for clients that don't return one, from a
+// "status code: NNN" in the error message.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var statusErr *llms.StatusError
+	if errors.As(err, &statusErr) {
+		return isRetryableStatus(statusErr.StatusCode)
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	if errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+
+	if m := statusCodeRegexp.FindStringSubmatch(err.Error()); m != nil {
+		code, _ := strconv.Atoi(m[1])
+		return isRetryableStatus(code)
+	}
+	return false
+}
+
+func isRetryableStatus(code int) bool {
+	return code == http.StatusTooManyRequests ||
+		code == http.StatusRequestTimeout ||
+		code >= http.StatusInternalServerError
+}
+
+// estimateTokens approximates the number of tokens a call will use, counting
+// the text of the messages and the maximum number of generated tokens.
+func estimateTokens(messages []llms.MessageContent, opts llms.CallOptions) int {
+	chars := 0
+	for _, msg := range messages {
+		for _, part := range msg.Parts {
+			switch p := part.(type) {
+			case llms.TextContent:
+				chars += len([]rune(p.Text))
+			case llms.ToolCallResponse:
+				chars += len([]rune(p.Content))
+			case llms.ToolCall:
+				if p.FunctionCall != nil {
+					chars += len([]rune(p.FunctionCall.Arguments))
+				}
+			}
+		}
+	}
+	return chars/_tokenApproximation + opts.MaxTokens
+}
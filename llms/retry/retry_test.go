@@ -0,0 +1,112 @@
+package retry_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/llms/retry"
+)
+
+// flakyModel returns its errors in order before succeeding.
+type flakyModel struct {
+	errs   []error
+	stream bool
+	calls  int
+}
+
+func (m *flakyModel) GenerateContent(ctx context.Context, _ []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) { //nolint:lll
+	opts := llms.CallOptions{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	m.calls++
+	if m.stream && opts.StreamingFunc != nil {
+		if err := opts.StreamingFunc(ctx, []byte("partial")); err != nil {
+			return nil, err
+		}
+	}
+	if m.calls <= len(m.errs) {
+		return nil, m.errs[m.calls-1]
+	}
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: "ok"}}}, nil
+}
+
+func (m *flakyModel) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+func statusError(code int, retryAfter string) error {
+	resp := &http.Response{StatusCode: code, Header: http.Header{}}
+	if retryAfter != "" {
+		resp.Header.Set("Retry-After", retryAfter)
+	}
+	return llms.NewStatusError(resp, fmt.Sprintf("API returned unexpected status code: %d", code))
+}
+
+func TestRetrier(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	fast := retry.WithBackoff(time.Millisecond, 2*time.Millisecond)
+
+	model := &flakyModel{errs: []error{statusError(429, ""), statusError(503, "")}}
+	out, err := llms.GenerateFromSinglePrompt(ctx, retry.New(model, fast), "hi")
+	require.NoError(t, err)
+	require.Equal(t, "ok", out)
+	require.Equal(t, 3, model.calls)
+
+	model = &flakyModel{errs: []error{statusError(400, "")}}
+	_, err = llms.GenerateFromSinglePrompt(ctx, retry.New(model, fast), "hi")
+	require.Error(t, err)
+	require.Equal(t, 1, model.calls)
+
+	model = &flakyModel{errs: []error{statusError(500, ""), statusError(500, ""), statusError(500, "")}}
+	_, err = llms.GenerateFromSinglePrompt(ctx, retry.New(model, fast, retry.WithMaxRetries(2)), "hi")
+	require.Error(t, err)
+	require.Equal(t, 3, model.calls)
+
+	model = &flakyModel{errs: []error{statusError(500, "")}, stream: true}
+	_, err = llms.GenerateFromSinglePrompt(ctx, retry.New(model, fast), "hi",
+		llms.WithStreamingFunc(func(context.Context, []byte) error { return nil }))
+	require.Error(t, err)
+	require.Equal(t, 1, model.calls)
+
+	model = &flakyModel{errs: []error{statusError(429, "1")}}
+	start := time.Now()
+	_, err = llms.GenerateFromSinglePrompt(ctx, retry.New(model, fast), "hi")
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, time.Since(start), time.Second)
+}
+
+func TestIsRetryable(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, retry.IsRetryable(statusError(429, "")))
+	require.True(t, retry.IsRetryable(fmt.Errorf("wrapped: %w", statusError(502, ""))))
+	require.False(t, retry.IsRetryable(statusError(401, "")))
+	require.True(t, retry.IsRetryable(errors.New("API returned unexpected status code: 529: overloaded")))
+	require.False(t, retry.IsRetryable(errors.New("API returned unexpected status code: 404")))
+	require.True(t, retry.IsRetryable(context.DeadlineExceeded))
+	require.False(t, retry.IsRetryable(context.Canceled))
+	require.False(t, retry.IsRetryable(nil))
+}
+
+func TestLimiter(t *testing.T) {
+	t.Parallel()
+
+	limiter := retry.NewLimiter(0, 60)
+	require.NoError(t, limiter.Wait(context.Background(), 60))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, limiter.Wait(ctx, 1), context.DeadlineExceeded)
+
+	// Giving tokens back makes them available immediately.
+	limiter.Adjust(-10)
+	require.NoError(t, limiter.Wait(context.Background(), 10))
+}
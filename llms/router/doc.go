@@ -0,0 +1,8 @@
+// Package router provides an llms.Model that routes each call to one of
+// several underlying models, falling back to the others when it fails.
+//
+// The order in which the models are tried depends on the Strategy: always in
+// the given order, round-robin, randomly by weight, or fastest first. The
+// name of the model that served a call is added to the GenerationInfo of the
+// choices of the response and can be read with ServedBy.
+package router
@@ -0,0 +1,328 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/tmc/langchaingo/llms"
+)
+
+// GenerationInfoKey is the key of the name of the model that served a call in
+// the GenerationInfo of the choices of the response.
+const GenerationInfoKey = "RouterModel"
+
+// _latencyWeight is the weight of the latest call in the moving average of
+// the latency of a model.
+const _latencyWeight = 0.3
+
+// DefaultFailureCooldown is how long a model that failed a call is tried
+// last by the LeastLatency strategy when none is given.
+const DefaultFailureCooldown = 30 * time.Second
+
+var (
+	// ErrNoRoutes is returned by New when no routes are given.
+	ErrNoRoutes = errors.New("no routes given")
+	// ErrInvalidRoute is returned by New when a route has no model, a
+	// duplicate name or a negative weight.
+	ErrInvalidRoute = errors.New("invalid route")
+)
+
+// Strategy decides in which order the models of a Router are tried.
+type Strategy int
+
+const (
+	// Fallback always tries the models in the order they were given.
+	Fallback Strategy = iota
+	// RoundRobin starts with the next model on every call.
+	RoundRobin
+	// Weighted starts with a model picked randomly in proportion to the
+	// weights of the routes.
+	Weighted
+	// LeastLatency starts with the model with the lowest average latency.
+	// Models that haven't served a call yet are tried first, to measure
+	// them, and models that failed a call are tried last until their
+	// failure cooldown is over.
+	LeastLatency
+)
+
+// Route is a model a Router can send calls to.
+type Route struct {
+	// Name identifies the model in responses and errors.
+	Name string
+	// Model is the model calls are sent to.
+	Model llms.Model
+	// Weight is the relative share of calls sent first to this model with
+	// the Weighted strategy. Zero means 1.
+	Weight int
+}
+
+// Router is an llms.Model that sends each call to one of its routes, trying
+// the others in turn when a call fails. It is safe for concurrent use.
+type Router struct {
+	routes          []Route
+	strategy        Strategy
+	shouldFallback  func(error) bool
+	failureCooldown time.Duration
+
+	mu       sync.Mutex
+	next     int
+	latency  []time.Duration
+	measured []bool
+	failedAt []time.Time
+	rand     *rand.Rand
+}
+
+// assert that `Router` implements the `llms.Model` interface.
+var _ llms.Model = (*Router)(nil)
+
+// Option is a function that configures a Router.
+type Option func(*Router)
+
+// WithStrategy sets the strategy of the router. The default is Fallback.
+func WithStrategy(strategy Strategy) Option {
+	return func(r *Router) {
+		r.strategy = strategy
+	}
+}
+
+// WithShouldFallback sets the function deciding which errors make the router
+// try the next model. By default every error except a canceled context does.
+func WithShouldFallback(shouldFallback func(error) bool) Option {
+	return func(r *Router) {
+		r.shouldFallback = shouldFallback
+	}
+}
+
+// WithFailureCooldown sets how long a model that failed a call is tried last
+// by the LeastLatency strategy. The default is DefaultFailureCooldown.
+func WithFailureCooldown(cooldown time.Duration) Option {
+	return func(r *Router) {
+		r.failureCooldown = cooldown
+	}
+}
+
+// New creates a Router over routes.
+func New(routes []Route, opts ...Option) (*Router, error) {
+	if len(routes) == 0 {
+		return nil, ErrNoRoutes
+	}
+	names := make(map[string]bool, len(routes))
+	for i, route := range routes {
+		if route.Model == nil || route.Weight < 0 || names[route.Name] {
+			return nil, fmt.Errorf("%w: route %d (%q)", ErrInvalidRoute, i, route.Name)
+		}
+		names[route.Name] = true
+	}
+
+	r := &Router{
+		routes:          routes,
+		shouldFallback:  defaultShouldFallback,
+		failureCooldown: DefaultFailureCooldown,
+		latency:         make([]time.Duration, len(routes)),
+		measured:        make([]bool, len(routes)),
+		failedAt:        make([]time.Time, len(routes)),
+		rand:            rand.New(rand.NewSource(time.Now().UnixNano())), //nolint:gosec
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r, nil
+}
+
+// SupportsResponseSchema implements llms.ResponseSchemaSupporter, reporting
+// whether the models of all routes support response schemas.
+func (r *Router) SupportsResponseSchema() bool {
+	for _, route := range r.routes {
+		if !llms.SupportsResponseSchema(route.Model) {
+			return false
+		}
+	}
+	return len(r.routes) > 0
+}
+
+// Call is a simplified interface for a text-only Model, generating a single
+// string response from a single string prompt.
+//
+// Deprecated: this method is retained for backwards compatibility. Use the
+// more general [GenerateContent] instead. You can also use
+// the [GenerateFromSinglePrompt] function which provides a similar capability
+// to Call and is built on top of the new interface.
+func (r *Router) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, r, prompt, options...)
+}
+
+// GenerateContent sends the call to the models in the order given by the
+// strategy until one succeeds. A call that already streamed part of its
+// response doesn't fall back. If all models fail the errors of all of them
+// are returned.
+func (r *Router) GenerateContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) { //nolint:lll
+	var opts llms.CallOptions
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	var errs []error
+	for _, i := range r.order() {
+		route := r.routes[i]
+
+		streamed := false
+		callOptions := options
+		if opts.StreamingFunc != nil {
+			callOptions = append(options[:len(options):len(options)],
+				llms.WithStreamingFunc(func(ctx context.Context, chunk []byte) error {
+					streamed = true
+					return opts.StreamingFunc(ctx, chunk)
+				}))
+		}
+		if opts.StreamingEventFunc != nil {
+			callOptions = append(callOptions[:len(callOptions):len(callOptions)],
+				llms.WithStreamingEventFunc(func(ctx context.Context, event llms.StreamEvent) error {
+					streamed = true
+					return opts.StreamingEventFunc(ctx, event)
+				}))
+		}
+
+		start := time.Now()
+		resp, err := route.Model.GenerateContent(ctx, messages, callOptions...)
+		if err == nil {
+			r.recordLatency(i, time.Since(start))
+			setServedBy(resp, route.Name)
+			return resp, nil
+		}
+
+		errs = append(errs, fmt.Errorf("%s: %w", route.Name, err))
+		if ctx.Err() == nil {
+			r.recordFailure(i)
+		}
+		if streamed || ctx.Err() != nil || !r.shouldFallback(err) {
+			break
+		}
+	}
+	return nil, errors.Join(errs...)
+}
+
+// ServedBy returns the name of the route that served resp, or "" if resp
+// wasn't returned by a Router. The name is stored in the GenerationInfo of
+// the choices of resp, so it is also "" for a response without choices.
+func ServedBy(resp *llms.ContentResponse) string {
+	if resp == nil {
+		return ""
+	}
+	for _, choice := range resp.Choices {
+		if name, ok := choice.GenerationInfo[GenerationInfoKey].(string); ok {
+			return name
+		}
+	}
+	return ""
+}
+
+func setServedBy(resp *llms.ContentResponse, name string) {
+	if resp == nil {
+		return
+	}
+	for _, choice := range resp.Choices {
+		if choice.GenerationInfo == nil {
+			choice.GenerationInfo = make(map[string]any, 1)
+		}
+		choice.GenerationInfo[GenerationInfoKey] = name
+	}
+}
+
+// order returns the indexes of the routes in the order they should be tried.
+func (r *Router) order() []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	n := len(r.routes)
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+
+	switch r.strategy {
+	case RoundRobin:
+		start := r.next
+		r.next = (r.next + 1) % n
+		return rotate(order, start)
+	case Weighted:
+		return rotate(order, r.pickWeighted())
+	case LeastLatency:
+		// Models cooling down after a failure go last, then models not
+		// measured yet go first. Stable, so models with the same latency keep
+		// their order.
+		now := time.Now()
+		coolingDown := make([]bool, n)
+		for i, failedAt := range r.failedAt {
+			coolingDown[i] = !failedAt.IsZero() && now.Sub(failedAt) < r.failureCooldown
+		}
+		sort.SliceStable(order, func(a, b int) bool {
+			i, j := order[a], order[b]
+			if coolingDown[i] != coolingDown[j] {
+				return coolingDown[j]
+			}
+			if r.measured[i] != r.measured[j] {
+				return r.measured[j]
+			}
+			return r.latency[i] < r.latency[j]
+		})
+		return order
+	case Fallback:
+		fallthrough
+	default:
+		return order
+	}
+}
+
+func (r *Router) pickWeighted() int {
+	total := 0
+	for _, route := range r.routes {
+		total += weight(route)
+	}
+	pick := r.rand.Intn(total)
+	for i, route := range r.routes {
+		pick -= weight(route)
+		if pick < 0 {
+			return i
+		}
+	}
+	return 0
+}
+
+func (r *Router) recordLatency(i int, latency time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.failedAt[i] = time.Time{}
+	if !r.measured[i] {
+		r.latency[i] = latency
+		r.measured[i] = true
+		return
+	}
+	r.latency[i] = time.Duration(_latencyWeight*float64(latency) + (1-_latencyWeight)*float64(r.latency[i]))
+}
+
+func (r *Router) recordFailure(i int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.failedAt[i] = time.Now()
+}
+
+func weight(route Route) int {
+	if route.Weight == 0 {
+		return 1
+	}
+	return route.Weight
+}
+
+// rotate returns order starting at index start and wrapping around.
+func rotate(order []int, start int) []int {
+	return append(order[start:], order[:start]...)
+}
+
+func defaultShouldFallback(err error) bool {
+	return !errors.Is(err, context.Canceled)
+}
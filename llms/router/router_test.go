@@ -0,0 +1,161 @@
+package router_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/llms/router"
+)
+
+type fakeModel struct {
+	content string
+	err     error
+	delay   time.Duration
+	calls   int
+}
+
+func (m *fakeModel) GenerateContent(_ context.Context, _ []llms.MessageContent, _ ...llms.CallOption) (*llms.ContentResponse, error) { //nolint:lll
+	m.calls++
+	time.Sleep(m.delay)
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: m.content}}}, nil
+}
+
+func (m *fakeModel) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+func generate(t *testing.T, r *router.Router) (string, *llms.ContentResponse) {
+	t.Helper()
+	resp, err := r.GenerateContent(context.Background(), []llms.MessageContent{
+		llms.TextParts(llms.ChatMessageTypeHuman, "hi"),
+	})
+	require.NoError(t, err)
+	return resp.Choices[0].Content, resp
+}
+
+func TestFallback(t *testing.T) {
+	t.Parallel()
+
+	down := &fakeModel{err: errors.New("connection refused")}
+	up := &fakeModel{content: "from anthropic"}
+	r, err := router.New([]router.Route{
+		{Name: "openai", Model: down},
+		{Name: "anthropic", Model: up},
+	})
+	require.NoError(t, err)
+
+	content, resp := generate(t, r)
+	require.Equal(t, "from anthropic", content)
+	require.Equal(t, "anthropic", router.ServedBy(resp))
+	require.Equal(t, 1, down.calls)
+
+	up.err = errors.New("overloaded")
+	_, err = r.GenerateContent(context.Background(), nil)
+	require.ErrorContains(t, err, "openai: connection refused")
+	require.ErrorContains(t, err, "anthropic: overloaded")
+}
+
+type noChoicesModel struct{ fakeModel }
+
+func (m *noChoicesModel) GenerateContent(_ context.Context, _ []llms.MessageContent, _ ...llms.CallOption) (*llms.ContentResponse, error) { //nolint:lll
+	return &llms.ContentResponse{}, nil
+}
+
+func TestServedByWithoutChoices(t *testing.T) {
+	t.Parallel()
+
+	r, err := router.New([]router.Route{{Name: "a", Model: &noChoicesModel{}}})
+	require.NoError(t, err)
+
+	resp, err := r.GenerateContent(context.Background(), nil)
+	require.NoError(t, err)
+	require.Empty(t, resp.Choices)
+	require.Empty(t, router.ServedBy(resp))
+}
+
+func TestRoundRobin(t *testing.T) {
+	t.Parallel()
+
+	a, b := &fakeModel{content: "a"}, &fakeModel{content: "b"}
+	r, err := router.New([]router.Route{{Name: "a", Model: a}, {Name: "b", Model: b}},
+		router.WithStrategy(router.RoundRobin))
+	require.NoError(t, err)
+
+	for _, want := range []string{"a", "b", "a", "b"} {
+		content, _ := generate(t, r)
+		require.Equal(t, want, content)
+	}
+}
+
+func TestWeighted(t *testing.T) {
+	t.Parallel()
+
+	a, b := &fakeModel{content: "a"}, &fakeModel{content: "b"}
+	r, err := router.New([]router.Route{{Name: "a", Model: a, Weight: 3}, {Name: "b", Model: b, Weight: 1}},
+		router.WithStrategy(router.Weighted))
+	require.NoError(t, err)
+
+	for i := 0; i < 400; i++ {
+		generate(t, r)
+	}
+	require.InDelta(t, 300, a.calls, 60)
+	require.Equal(t, 400, a.calls+b.calls)
+}
+
+func TestLeastLatency(t *testing.T) {
+	t.Parallel()
+
+	slow, fast := &fakeModel{content: "slow", delay: 20 * time.Millisecond}, &fakeModel{content: "fast"}
+	r, err := router.New([]router.Route{{Name: "slow", Model: slow}, {Name: "fast", Model: fast}},
+		router.WithStrategy(router.LeastLatency))
+	require.NoError(t, err)
+
+	// Both are tried once before their latency is known.
+	generate(t, r)
+	generate(t, r)
+	content, _ := generate(t, r)
+	require.Equal(t, "fast", content)
+	require.Equal(t, 1, slow.calls)
+}
+
+func TestLeastLatencyFailingRoute(t *testing.T) {
+	t.Parallel()
+
+	down, up := &fakeModel{err: errors.New("connection refused")}, &fakeModel{content: "up"}
+	r, err := router.New([]router.Route{{Name: "down", Model: down}, {Name: "up", Model: up}},
+		router.WithStrategy(router.LeastLatency), router.WithFailureCooldown(50*time.Millisecond))
+	require.NoError(t, err)
+
+	// The failing model is tried first only until it fails.
+	for i := 0; i < 3; i++ {
+		content, _ := generate(t, r)
+		require.Equal(t, "up", content)
+	}
+	require.Equal(t, 1, down.calls)
+	require.Equal(t, 3, up.calls)
+
+	// It is tried again once its cooldown is over, and served once it recovers.
+	time.Sleep(60 * time.Millisecond)
+	down.err = nil
+	down.content = "down"
+	content, _ := generate(t, r)
+	require.Equal(t, "down", content)
+	require.Equal(t, 2, down.calls)
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	_, err := router.New(nil)
+	require.ErrorIs(t, err, router.ErrNoRoutes)
+
+	_, err = router.New([]router.Route{{Name: "a", Model: &fakeModel{}}, {Name: "a", Model: &fakeModel{}}})
+	require.ErrorIs(t, err, router.ErrInvalidRoute)
+}
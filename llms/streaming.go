@@ -0,0 +1,50 @@
+package llms
+
+import "context"
+
+// StreamEventType is the type of a StreamEvent.
+type StreamEventType string
+
+const (
+	// StreamEventTextDelta carries the next fragment of the text of the
+	// response in Text.
+	StreamEventTextDelta StreamEventType = "text_delta"
+	// StreamEventReasoningDelta carries the next fragment of the reasoning
+	// (thinking) of the model in Text, for models that expose it.
+	StreamEventReasoningDelta StreamEventType = "reasoning_delta"
+	// StreamEventToolCallStart is sent when the model starts a tool call.
+	// ToolCall has the ID and function name of the call.
+	StreamEventToolCallStart StreamEventType = "tool_call_start"
+	// StreamEventToolCallDelta carries the next fragment of the JSON
+	// arguments of a tool call in ToolCall.FunctionCall.Arguments.
+	StreamEventToolCallDelta StreamEventType = "tool_call_delta"
+	// StreamEventToolCallEnd is sent when a tool call is complete. ToolCall
+	// has the complete arguments of the call.
+	StreamEventToolCallEnd StreamEventType = "tool_call_end"
+	// StreamEventUsage carries the token usage of the call in Usage.
+	StreamEventUsage StreamEventType = "usage"
+	// StreamEventStop is sent when the model stops generating, with the
+	// provider specific reason in StopReason.
+	StreamEventStop StreamEventType = "stop"
+)
+
+// StreamEvent is a typed event of a streaming response. Which fields are set
+// depends on Type.
+type StreamEvent struct {
+	Type StreamEventType
+	// Index is the position of the tool call in the response, for tool call
+	// events. Events of concurrent tool calls can be interleaved.
+	Index int
+	// Text is the text or reasoning fragment.
+	Text string
+	// ToolCall is the tool call the event is about.
+	ToolCall *ToolCall
+	// Usage is the token usage reported by the provider.
+	Usage *Usage
+	// StopReason is the reason the model stopped generating.
+	StopReason string
+}
+
+// StreamEventFunc is a function called for each event of a streaming
+// response. Return an error to stop streaming early.
+type StreamEventFunc func(ctx context.Context, event StreamEvent) error
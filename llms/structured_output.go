@@ -0,0 +1,123 @@
+package llms
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/tmc/langchaingo/jsonschema"
+)
+
+// ErrInvalidStructuredOutput is returned by GenerateStructured when the
+// response of the model doesn't match the schema of the requested type.
+var ErrInvalidStructuredOutput = errors.New("invalid structured output")
+
+// ResponseSchema describes the JSON value a model must respond with.
+type ResponseSchema struct {
+	// Name is the name of the schema. Some providers require it to only
+	// contain letters, digits, underscores and dashes.
+	Name string `json:"name"`
+	// Description describes the value to the model.
+	Description string `json:"description,omitempty"`
+	// Schema is the JSON schema of the value. Its type must be an object.
+	Schema jsonschema.Definition `json:"schema"`
+	// Strict makes providers that support it, like openai, follow the schema
+	// exactly. OpenAI then requires every property to be required.
+	Strict bool `json:"strict,omitempty"`
+}
+
+// ResponseSchemaSupporter is implemented by models that honor the
+// ResponseSchema call option with a native structured output feature.
+type ResponseSchemaSupporter interface {
+	SupportsResponseSchema() bool
+}
+
+// SupportsResponseSchema reports whether model constrains its responses to
+// the ResponseSchema call option natively.
+func SupportsResponseSchema(model Model) bool {
+	s, ok := model.(ResponseSchemaSupporter)
+	return ok && s.SupportsResponseSchema()
+}
+
+// GenerateStructured asks the model for a JSON response matching the schema
+// of T, as derived by jsonschema.Reflect, and decodes it into a T.
+//
+// Models that support it use their native structured output feature. For
+// other models the schema is added to the last message as instructions. The
+// response is validated against the schema in both cases.
+func GenerateStructured[T any](ctx context.Context, model Model, messages []MessageContent, options ...CallOption) (T, error) { //nolint:lll
+	var result T
+	schema, err := jsonschema.Reflect(result)
+	if err != nil {
+		return result, err
+	}
+	if schema.Type != jsonschema.Object {
+		return result, fmt.Errorf("%w: structured output must be an object, got %T",
+			jsonschema.ErrUnsupportedType, result)
+	}
+
+	name := reflect.TypeOf(result).Name()
+	if name == "" || strings.ContainsAny(name, "[]., ") {
+		name = "response"
+	}
+	options = append(options[:len(options):len(options)], WithResponseSchema(name, schema))
+
+	if !SupportsResponseSchema(model) {
+		messages, err = withSchemaInstructions(messages, schema)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	resp, err := model.GenerateContent(ctx, messages, options...)
+	if err != nil {
+		return result, err
+	}
+	if len(resp.Choices) == 0 {
+		return result, errors.New("empty response from model")
+	}
+
+	content := []byte(trimCodeFence(resp.Choices[0].Content))
+	if err := schema.Validate(content); err != nil {
+		return result, fmt.Errorf("%w: %w", ErrInvalidStructuredOutput, err)
+	}
+	if err := json.Unmarshal(content, &result); err != nil {
+		return result, fmt.Errorf("%w: %w", ErrInvalidStructuredOutput, err)
+	}
+	return result, nil
+}
+
+// withSchemaInstructions returns a copy of messages with instructions to
+// respond with JSON matching schema added to the last message.
+func withSchemaInstructions(messages []MessageContent, schema jsonschema.Definition) ([]MessageContent, error) {
+	if len(messages) == 0 {
+		return nil, errors.New("no messages to add the response schema to")
+	}
+	b, err := json.Marshal(schema)
+	if err != nil {
+		return nil, err
+	}
+	instructions := "Respond only with a JSON object, without any other text, " +
+		"that matches the following JSON schema:\n" + string(b)
+
+	messages = append([]MessageContent(nil), messages...)
+	last := messages[len(messages)-1]
+	last.Parts = append(last.Parts[:len(last.Parts):len(last.Parts)], TextContent{Text: instructions})
+	messages[len(messages)-1] = last
+	return messages, nil
+}
+
+// trimCodeFence removes the markdown code fence models often wrap JSON in.
+func trimCodeFence(s string) string {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "```") {
+		return s
+	}
+	s = strings.TrimPrefix(s, "```")
+	s = strings.TrimPrefix(s, "json")
+	s = strings.TrimSuffix(strings.TrimSpace(s), "```")
+	return strings.TrimSpace(s)
+}
@@ -0,0 +1,72 @@
+package llms
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type structuredModel struct {
+	native   bool
+	response string
+	messages []MessageContent
+	opts     CallOptions
+}
+
+func (m *structuredModel) GenerateContent(_ context.Context, messages []MessageContent, options ...CallOption) (*ContentResponse, error) { //nolint:lll
+	m.messages = messages
+	for _, opt := range options {
+		opt(&m.opts)
+	}
+	return &ContentResponse{Choices: []*ContentChoice{{Content: m.response}}}, nil
+}
+
+func (m *structuredModel) Call(ctx context.Context, prompt string, options ...CallOption) (string, error) {
+	return GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+func (m *structuredModel) SupportsResponseSchema() bool {
+	return m.native
+}
+
+type Person struct {
+	Name string `json:"name"`
+	Age  int    `json:"age"`
+}
+
+func TestGenerateStructured(t *testing.T) {
+	t.Parallel()
+
+	messages := []MessageContent{TextParts(ChatMessageTypeHuman, "Who is Ann?")}
+
+	t.Run("native", func(t *testing.T) {
+		t.Parallel()
+		model := &structuredModel{native: true, response: `{"name":"Ann","age":30}`}
+		person, err := GenerateStructured[Person](context.Background(), model, messages)
+		require.NoError(t, err)
+		require.Equal(t, Person{Name: "Ann", Age: 30}, person)
+		require.NotNil(t, model.opts.ResponseSchema)
+		require.Equal(t, "Person", model.opts.ResponseSchema.Name)
+		require.Equal(t, []string{"name", "age"}, model.opts.ResponseSchema.Schema.Required)
+		require.Len(t, model.messages[0].Parts, 1)
+	})
+
+	t.Run("fallback", func(t *testing.T) {
+		t.Parallel()
+		model := &structuredModel{response: "```json\n{\"name\":\"Ann\",\"age\":30}\n```"}
+		person, err := GenerateStructured[Person](context.Background(), model, messages)
+		require.NoError(t, err)
+		require.Equal(t, Person{Name: "Ann", Age: 30}, person)
+		require.Len(t, model.messages[0].Parts, 2)
+		require.Contains(t, model.messages[0].Parts[1].(TextContent).Text, `"required":["name","age"]`)
+		require.Len(t, messages[0].Parts, 1)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+		model := &structuredModel{response: `{"name":"Ann"}`}
+		_, err := GenerateStructured[Person](context.Background(), model, messages)
+		require.ErrorIs(t, err, ErrInvalidStructuredOutput)
+	})
+}
@@ -59,6 +59,7 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 				Content: result.Text,
 			},
 		},
+		Usage: llms.NewUsage(result.InputTokenCount, result.GeneratedTokenCount),
 	}
 	return resp, nil
 }
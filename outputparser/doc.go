@@ -8,6 +8,10 @@ The outputparser package includes the following parsers:
   - Simple: a basic parser that returns the raw text as-is without any processing.
   - Structured: a parser that expects a JSON-formatted response and returns it as
     a map[string]string while validating against a provided schema.
+  - Typed: a parser that describes a Go struct type with a JSON schema in its
+    format instructions and parses and validates a JSON response into it.
+  - OutputFixing and Retry: parsers that wrap another parser and ask an LLM to
+    correct the output when the wrapped parser fails.
   - Combining: a parser that combines the output of multiple parsers into a single parser.
   - CommaSeparatedList: a parser that takes a string with comma-separated values
     and returns them as a string slice.
@@ -0,0 +1,195 @@
+package outputparser
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/schema"
+)
+
+const (
+	// _outputFixingTemplate is the prompt used by the output fixing parser.
+	// The verbs are the format instructions, the completion and the error.
+	_outputFixingTemplate = `Instructions:
+--------------
+%s
+--------------
+Completion:
+--------------
+%s
+--------------
+
+Above, the Completion did not satisfy the constraints given in the Instructions.
+Error:
+--------------
+%s
+--------------
+
+Please try again. Please only respond with an answer that satisfies the constraints laid out in the Instructions:`
+
+	// _retryTemplate is the prompt used by the retry parser. The verbs are
+	// the original prompt, the completion and the error.
+	_retryTemplate = `Prompt:
+%s
+Completion:
+%s
+
+Above, the Completion did not satisfy the constraints given in the Prompt.
+Details: %s
+Please try again:`
+)
+
+// ContextParser is implemented by output parsers that call a model while
+// parsing, so the context of the call can be passed to them.
+type ContextParser[T any] interface {
+	// ParseWithPromptContext parses the output of an LLM call with the prompt
+	// used, using ctx for the model calls made while parsing.
+	ParseWithPromptContext(ctx context.Context, text string, prompt llms.PromptValue) (T, error)
+}
+
+// OutputFixing is an output parser that wraps another parser. When the wrapped
+// parser fails, it asks an LLM to fix the completion given the format
+// instructions of the wrapped parser and the parse error, up to MaxRetries
+// times.
+type OutputFixing[T any] struct {
+	Parser     schema.OutputParser[T]
+	LLM        llms.Model
+	MaxRetries int
+}
+
+// NewOutputFixing creates a new output fixing parser.
+func NewOutputFixing[T any](llm llms.Model, parser schema.OutputParser[T], maxRetries int) OutputFixing[T] {
+	return OutputFixing[T]{
+		Parser:     parser,
+		LLM:        llm,
+		MaxRetries: maxRetries,
+	}
+}
+
+// Statically assert that OutputFixing implement the OutputParser and ContextParser interfaces.
+var (
+	_ schema.OutputParser[any] = OutputFixing[any]{}
+	_ ContextParser[any]       = OutputFixing[any]{}
+)
+
+// Parse parses the output of an LLM with the wrapped parser, fixing it with
+// the LLM if it fails.
+func (p OutputFixing[T]) Parse(text string) (T, error) {
+	return p.ParseWithPromptContext(context.Background(), text, nil)
+}
+
+// ParseWithPrompt does the same as Parse, passing the prompt to the wrapped parser.
+func (p OutputFixing[T]) ParseWithPrompt(text string, prompt llms.PromptValue) (T, error) {
+	return p.ParseWithPromptContext(context.Background(), text, prompt)
+}
+
+// ParseWithPromptContext does the same as ParseWithPrompt, using ctx for the
+// LLM calls.
+func (p OutputFixing[T]) ParseWithPromptContext(ctx context.Context, text string, prompt llms.PromptValue) (T, error) { //nolint:lll
+	return retryParse(ctx, p.LLM, p.MaxRetries, text,
+		func(text string) (T, error) { return parseWithPrompt(p.Parser, text, prompt) },
+		func(completion string, err error) string {
+			return fmt.Sprintf(_outputFixingTemplate, p.Parser.GetFormatInstructions(), completion, err)
+		},
+	)
+}
+
+// GetFormatInstructions returns the format instructions of the wrapped parser.
+func (p OutputFixing[T]) GetFormatInstructions() string {
+	return p.Parser.GetFormatInstructions()
+}
+
+// Type returns the type of the output parser.
+func (p OutputFixing[T]) Type() string {
+	return "output_fixing_parser"
+}
+
+// Retry is an output parser that wraps another parser. When the wrapped
+// parser fails, it asks an LLM to answer the original prompt again given the
+// failed completion and the parse error, up to MaxRetries times. It needs the
+// prompt, so only ParseWithPrompt retries; Parse just uses the wrapped parser.
+type Retry[T any] struct {
+	Parser     schema.OutputParser[T]
+	LLM        llms.Model
+	MaxRetries int
+}
+
+// NewRetry creates a new retry parser.
+func NewRetry[T any](llm llms.Model, parser schema.OutputParser[T], maxRetries int) Retry[T] {
+	return Retry[T]{
+		Parser:     parser,
+		LLM:        llm,
+		MaxRetries: maxRetries,
+	}
+}
+
+// Statically assert that Retry implement the OutputParser and ContextParser interfaces.
+var (
+	_ schema.OutputParser[any] = Retry[any]{}
+	_ ContextParser[any]       = Retry[any]{}
+)
+
+// Parse parses the output of an LLM with the wrapped parser, without retrying.
+func (p Retry[T]) Parse(text string) (T, error) {
+	return p.Parser.Parse(text)
+}
+
+// ParseWithPrompt parses the output of an LLM with the wrapped parser, asking
+// the LLM to answer the prompt again if it fails.
+func (p Retry[T]) ParseWithPrompt(text string, prompt llms.PromptValue) (T, error) {
+	return p.ParseWithPromptContext(context.Background(), text, prompt)
+}
+
+// ParseWithPromptContext does the same as ParseWithPrompt, using ctx for the
+// LLM calls.
+func (p Retry[T]) ParseWithPromptContext(ctx context.Context, text string, prompt llms.PromptValue) (T, error) {
+	if prompt == nil {
+		return p.Parser.Parse(text)
+	}
+	return retryParse(ctx, p.LLM, p.MaxRetries, text,
+		func(text string) (T, error) { return p.Parser.ParseWithPrompt(text, prompt) },
+		func(completion string, err error) string {
+			return fmt.Sprintf(_retryTemplate, prompt.String(), completion, err)
+		},
+	)
+}
+
+// GetFormatInstructions returns the format instructions of the wrapped parser.
+func (p Retry[T]) GetFormatInstructions() string {
+	return p.Parser.GetFormatInstructions()
+}
+
+// Type returns the type of the output parser.
+func (p Retry[T]) Type() string {
+	return "retry_with_error_parser"
+}
+
+// retryParse parses text, asking llm for a new completion with the prompt
+// returned by reprompt each time parsing fails, up to maxRetries times.
+func retryParse[T any](
+	ctx context.Context,
+	llm llms.Model,
+	maxRetries int,
+	text string,
+	parse func(text string) (T, error),
+	reprompt func(completion string, err error) string,
+) (T, error) {
+	for attempt := 0; ; attempt++ {
+		result, err := parse(text)
+		if err == nil || attempt >= maxRetries {
+			return result, err
+		}
+		text, err = llms.GenerateFromSinglePrompt(ctx, llm, reprompt(text, err))
+		if err != nil {
+			return result, err
+		}
+	}
+}
+
+func parseWithPrompt[T any](parser schema.OutputParser[T], text string, prompt llms.PromptValue) (T, error) {
+	if prompt == nil {
+		return parser.Parse(text)
+	}
+	return parser.ParseWithPrompt(text, prompt)
+}
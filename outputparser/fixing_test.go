@@ -0,0 +1,73 @@
+package outputparser
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/prompts"
+)
+
+type fixingModel struct {
+	responses []string
+	prompts   []string
+}
+
+func (m *fixingModel) GenerateContent(_ context.Context, messages []llms.MessageContent, _ ...llms.CallOption) (*llms.ContentResponse, error) { //nolint:lll
+	m.prompts = append(m.prompts, messages[0].Parts[0].(llms.TextContent).Text)
+	response := m.responses[0]
+	m.responses = m.responses[1:]
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: response}}}, nil
+}
+
+func (m *fixingModel) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+func TestOutputFixing(t *testing.T) {
+	t.Parallel()
+
+	model := &fixingModel{responses: []string{"maybe", "YES"}}
+	parser := NewOutputFixing[any](model, NewBooleanParser(), 2)
+
+	got, err := parser.Parse("perhaps")
+	require.NoError(t, err)
+	require.Equal(t, true, got)
+	require.Len(t, model.prompts, 2)
+	require.Contains(t, model.prompts[0], "Completion:\n--------------\nperhaps\n")
+	require.Contains(t, model.prompts[0], NewBooleanParser().GetFormatInstructions())
+	require.Contains(t, model.prompts[1], "Completion:\n--------------\nmaybe\n")
+}
+
+func TestOutputFixingMaxRetries(t *testing.T) {
+	t.Parallel()
+
+	model := &fixingModel{responses: []string{"maybe", "YES"}}
+	parser := NewOutputFixing[any](model, NewBooleanParser(), 1)
+
+	_, err := parser.Parse("perhaps")
+	var parseErr ParseError
+	require.ErrorAs(t, err, &parseErr)
+	require.Len(t, model.prompts, 1)
+}
+
+func TestRetry(t *testing.T) {
+	t.Parallel()
+
+	model := &fixingModel{responses: []string{"NO"}}
+	parser := NewRetry[any](model, NewBooleanParser(), 3)
+	prompt, err := prompts.NewPromptTemplate("Is {{.city}} in France?", []string{"city"}).
+		FormatPrompt(map[string]any{"city": "Paris"})
+	require.NoError(t, err)
+
+	got, err := parser.ParseWithPrompt("I think so", prompt)
+	require.NoError(t, err)
+	require.Equal(t, false, got)
+	require.Len(t, model.prompts, 1)
+	require.Contains(t, model.prompts[0], "Prompt:\nIs Paris in France?\nCompletion:\nI think so\n")
+
+	_, err = parser.Parse("I think so")
+	require.Error(t, err)
+	require.Len(t, model.prompts, 1)
+}
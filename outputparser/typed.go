@@ -0,0 +1,97 @@
+package outputparser
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/tmc/langchaingo/jsonschema"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/schema"
+)
+
+// _typedFormatInstructionTemplate is a template for the format instructions
+// of the typed output parser. The verb is the JSON schema of the output.
+const _typedFormatInstructionTemplate = "The output should be a markdown code snippet containing a JSON object that matches the following JSON schema: \n```json\n%s\n```" // nolint
+
+// Typed is an output parser that parses the output of an LLM into a value of
+// type T. The format instructions contain the JSON schema of T, derived from
+// its fields and their json, description and enum tags by jsonschema.Reflect,
+// and the output is validated against that schema before it is decoded.
+type Typed[T any] struct {
+	Schema jsonschema.Definition
+}
+
+// NewTyped creates a new typed output parser for the struct type T.
+func NewTyped[T any]() (Typed[T], error) {
+	var zero T
+	def, err := jsonschema.Reflect(zero)
+	if err != nil {
+		return Typed[T]{}, err
+	}
+	if def.Type != jsonschema.Object {
+		return Typed[T]{}, fmt.Errorf("%w: output must be an object, got %T", jsonschema.ErrUnsupportedType, zero)
+	}
+	return Typed[T]{Schema: def}, nil
+}
+
+// Statically assert that Typed implement the OutputParser interface.
+var _ schema.OutputParser[struct{}] = Typed[struct{}]{}
+
+func (p Typed[T]) parse(text string) (T, error) {
+	var result T
+	jsonString := extractJSONObject(text)
+	if jsonString == "" {
+		return result, ParseError{Text: text, Reason: "no JSON object in output"}
+	}
+
+	// Validating first gives errors naming the field that failed, such as
+	// "$.address.city must be of type string".
+	if err := p.Schema.Validate([]byte(jsonString)); err != nil {
+		return result, ParseError{Text: text, Reason: err.Error()}
+	}
+	if err := json.Unmarshal([]byte(jsonString), &result); err != nil {
+		return result, ParseError{Text: text, Reason: err.Error()}
+	}
+	return result, nil
+}
+
+// Parse parses the output of an LLM into a T. It returns a ParseError saying
+// which field failed if the output doesn't match the schema of T.
+func (p Typed[T]) Parse(text string) (T, error) {
+	return p.parse(text)
+}
+
+// ParseWithPrompt does the same as Parse.
+func (p Typed[T]) ParseWithPrompt(text string, _ llms.PromptValue) (T, error) {
+	return p.parse(text)
+}
+
+// GetFormatInstructions returns a string explaining how the llm should format
+// its response.
+func (p Typed[T]) GetFormatInstructions() string {
+	b, err := json.MarshalIndent(p.Schema, "", "  ")
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf(_typedFormatInstructionTemplate, string(b))
+}
+
+// Type returns the type of the output parser.
+func (p Typed[T]) Type() string {
+	return "typed_parser"
+}
+
+// extractJSONObject returns the JSON object in text, inside a ```json code
+// snippet if there is one, or "" if there is none.
+func extractJSONObject(text string) string {
+	if _, after, ok := strings.Cut(text, "```json"); ok {
+		text, _, _ = strings.Cut(after, "```")
+	}
+	start := strings.Index(text, "{")
+	end := strings.LastIndex(text, "}")
+	if start == -1 || end < start {
+		return ""
+	}
+	return text[start : end+1]
+}
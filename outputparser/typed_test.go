@@ -0,0 +1,103 @@
+package outputparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type typedAddress struct {
+	City    string `json:"city"`
+	Country string `json:"country" enum:"FR,US"`
+}
+
+type typedPet struct {
+	Name string `json:"name"`
+}
+
+type typedPerson struct {
+	Name    string       `json:"name" description:"The full name"`
+	Tags    []string     `json:"tags,omitempty"`
+	Address typedAddress `json:"address"`
+	Pets    []typedPet   `json:"pets,omitempty"`
+}
+
+func TestTyped(t *testing.T) {
+	t.Parallel()
+
+	parser, err := NewTyped[typedPerson]()
+	require.NoError(t, err)
+
+	instructions := parser.GetFormatInstructions()
+	require.Contains(t, instructions, `"description": "The full name"`)
+	require.Contains(t, instructions, `"enum": [`)
+
+	testCases := []struct {
+		name   string
+		output string
+		want   typedPerson
+		reason string
+	}{
+		{
+			name:   "code snippet",
+			output: "Sure:\n```json\n{\"name\":\"Ann\",\"tags\":[\"a\"],\"address\":{\"city\":\"Paris\",\"country\":\"FR\"}}\n```",
+			want: typedPerson{
+				Name:    "Ann",
+				Tags:    []string{"a"},
+				Address: typedAddress{City: "Paris", Country: "FR"},
+			},
+		},
+		{
+			name:   "bare object",
+			output: `{"name":"Ann","address":{"city":"Paris","country":"US"}}`,
+			want:   typedPerson{Name: "Ann", Address: typedAddress{City: "Paris", Country: "US"}},
+		},
+		{
+			name:   "missing field",
+			output: `{"name":"Ann"}`,
+			reason: `$ is missing required property "address"`,
+		},
+		{
+			name:   "nested wrong type",
+			output: `{"name":"Ann","address":{"city":1,"country":"FR"}}`,
+			reason: "$.address.city must be of type string",
+		},
+		{
+			name:   "array item",
+			output: `{"name":"Ann","address":{"city":"Paris","country":"FR"},"pets":[{"name":"Rex"},{}]}`,
+			reason: `$.pets[1] is missing required property "name"`,
+		},
+		{
+			name:   "enum",
+			output: `{"name":"Ann","address":{"city":"Paris","country":"DE"}}`,
+			reason: "$.address.country must be one of [FR US]",
+		},
+		{
+			name:   "no json",
+			output: "I don't know",
+			reason: "no JSON object in output",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parser.Parse(tc.output)
+			if tc.reason != "" {
+				var parseErr ParseError
+				require.ErrorAs(t, err, &parseErr)
+				require.Contains(t, parseErr.Reason, tc.reason)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestNewTypedNotObject(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewTyped[[]string]()
+	require.Error(t, err)
+}
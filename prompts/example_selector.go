@@ -1,8 +1,21 @@
 package prompts
 
+import "context"
+
 // ExampleSelector is an interface for example selectors. It is equivalent to
 // BaseExampleSelector in langchain and langchainjs.
 type ExampleSelector interface {
 	AddExample(example map[string]string) string
 	SelectExamples(inputVariables map[string]string) []map[string]string
 }
+
+// ContextExampleSelector is implemented by example selectors that call other
+// services, such as vector stores, and so can fail. FewShotPrompt uses it to
+// return their errors.
+type ContextExampleSelector interface {
+	ExampleSelector
+	// AddExampleContext adds an example and returns its id.
+	AddExampleContext(ctx context.Context, example map[string]string) (string, error)
+	// SelectExamplesContext selects the examples to use for the input variables.
+	SelectExamplesContext(ctx context.Context, inputVariables map[string]string) ([]map[string]string, error)
+}
@@ -0,0 +1,9 @@
+// Package exampleselector provides implementations of prompts.ExampleSelector
+// to choose the examples of a prompts.FewShotPrompt from the input:
+//
+//   - SemanticSimilarity selects the examples most similar to the input,
+//     using a vector store.
+//   - MaxMarginalRelevance selects examples similar to the input but diverse
+//     among themselves, using a vector store and an embedder.
+//   - LengthBased selects as many examples as fit in a token budget.
+package exampleselector
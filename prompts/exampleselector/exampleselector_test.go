@@ -0,0 +1,159 @@
+package exampleselector_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/prompts"
+	"github.com/tmc/langchaingo/prompts/exampleselector"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/vectorstores"
+	"github.com/tmc/langchaingo/vectorstores/inmemory"
+)
+
+// keywordEmbedder embeds a text as the count of each keyword in it.
+type keywordEmbedder struct{}
+
+var keywords = []string{"cat", "dog", "fish", "bird"}
+
+func (keywordEmbedder) EmbedDocuments(_ context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		vectors[i] = embed(text)
+	}
+	return vectors, nil
+}
+
+func (keywordEmbedder) EmbedQuery(_ context.Context, text string) ([]float32, error) {
+	return embed(text), nil
+}
+
+func embed(text string) []float32 {
+	v := make([]float32, len(keywords))
+	for i, kw := range keywords {
+		v[i] = float32(strings.Count(text, kw))
+	}
+	return v
+}
+
+var examples = []map[string]string{
+	{"input": "cat", "output": "meow"},
+	{"input": "cat cat", "output": "meow meow"},
+	{"input": "dog", "output": "woof"},
+	{"input": "bird", "output": "tweet"},
+}
+
+func newStore(t *testing.T) *inmemory.Store {
+	t.Helper()
+	store, err := inmemory.New(inmemory.WithEmbedder(keywordEmbedder{}))
+	require.NoError(t, err)
+	return store
+}
+
+func TestSemanticSimilarity(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	selector, err := exampleselector.NewSemanticSimilarityFromExamples(ctx, newStore(t), examples, 2,
+		exampleselector.WithInputKeys("input"))
+	require.NoError(t, err)
+
+	selected, err := selector.SelectExamplesContext(ctx, map[string]string{"input": "a cat"})
+	require.NoError(t, err)
+	require.Len(t, selected, 2)
+	require.ElementsMatch(t, []string{"meow", "meow meow"}, []string{selected[0]["output"], selected[1]["output"]})
+
+	id := selector.AddExample(map[string]string{"input": "fish", "output": "blub"})
+	require.NotEmpty(t, id)
+	selected = selector.SelectExamples(map[string]string{"input": "fish"})
+	require.Equal(t, "blub", selected[0]["output"])
+}
+
+func TestMaxMarginalRelevance(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	selector, err := exampleselector.NewMaxMarginalRelevanceFromExamples(ctx, newStore(t), keywordEmbedder{},
+		examples, 2, exampleselector.WithInputKeys("input"), exampleselector.WithLambda(0.5))
+	require.NoError(t, err)
+
+	// The two cat examples are the most similar, but they embed to the same
+	// direction, so a dog is selected instead of the second one.
+	selected, err := selector.SelectExamplesContext(ctx, map[string]string{"input": "cat cat dog"})
+	require.NoError(t, err)
+	require.Len(t, selected, 2)
+	require.Contains(t, []string{"meow", "meow meow"}, selected[0]["output"])
+	require.Equal(t, "woof", selected[1]["output"])
+}
+
+// countingEmbedder counts the texts it embeds.
+type countingEmbedder struct {
+	keywordEmbedder
+	mu    sync.Mutex
+	texts []string
+}
+
+func (e *countingEmbedder) EmbedDocuments(ctx context.Context, texts []string) ([][]float32, error) {
+	e.mu.Lock()
+	e.texts = append(e.texts, texts...)
+	e.mu.Unlock()
+	return e.keywordEmbedder.EmbedDocuments(ctx, texts)
+}
+
+// searchOnlyStore hides the VectorSearcher implementation of a store.
+type searchOnlyStore struct {
+	vectorstores.VectorStore
+}
+
+func TestMaxMarginalRelevanceCachesVectors(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	embedder := &countingEmbedder{}
+	store := searchOnlyStore{newStore(t)}
+	_, err := store.AddDocuments(ctx, []schema.Document{{
+		PageContent: "fish", Metadata: map[string]any{"input": "fish", "output": "blub"},
+	}})
+	require.NoError(t, err)
+
+	selector, err := exampleselector.NewMaxMarginalRelevanceFromExamples(ctx, store, embedder,
+		examples, 2, exampleselector.WithInputKeys("input"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"cat", "cat cat", "dog", "bird"}, embedder.texts)
+	selector.AddExample(map[string]string{"input": "cat dog", "output": "meow woof"})
+
+	for i := 0; i < 2; i++ {
+		selected, err := selector.SelectExamplesContext(ctx, map[string]string{"input": "cat cat dog"})
+		require.NoError(t, err)
+		require.Len(t, selected, 2)
+	}
+	// Only the example stored without the selector is embedded to select.
+	require.Equal(t, []string{"cat", "cat cat", "dog", "bird", "cat dog", "fish"}, embedder.texts)
+}
+
+func TestLengthBased(t *testing.T) {
+	t.Parallel()
+
+	examplePrompt := prompts.NewPromptTemplate("{{.input}} -> {{.output}}", []string{"input", "output"})
+	wordCount := exampleselector.WithTextLength(func(text string) int { return len(strings.Fields(text)) })
+
+	selector, err := exampleselector.NewLengthBased(examples, examplePrompt, 8, wordCount)
+	require.NoError(t, err)
+
+	// "cat -> meow" is 3 words, "cat cat -> meow meow" is 5.
+	selected := selector.SelectExamples(map[string]string{"input": "hello"})
+	require.Equal(t, examples[:1], selected)
+
+	selected = selector.SelectExamples(map[string]string{"input": ""})
+	require.Equal(t, examples[:2], selected)
+
+	fewShot, err := prompts.NewFewShotPrompt(examplePrompt, nil, selector, "", "{{.input}} ->",
+		[]string{"input"}, nil, "\n", prompts.TemplateFormatGoTemplate, false)
+	require.NoError(t, err)
+	text, err := fewShot.Format(map[string]any{"input": "dog"})
+	require.NoError(t, err)
+	require.Equal(t, "cat -> meow\ndog ->", text)
+}
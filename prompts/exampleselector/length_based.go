@@ -0,0 +1,89 @@
+package exampleselector
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/tmc/langchaingo/prompts"
+)
+
+// LengthBased is an example selector that selects examples in order until the
+// formatted examples and the input variables would exceed a maximum length.
+// Lengths are counted in tokens with llms.CountTokens, unless WithTextLength
+// is used.
+type LengthBased struct {
+	examples      []map[string]string
+	lengths       []int
+	examplePrompt prompts.PromptTemplate
+	maxLength     int
+	opts          options
+}
+
+var _ prompts.ContextExampleSelector = (*LengthBased)(nil)
+
+// NewLengthBased creates a length based example selector for examples
+// formatted with examplePrompt that fit in maxLength tokens.
+func NewLengthBased(
+	examples []map[string]string,
+	examplePrompt prompts.PromptTemplate,
+	maxLength int,
+	opts ...Option,
+) (*LengthBased, error) {
+	s := &LengthBased{
+		examplePrompt: examplePrompt,
+		maxLength:     maxLength,
+		opts:          applyOptions(opts),
+	}
+	for _, example := range examples {
+		if _, err := s.AddExampleContext(context.Background(), example); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// AddExample adds an example and returns its index, or "" if it can't be
+// formatted with the example prompt. Use AddExampleContext to get the error.
+func (s *LengthBased) AddExample(example map[string]string) string {
+	id, _ := s.AddExampleContext(context.Background(), example)
+	return id
+}
+
+// AddExampleContext adds an example and returns its index.
+func (s *LengthBased) AddExampleContext(_ context.Context, example map[string]string) (string, error) {
+	values := make(map[string]any, len(example))
+	for k, v := range example {
+		values[k] = v
+	}
+	text, err := s.examplePrompt.Format(values)
+	if err != nil {
+		return "", err
+	}
+	s.examples = append(s.examples, example)
+	s.lengths = append(s.lengths, s.opts.textLength(text))
+	return strconv.Itoa(len(s.examples) - 1), nil
+}
+
+// SelectExamples selects the examples, in the order they were added, that fit
+// in the maximum length along with the input variables.
+func (s *LengthBased) SelectExamples(inputVariables map[string]string) []map[string]string {
+	examples, _ := s.SelectExamplesContext(context.Background(), inputVariables)
+	return examples
+}
+
+// SelectExamplesContext does the same as SelectExamples. It never fails.
+func (s *LengthBased) SelectExamplesContext(
+	_ context.Context,
+	inputVariables map[string]string,
+) ([]map[string]string, error) {
+	remaining := s.maxLength - s.opts.textLength(exampleText(inputVariables, s.opts.inputKeys))
+	var examples []map[string]string
+	for i, example := range s.examples {
+		remaining -= s.lengths[i]
+		if remaining < 0 {
+			break
+		}
+		examples = append(examples, example)
+	}
+	return examples, nil
+}
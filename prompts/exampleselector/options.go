@@ -0,0 +1,98 @@
+package exampleselector
+
+import (
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/vectorstores"
+)
+
+const (
+	_defaultFetchK         = 20
+	_defaultLambda         = 0.5
+	_defaultTokenizerModel = "gpt-3.5-turbo"
+)
+
+// Option is a function that configures an example selector.
+type Option func(*options)
+
+type options struct {
+	inputKeys    []string
+	exampleKeys  []string
+	storeOptions []vectorstores.Option
+	fetchK       int
+	lambda       float32
+	textLength   func(text string) int
+}
+
+func applyOptions(opts []Option) options {
+	o := options{
+		fetchK: _defaultFetchK,
+		lambda: _defaultLambda,
+		textLength: func(text string) int {
+			return llms.CountTokens(_defaultTokenizerModel, text)
+		},
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithInputKeys sets the keys of the examples and input variables that are
+// compared to select examples. By default all keys are used.
+func WithInputKeys(keys ...string) Option {
+	return func(o *options) {
+		o.inputKeys = keys
+	}
+}
+
+// WithExampleKeys sets the keys of the selected examples returned by vector
+// store backed selectors. By default all the metadata of the stored example
+// documents is returned.
+func WithExampleKeys(keys ...string) Option {
+	return func(o *options) {
+		o.exampleKeys = keys
+	}
+}
+
+// WithVectorStoreOptions sets the options passed to the vector store when
+// adding and searching examples, such as a namespace.
+func WithVectorStoreOptions(opts ...vectorstores.Option) Option {
+	return func(o *options) {
+		o.storeOptions = opts
+	}
+}
+
+// WithFetchK sets the number of examples fetched from the vector store for
+// MaxMarginalRelevance to choose from. The default is 20.
+func WithFetchK(fetchK int) Option {
+	return func(o *options) {
+		o.fetchK = fetchK
+	}
+}
+
+// WithLambda sets the trade-off between relevance and diversity of
+// MaxMarginalRelevance, between 0 for maximum diversity and 1 for maximum
+// relevance. The default is 0.5.
+func WithLambda(lambda float32) Option {
+	return func(o *options) {
+		o.lambda = lambda
+	}
+}
+
+// WithTokenizerModel sets the model whose tokenizer LengthBased counts the
+// tokens of examples with. The default is gpt-3.5-turbo.
+func WithTokenizerModel(model string) Option {
+	return func(o *options) {
+		o.textLength = func(text string) int {
+			return llms.CountTokens(model, text)
+		}
+	}
+}
+
+// WithTextLength sets the function LengthBased measures the length of
+// examples with, instead of counting tokens.
+func WithTextLength(textLength func(text string) int) Option {
+	return func(o *options) {
+		o.textLength = textLength
+	}
+}
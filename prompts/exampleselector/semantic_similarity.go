@@ -0,0 +1,340 @@
+package exampleselector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/tmc/langchaingo/embeddings"
+	"github.com/tmc/langchaingo/prompts"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/vectorstores"
+)
+
+var errVectorCount = errors.New("embedder returned a wrong number of vectors")
+
+// SemanticSimilarity is an example selector that selects the k examples most
+// similar to the input variables. Examples are stored in a vector store as
+// documents with the input key values as content and the example as metadata.
+type SemanticSimilarity struct {
+	store vectorstores.VectorStore
+	k     int
+	opts  options
+}
+
+var _ prompts.ContextExampleSelector = (*SemanticSimilarity)(nil)
+
+// NewSemanticSimilarity creates a semantic similarity example selector
+// selecting k examples from the examples in store.
+func NewSemanticSimilarity(store vectorstores.VectorStore, k int, opts ...Option) *SemanticSimilarity {
+	return &SemanticSimilarity{
+		store: store,
+		k:     k,
+		opts:  applyOptions(opts),
+	}
+}
+
+// NewSemanticSimilarityFromExamples creates a semantic similarity example
+// selector and adds examples to store.
+func NewSemanticSimilarityFromExamples(
+	ctx context.Context,
+	store vectorstores.VectorStore,
+	examples []map[string]string,
+	k int,
+	opts ...Option,
+) (*SemanticSimilarity, error) {
+	s := NewSemanticSimilarity(store, k, opts...)
+	if err := addExamples(ctx, store, examples, s.opts); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// AddExample adds an example to the vector store and returns its id, or "" if
+// it couldn't be added. Use AddExampleContext to get the error.
+func (s *SemanticSimilarity) AddExample(example map[string]string) string {
+	id, _ := s.AddExampleContext(context.Background(), example)
+	return id
+}
+
+// AddExampleContext adds an example to the vector store and returns its id.
+func (s *SemanticSimilarity) AddExampleContext(ctx context.Context, example map[string]string) (string, error) {
+	return addExample(ctx, s.store, example, s.opts)
+}
+
+// SelectExamples selects the examples most similar to the input variables, or
+// none if the vector store search fails. Use SelectExamplesContext to get the
+// error.
+func (s *SemanticSimilarity) SelectExamples(inputVariables map[string]string) []map[string]string {
+	examples, _ := s.SelectExamplesContext(context.Background(), inputVariables)
+	return examples
+}
+
+// SelectExamplesContext selects the examples most similar to the input variables.
+func (s *SemanticSimilarity) SelectExamplesContext(
+	ctx context.Context,
+	inputVariables map[string]string,
+) ([]map[string]string, error) {
+	query := exampleText(inputVariables, s.opts.inputKeys)
+	docs, err := s.store.SimilaritySearch(ctx, query, s.k, s.opts.storeOptions...)
+	if err != nil {
+		return nil, err
+	}
+	return documentsToExamples(docs, s.opts.exampleKeys), nil
+}
+
+// MaxMarginalRelevance is an example selector that selects k examples similar
+// to the input variables but diverse among themselves. It fetches the most
+// similar examples from a vector store and reranks them with
+// embeddings.MaximalMarginalRelevance.
+//
+// The vectors of the examples are read from the store if it implements
+// vectorstores.VectorSearcher. Otherwise they are embedded once, when added
+// with the selector or first fetched, and only the input is embedded to
+// select examples.
+type MaxMarginalRelevance struct {
+	store    vectorstores.VectorStore
+	embedder embeddings.Embedder
+	k        int
+	opts     options
+
+	mu      sync.Mutex
+	vectors map[string][]float32
+}
+
+var _ prompts.ContextExampleSelector = (*MaxMarginalRelevance)(nil)
+
+// NewMaxMarginalRelevance creates a max marginal relevance example selector
+// selecting k examples from the examples in store. The embedder should be the
+// one store embeds documents with.
+func NewMaxMarginalRelevance(
+	store vectorstores.VectorStore,
+	embedder embeddings.Embedder,
+	k int,
+	opts ...Option,
+) *MaxMarginalRelevance {
+	return &MaxMarginalRelevance{
+		store:    store,
+		embedder: embedder,
+		k:        k,
+		opts:     applyOptions(opts),
+		vectors:  map[string][]float32{},
+	}
+}
+
+// NewMaxMarginalRelevanceFromExamples creates a max marginal relevance example
+// selector and adds examples to store.
+func NewMaxMarginalRelevanceFromExamples(
+	ctx context.Context,
+	store vectorstores.VectorStore,
+	embedder embeddings.Embedder,
+	examples []map[string]string,
+	k int,
+	opts ...Option,
+) (*MaxMarginalRelevance, error) {
+	s := NewMaxMarginalRelevance(store, embedder, k, opts...)
+	if err := addExamples(ctx, store, examples, s.opts); err != nil {
+		return nil, err
+	}
+	texts := make([]string, len(examples))
+	for i, example := range examples {
+		texts[i] = exampleText(example, s.opts.inputKeys)
+	}
+	if err := s.cacheVectors(ctx, texts); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// AddExample adds an example to the vector store and returns its id, or "" if
+// it couldn't be added. Use AddExampleContext to get the error.
+func (s *MaxMarginalRelevance) AddExample(example map[string]string) string {
+	id, _ := s.AddExampleContext(context.Background(), example)
+	return id
+}
+
+// AddExampleContext adds an example to the vector store and returns its id.
+func (s *MaxMarginalRelevance) AddExampleContext(ctx context.Context, example map[string]string) (string, error) {
+	id, err := addExample(ctx, s.store, example, s.opts)
+	if err != nil {
+		return "", err
+	}
+	if err := s.cacheVectors(ctx, []string{exampleText(example, s.opts.inputKeys)}); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// SelectExamples selects examples relevant to the input variables and diverse
+// among themselves, or none if the search fails. Use SelectExamplesContext to
+// get the error.
+func (s *MaxMarginalRelevance) SelectExamples(inputVariables map[string]string) []map[string]string {
+	examples, _ := s.SelectExamplesContext(context.Background(), inputVariables)
+	return examples
+}
+
+// SelectExamplesContext selects examples relevant to the input variables and
+// diverse among themselves.
+func (s *MaxMarginalRelevance) SelectExamplesContext(
+	ctx context.Context,
+	inputVariables map[string]string,
+) ([]map[string]string, error) {
+	query := exampleText(inputVariables, s.opts.inputKeys)
+	result, err := s.search(ctx, query)
+	if err != nil || len(result.Documents) == 0 {
+		return nil, err
+	}
+
+	selected := embeddings.MaximalMarginalRelevance(result.QueryVector, result.Vectors, s.k, s.opts.lambda)
+	selectedDocs := make([]schema.Document, len(selected))
+	for i, index := range selected {
+		selectedDocs[i] = result.Documents[index]
+	}
+	return documentsToExamples(selectedDocs, s.opts.exampleKeys), nil
+}
+
+// search returns the examples most similar to the query with their vectors.
+func (s *MaxMarginalRelevance) search(ctx context.Context, query string) (vectorstores.VectorSearchResult, error) {
+	fetchK := max(s.opts.fetchK, s.k)
+	if searcher, ok := s.store.(vectorstores.VectorSearcher); ok {
+		return searcher.SimilaritySearchVectors(ctx, query, fetchK, s.opts.storeOptions...)
+	}
+
+	docs, err := s.store.SimilaritySearch(ctx, query, fetchK, s.opts.storeOptions...)
+	if err != nil || len(docs) == 0 {
+		return vectorstores.VectorSearchResult{}, err
+	}
+	queryVector, err := s.embedder.EmbedQuery(ctx, query)
+	if err != nil {
+		return vectorstores.VectorSearchResult{}, err
+	}
+	texts := make([]string, len(docs))
+	for i, doc := range docs {
+		texts[i] = doc.PageContent
+	}
+	if err := s.cacheVectors(ctx, texts); err != nil {
+		return vectorstores.VectorSearchResult{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		vectors[i] = s.vectors[text]
+	}
+	return vectorstores.VectorSearchResult{QueryVector: queryVector, Documents: docs, Vectors: vectors}, nil
+}
+
+// cacheVectors embeds the example texts that aren't cached yet. Nothing is
+// cached if the store returns the vectors of the examples it finds.
+func (s *MaxMarginalRelevance) cacheVectors(ctx context.Context, texts []string) error {
+	if _, ok := s.store.(vectorstores.VectorSearcher); ok {
+		return nil
+	}
+
+	s.mu.Lock()
+	var missing []string
+	for _, text := range texts {
+		if _, ok := s.vectors[text]; !ok && !slices.Contains(missing, text) {
+			missing = append(missing, text)
+		}
+	}
+	s.mu.Unlock()
+	if len(missing) == 0 {
+		return nil
+	}
+
+	vectors, err := s.embedder.EmbedDocuments(ctx, missing)
+	if err != nil {
+		return err
+	}
+	if len(vectors) != len(missing) {
+		return fmt.Errorf("%w: %d vectors for %d examples", errVectorCount, len(vectors), len(missing))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, text := range missing {
+		s.vectors[text] = vectors[i]
+	}
+	return nil
+}
+
+func addExamples(ctx context.Context, store vectorstores.VectorStore, examples []map[string]string, opts options) error {
+	if len(examples) == 0 {
+		return nil
+	}
+	docs := make([]schema.Document, len(examples))
+	for i, example := range examples {
+		docs[i] = exampleDocument(example, opts.inputKeys)
+	}
+	_, err := store.AddDocuments(ctx, docs, opts.storeOptions...)
+	return err
+}
+
+func addExample(ctx context.Context, store vectorstores.VectorStore, example map[string]string, opts options) (string, error) { //nolint:lll
+	ids, err := store.AddDocuments(ctx, []schema.Document{exampleDocument(example, opts.inputKeys)}, opts.storeOptions...)
+	if err != nil {
+		return "", err
+	}
+	if len(ids) == 0 {
+		return "", nil
+	}
+	return ids[0], nil
+}
+
+// exampleDocument returns the document an example is stored as.
+func exampleDocument(example map[string]string, inputKeys []string) schema.Document {
+	metadata := make(map[string]any, len(example))
+	for k, v := range example {
+		metadata[k] = v
+	}
+	return schema.Document{
+		PageContent: exampleText(example, inputKeys),
+		Metadata:    metadata,
+	}
+}
+
+// exampleText joins the values of the input keys of values, or of all of its
+// keys in sorted order if inputKeys is empty.
+func exampleText(values map[string]string, inputKeys []string) string {
+	keys := inputKeys
+	if len(keys) == 0 {
+		keys = make([]string, 0, len(values))
+		for k := range values {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+	}
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		if v, ok := values[k]; ok {
+			parts = append(parts, v)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// documentsToExamples returns the examples stored as the metadata of docs,
+// keeping only exampleKeys if it isn't empty.
+func documentsToExamples(docs []schema.Document, exampleKeys []string) []map[string]string {
+	examples := make([]map[string]string, 0, len(docs))
+	for _, doc := range docs {
+		example := make(map[string]string, len(doc.Metadata))
+		for k, v := range doc.Metadata {
+			if len(exampleKeys) > 0 && !slices.Contains(exampleKeys, k) {
+				continue
+			}
+			if s, ok := v.(string); ok {
+				example[k] = s
+			} else {
+				example[k] = fmt.Sprint(v)
+			}
+		}
+		examples = append(examples, example)
+	}
+	return examples
+}
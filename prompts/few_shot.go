@@ -1,6 +1,7 @@
 package prompts
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
@@ -41,6 +42,11 @@ type FewShotPrompt struct {
 	ValidateTemplate bool
 }
 
+var (
+	_ Formatter             = &FewShotPrompt{}
+	_ ContextFormatPrompter = &FewShotPrompt{}
+)
+
 // NewFewShotPrompt creates a new few-shot prompt with the given input. It returns error if there is no example, both
 // examples and exampleSelector are provided, or CheckValidTemplate returns err when ValidateTemplate is true.
 func NewFewShotPrompt(examplePrompt PromptTemplate, examples []map[string]string, exampleSelector ExampleSelector,
@@ -88,11 +94,14 @@ func validateExamples(examples []map[string]string, exampleSelector ExampleSelec
 }
 
 // getExamples returns the provided examples or returns error when there is no example.
-func (p *FewShotPrompt) getExamples(input map[string]string) ([]map[string]string, error) {
+func (p *FewShotPrompt) getExamples(ctx context.Context, input map[string]string) ([]map[string]string, error) {
 	switch {
 	case p.Examples != nil:
 		return p.Examples, nil
 	case p.ExampleSelector != nil:
+		if selector, ok := p.ExampleSelector.(ContextExampleSelector); ok {
+			return selector.SelectExamplesContext(ctx, input)
+		}
 		return p.ExampleSelector.SelectExamples(input), nil
 	default:
 		return nil, ErrNoExample
@@ -101,6 +110,12 @@ func (p *FewShotPrompt) getExamples(input map[string]string) ([]map[string]strin
 
 // Format assembles and formats the pieces of the prompt with the given input values and partial values.
 func (p *FewShotPrompt) Format(values map[string]interface{}) (string, error) {
+	return p.FormatContext(context.Background(), values)
+}
+
+// FormatContext is like Format, but passes ctx to the example selector if it
+// is a ContextExampleSelector.
+func (p *FewShotPrompt) FormatContext(ctx context.Context, values map[string]any) (string, error) {
 	resolvedValues, err := resolvePartialValues(p.PartialVariables, values)
 	if err != nil {
 		return "", err
@@ -117,7 +132,7 @@ func (p *FewShotPrompt) Format(values map[string]interface{}) (string, error) {
 		}
 		stringResolvedValues[k] = strVal
 	}
-	examples, err := p.getExamples(stringResolvedValues)
+	examples, err := p.getExamples(ctx, stringResolvedValues)
 	if err != nil {
 		return "", err
 	}
@@ -171,7 +186,12 @@ func getMapKeys(inputMap map[string]any) []string {
 }
 
 func (p *FewShotPrompt) FormatPrompt(values map[string]any) (llms.PromptValue, error) {
-	f, err := p.Format(values)
+	return p.FormatPromptContext(context.Background(), values)
+}
+
+// FormatPromptContext implements the ContextFormatPrompter interface.
+func (p *FewShotPrompt) FormatPromptContext(ctx context.Context, values map[string]any) (llms.PromptValue, error) {
+	f, err := p.FormatContext(ctx, values)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,8 @@
 package prompts
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -141,6 +143,47 @@ func TestFewShotPrompt_Format(t *testing.T) {
 	}
 }
 
+type contextSelector struct{}
+
+func (contextSelector) AddExample(map[string]string) string { return "" }
+
+func (contextSelector) SelectExamples(map[string]string) []map[string]string { return nil }
+
+func (contextSelector) AddExampleContext(context.Context, map[string]string) (string, error) {
+	return "", nil
+}
+
+func (contextSelector) SelectExamplesContext(ctx context.Context, _ map[string]string) ([]map[string]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return []map[string]string{{"question": "1+1", "answer": "2"}}, nil
+}
+
+func TestFewShotPrompt_FormatPromptContext(t *testing.T) {
+	t.Parallel()
+	examplePrompt := NewPromptTemplate("{{.question}}: {{.answer}}", []string{"question", "answer"})
+	p, err := NewFewShotPrompt(examplePrompt, nil, contextSelector{}, "", "{{.q}}:",
+		[]string{"q"}, nil, "\n", TemplateFormatGoTemplate, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := p.FormatPromptContext(context.Background(), map[string]any{"q": "2+2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff("1+1: 2\n2+2:", got.String()); diff != "" {
+		t.Errorf("unexpected prompt output (-want +got):\n%s", diff)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := p.FormatPromptContext(ctx, map[string]any{"q": "2+2"}); !errors.Is(err, context.Canceled) {
+		t.Errorf("unexpected error: got %v, want %v", err, context.Canceled)
+	}
+}
+
 func checkError(t *testing.T, err error, expected string) bool {
 	t.Helper()
 	if err != nil {
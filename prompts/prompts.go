@@ -1,6 +1,10 @@
 package prompts
 
-import "github.com/tmc/langchaingo/llms"
+import (
+	"context"
+
+	"github.com/tmc/langchaingo/llms"
+)
 
 // Formatter is an interface for formatting a map of values into a string.
 type Formatter interface {
@@ -19,3 +23,13 @@ type FormatPrompter interface {
 	FormatPrompt(values map[string]any) (llms.PromptValue, error)
 	GetInputVariables() []string
 }
+
+// ContextFormatPrompter is implemented by prompts that may call other services
+// while formatting, like a FewShotPrompt with a ContextExampleSelector, so the
+// context of the call can be passed to them.
+type ContextFormatPrompter interface {
+	FormatPrompter
+	// FormatPromptContext is like FormatPrompt, using ctx for the calls made
+	// while formatting.
+	FormatPromptContext(ctx context.Context, values map[string]any) (llms.PromptValue, error)
+}
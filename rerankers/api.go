@@ -0,0 +1,78 @@
+package rerankers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/tmc/langchaingo/llms"
+)
+
+// APIClient sends requests to a rerank HTTP API with a bearer token. It holds
+// the settings and error handling shared by the cohere, jina and voyageai
+// rerankers, which only map their own request and response bodies.
+type APIClient struct {
+	BaseURL    string
+	Token      string
+	HTTPClient *http.Client
+}
+
+// SetDefaults sets the unset token and base URL from the environment
+// variables, which are skipped if empty, then the base URL to defaultBaseURL
+// and the HTTP client to http.DefaultClient.
+func (c *APIClient) SetDefaults(tokenEnvVar, baseURLEnvVar, defaultBaseURL string) {
+	if c.Token == "" && tokenEnvVar != "" {
+		c.Token = os.Getenv(tokenEnvVar)
+	}
+	if c.BaseURL == "" && baseURLEnvVar != "" {
+		c.BaseURL = os.Getenv(baseURLEnvVar)
+	}
+	if c.BaseURL == "" {
+		c.BaseURL = defaultBaseURL
+	}
+	if c.HTTPClient == nil {
+		c.HTTPClient = http.DefaultClient
+	}
+}
+
+// Post sends the JSON encoding of request to the path of the API and decodes
+// the JSON response into response. A response with another status than 200
+// is returned as an llms.StatusError, with the message errorMessage extracts
+// from its body, or the status if it returns "".
+func (c *APIClient) Post(ctx context.Context, path string, request, response any,
+	errorMessage func(body []byte) string,
+) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		strings.TrimSuffix(c.BaseURL, "/")+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.Token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("rerank request error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message := resp.Status
+		if body, err := io.ReadAll(resp.Body); err == nil {
+			if m := errorMessage(body); m != "" {
+				message = m
+			}
+		}
+		return llms.NewStatusError(resp, "rerank error: "+message)
+	}
+	return json.NewDecoder(resp.Body).Decode(response)
+}
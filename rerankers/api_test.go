@@ -0,0 +1,137 @@
+package rerankers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/rerankers"
+	"github.com/tmc/langchaingo/rerankers/cohere"
+	"github.com/tmc/langchaingo/rerankers/jina"
+	"github.com/tmc/langchaingo/rerankers/voyageai"
+	"github.com/tmc/langchaingo/schema"
+)
+
+// apiProvider describes how a rerank API differs from the others.
+type apiProvider struct {
+	name    string
+	new     func(baseURL string) (rerankers.Reranker, error)
+	path    string
+	topNKey string
+	results string
+}
+
+var apiProviders = []apiProvider{
+	{
+		name: "cohere",
+		new: func(baseURL string) (rerankers.Reranker, error) {
+			return cohere.New(cohere.WithToken("token"), cohere.WithBaseURL(baseURL), cohere.WithTopN(2))
+		},
+		path:    "/v1/rerank",
+		topNKey: "top_n",
+		results: "results",
+	},
+	{
+		name: "jina",
+		new: func(baseURL string) (rerankers.Reranker, error) {
+			return jina.New(jina.WithToken("token"), jina.WithBaseURL(baseURL), jina.WithTopN(2))
+		},
+		path:    "/rerank",
+		topNKey: "top_n",
+		results: "results",
+	},
+	{
+		name: "voyageai",
+		new: func(baseURL string) (rerankers.Reranker, error) {
+			return voyageai.New(voyageai.WithToken("token"), voyageai.WithBaseURL(baseURL), voyageai.WithTopN(2))
+		},
+		path:    "/rerank",
+		topNKey: "top_k",
+		results: "data",
+	},
+}
+
+func TestAPIRerank(t *testing.T) {
+	t.Parallel()
+
+	for _, p := range apiProviders {
+		t.Run(p.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, p.path, r.URL.Path)
+				require.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+				var req map[string]any
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+				require.Equal(t, "XJ-200", req["query"])
+				require.Equal(t, []any{"pumps", "XJ-200 pump"}, req["documents"])
+				require.InDelta(t, 2, req[p.topNKey], 0)
+				_, _ = w.Write([]byte(`{"` + p.results +
+					`": [{"index": 1, "relevance_score": 0.9}, {"index": 0, "relevance_score": 0.2}]}`))
+			}))
+			defer server.Close()
+
+			reranker, err := p.new(server.URL + "/")
+			require.NoError(t, err)
+			docs, err := reranker.Rerank(context.Background(), "XJ-200", []schema.Document{
+				{PageContent: "pumps"},
+				{PageContent: "XJ-200 pump"},
+			})
+			require.NoError(t, err)
+			require.Equal(t, []schema.Document{
+				{PageContent: "XJ-200 pump", Score: 0.9},
+				{PageContent: "pumps", Score: 0.2},
+			}, docs)
+		})
+	}
+}
+
+func TestAPIRerankErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		provider string
+		status   int
+		body     string
+		wantErr  string
+	}{
+		{"cohere", http.StatusBadRequest, `{"message": "invalid model"}`, "rerank error: invalid model"},
+		{"jina", http.StatusUnauthorized, `{"detail": "invalid token"}`, "rerank error: invalid token"},
+		{
+			"jina", http.StatusUnprocessableEntity,
+			`{"detail": [{"loc": ["body", "top_n"], "msg": "must be positive"}, {"loc": ["body"], "msg": "bad"}]}`,
+			"rerank error: [body top_n]: must be positive; [body]: bad",
+		},
+		{"voyageai", http.StatusTooManyRequests, `{"detail": "rate limited"}`, "rerank error: rate limited"},
+		{"voyageai", http.StatusBadGateway, `<html>`, "rerank error: 502 Bad Gateway"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.provider+"/"+http.StatusText(tt.status), func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			var reranker rerankers.Reranker
+			for _, p := range apiProviders {
+				if p.name == tt.provider {
+					var err error
+					reranker, err = p.new(server.URL)
+					require.NoError(t, err)
+				}
+			}
+			_, err := reranker.Rerank(context.Background(), "q", []schema.Document{{PageContent: "a"}})
+			require.EqualError(t, err, tt.wantErr)
+			var statusErr *llms.StatusError
+			require.ErrorAs(t, err, &statusErr)
+			require.Equal(t, tt.status, statusErr.StatusCode)
+		})
+	}
+}
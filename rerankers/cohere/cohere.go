@@ -0,0 +1,68 @@
+package cohere
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/tmc/langchaingo/rerankers"
+	"github.com/tmc/langchaingo/schema"
+)
+
+// Reranker is a reranker using the Cohere rerank API.
+type Reranker struct {
+	api   rerankers.APIClient
+	Model string
+	TopN  int
+}
+
+var _ rerankers.Reranker = &Reranker{}
+
+// New returns a new reranker using the Cohere rerank API.
+func New(opts ...Option) (*Reranker, error) {
+	return applyOptions(opts...)
+}
+
+type rerankRequest struct {
+	Model           string   `json:"model"`
+	Query           string   `json:"query"`
+	Documents       []string `json:"documents"`
+	TopN            int      `json:"top_n,omitempty"`
+	ReturnDocuments bool     `json:"return_documents"`
+}
+
+type rerankResponse struct {
+	Results []struct {
+		Index          int     `json:"index"`
+		RelevanceScore float32 `json:"relevance_score"`
+	} `json:"results"`
+}
+
+// Rerank implements the rerankers.Reranker interface.
+func (r *Reranker) Rerank(ctx context.Context, query string, docs []schema.Document) ([]schema.Document, error) {
+	var resp rerankResponse
+	err := r.api.Post(ctx, "/v1/rerank", rerankRequest{
+		Model:     r.Model,
+		Query:     query,
+		Documents: rerankers.Contents(docs),
+		TopN:      r.TopN,
+	}, &resp, errorMessage)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]rerankers.Result, len(resp.Results))
+	for i, result := range resp.Results {
+		results[i] = rerankers.Result{Index: result.Index, Score: result.RelevanceScore}
+	}
+	return rerankers.ApplyResults(docs, results)
+}
+
+// errorMessage returns the message of a Cohere error response.
+func errorMessage(body []byte) string {
+	var resp struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return ""
+	}
+	return resp.Message
+}
@@ -0,0 +1,71 @@
+package cohere
+
+import (
+	"errors"
+	"net/http"
+)
+
+const (
+	tokenEnvVarName   = "COHERE_API_KEY"  //nolint:gosec
+	baseURLEnvVarName = "COHERE_BASE_URL" //nolint:gosec
+
+	_defaultBaseURL = "https://api.cohere.com"
+	_defaultModel   = "rerank-english-v3.0"
+)
+
+// ErrMissingToken is returned by New if no API key is given.
+var ErrMissingToken = errors.New("missing the Cohere API key, set it in the COHERE_API_KEY environment variable")
+
+// Option is a function type that can be used to modify the reranker.
+type Option func(r *Reranker)
+
+// WithToken is an option for providing the Cohere API key. If not set, it is
+// read from the COHERE_API_KEY environment variable.
+func WithToken(token string) Option {
+	return func(r *Reranker) {
+		r.api.Token = token
+	}
+}
+
+// WithModel is an option for providing the rerank model to use. The default
+// is "rerank-english-v3.0".
+func WithModel(model string) Option {
+	return func(r *Reranker) {
+		r.Model = model
+	}
+}
+
+// WithBaseURL is an option for providing the API base URL. If not set, it is
+// read from the COHERE_BASE_URL environment variable, then defaults to
+// https://api.cohere.com.
+func WithBaseURL(baseURL string) Option {
+	return func(r *Reranker) {
+		r.api.BaseURL = baseURL
+	}
+}
+
+// WithHTTPClient is an option for providing a custom http client.
+func WithHTTPClient(client *http.Client) Option {
+	return func(r *Reranker) {
+		r.api.HTTPClient = client
+	}
+}
+
+// WithTopN is an option for returning only the n most relevant documents.
+func WithTopN(n int) Option {
+	return func(r *Reranker) {
+		r.TopN = n
+	}
+}
+
+func applyOptions(opts ...Option) (*Reranker, error) {
+	r := &Reranker{Model: _defaultModel}
+	for _, opt := range opts {
+		opt(r)
+	}
+	r.api.SetDefaults(tokenEnvVarName, baseURLEnvVarName, _defaultBaseURL)
+	if r.api.Token == "" {
+		return nil, ErrMissingToken
+	}
+	return r, nil
+}
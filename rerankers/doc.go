@@ -0,0 +1,17 @@
+/*
+Package rerankers contains the Reranker interface, for ordering documents by
+their relevance to a query, and a retriever applying a reranker to the
+documents of another retriever.
+
+Rerankers are typically used to narrow the top results of a fast but coarse
+retriever, such as a vectorstores.Retriever returning 50 documents, down to
+the few most relevant ones.
+
+The implementations live in sub packages:
+
+  - cohere, jina and voyageai use the rerank endpoints of these providers,
+    sending their requests with an APIClient.
+  - llmreranker uses any llms.Model, scoring documents one by one or ranking
+    them all in one prompt.
+*/
+package rerankers
@@ -0,0 +1,85 @@
+package jina
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/tmc/langchaingo/rerankers"
+	"github.com/tmc/langchaingo/schema"
+)
+
+// Reranker is a reranker using the Jina rerank API.
+type Reranker struct {
+	api   rerankers.APIClient
+	Model string
+	TopN  int
+}
+
+var _ rerankers.Reranker = &Reranker{}
+
+// New returns a new reranker using the Jina rerank API.
+func New(opts ...Option) (*Reranker, error) {
+	return applyOptions(opts...)
+}
+
+type rerankRequest struct {
+	Model     string   `json:"model"`
+	Query     string   `json:"query"`
+	Documents []string `json:"documents"`
+	TopN      int      `json:"top_n,omitempty"`
+}
+
+type rerankResponse struct {
+	Results []struct {
+		Index          int     `json:"index"`
+		RelevanceScore float32 `json:"relevance_score"`
+	} `json:"results"`
+}
+
+// Rerank implements the rerankers.Reranker interface.
+func (r *Reranker) Rerank(ctx context.Context, query string, docs []schema.Document) ([]schema.Document, error) {
+	var resp rerankResponse
+	err := r.api.Post(ctx, "/rerank", rerankRequest{
+		Model:     r.Model,
+		Query:     query,
+		Documents: rerankers.Contents(docs),
+		TopN:      r.TopN,
+	}, &resp, errorMessage)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]rerankers.Result, len(resp.Results))
+	for i, result := range resp.Results {
+		results[i] = rerankers.Result{Index: result.Index, Score: result.RelevanceScore}
+	}
+	return rerankers.ApplyResults(docs, results)
+}
+
+// errorMessage returns the detail of a Jina error response, which is either
+// a message or a list of request validation errors.
+func errorMessage(body []byte) string {
+	var resp struct {
+		Detail json.RawMessage `json:"detail"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil || resp.Detail == nil {
+		return ""
+	}
+	var message string
+	if err := json.Unmarshal(resp.Detail, &message); err == nil {
+		return message
+	}
+	var validationErrors []struct {
+		Loc []any  `json:"loc"`
+		Msg string `json:"msg"`
+	}
+	if err := json.Unmarshal(resp.Detail, &validationErrors); err != nil {
+		return string(resp.Detail)
+	}
+	messages := make([]string, len(validationErrors))
+	for i, e := range validationErrors {
+		messages[i] = fmt.Sprintf("%v: %s", e.Loc, e.Msg)
+	}
+	return strings.Join(messages, "; ")
+}
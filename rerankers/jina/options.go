@@ -0,0 +1,69 @@
+package jina
+
+import (
+	"errors"
+	"net/http"
+)
+
+const (
+	tokenEnvVarName = "JINA_API_KEY" //nolint:gosec
+
+	_defaultBaseURL = "https://api.jina.ai/v1"
+	_defaultModel   = "jina-reranker-v2-base-multilingual"
+)
+
+// ErrMissingToken is returned by New if no API key is given.
+var ErrMissingToken = errors.New("missing the Jina API key, set it in the JINA_API_KEY environment variable")
+
+// Option is a function type that can be used to modify the reranker.
+type Option func(r *Reranker)
+
+// WithToken is an option for providing the Jina API key. If not set, it is
+// read from the JINA_API_KEY environment variable.
+func WithToken(token string) Option {
+	return func(r *Reranker) {
+		r.api.Token = token
+	}
+}
+
+// WithModel is an option for providing the rerank model to use. The default
+// is "jina-reranker-v2-base-multilingual".
+func WithModel(model string) Option {
+	return func(r *Reranker) {
+		r.Model = model
+	}
+}
+
+// WithBaseURL is an option for providing the API base URL. The default is
+// https://api.jina.ai/v1.
+func WithBaseURL(baseURL string) Option {
+	return func(r *Reranker) {
+		r.api.BaseURL = baseURL
+	}
+}
+
+// WithHTTPClient is an option for providing a custom http client.
+func WithHTTPClient(client *http.Client) Option {
+	return func(r *Reranker) {
+		r.api.HTTPClient = client
+	}
+}
+
+// WithTopN is an option for returning only the n most relevant documents.
+func WithTopN(n int) Option {
+	return func(r *Reranker) {
+		r.TopN = n
+	}
+}
+
+func applyOptions(opts ...Option) (*Reranker, error) {
+	r := &Reranker{Model: _defaultModel}
+	for _, opt := range opts {
+		opt(r)
+	}
+	r.api.SetDefaults(tokenEnvVarName, "", _defaultBaseURL)
+	if r.api.Token == "" {
+		return nil, ErrMissingToken
+	}
+	return r, nil
+}
@@ -0,0 +1,5 @@
+// Package llmreranker contains a reranker asking a language model how
+// relevant documents are to a query. In ModePointwise each document is scored
+// in its own prompt, concurrently. In ModeListwise all the documents are
+// listed in a single prompt and the model answers with their order.
+package llmreranker
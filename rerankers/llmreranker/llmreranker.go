@@ -0,0 +1,184 @@
+package llmreranker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/rerankers"
+	"github.com/tmc/langchaingo/schema"
+)
+
+// ErrInvalidResponse is returned when the model response has no score.
+var ErrInvalidResponse = errors.New("invalid model response")
+
+const _pointwiseTemplate = `Rate how relevant the document is to the query, from 0 (unrelated) to 10 (fully answers the query).
+
+Query: %s
+
+Document:
+%s
+
+Answer with the number only.`
+
+const _listwiseTemplate = `Below are %d documents, each with an identifier in brackets. Rank them by relevance to the query, from the most to the least relevant.
+
+Query: %s
+
+%s
+Answer with the identifiers only, most relevant first, for example: [2] > [1] > [3].`
+
+var (
+	_numberRegexp  = regexp.MustCompile(`\d+(\.\d+)?`)
+	_integerRegexp = regexp.MustCompile(`\d+`)
+)
+
+// Reranker is a reranker using a language model.
+type Reranker struct {
+	llm               llms.Model
+	mode              Mode
+	maxConcurrency    int
+	maxDocumentLength int
+	topN              int
+	callOptions       []llms.CallOption
+}
+
+var _ rerankers.Reranker = &Reranker{}
+
+// New returns a new reranker using llm.
+func New(llm llms.Model, opts ...Option) *Reranker {
+	r := &Reranker{
+		llm:               llm,
+		mode:              ModePointwise,
+		maxConcurrency:    DefaultMaxConcurrency,
+		maxDocumentLength: DefaultMaxDocumentLength,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Rerank implements the rerankers.Reranker interface. Scores are between 0
+// and 1.
+func (r *Reranker) Rerank(ctx context.Context, query string, docs []schema.Document) ([]schema.Document, error) {
+	var results []rerankers.Result
+	var err error
+	if r.mode == ModeListwise {
+		results, err = r.rankList(ctx, query, docs)
+	} else {
+		results, err = r.scoreEach(ctx, query, docs)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	reranked, err := rerankers.ApplyResults(docs, results)
+	if err != nil {
+		return nil, err
+	}
+	if r.topN > 0 && len(reranked) > r.topN {
+		reranked = reranked[:r.topN]
+	}
+	return reranked, nil
+}
+
+func (r *Reranker) scoreEach(ctx context.Context, query string, docs []schema.Document) ([]rerankers.Result, error) {
+	results := make([]rerankers.Result, len(docs))
+	errs := make([]error, len(docs))
+	sem := make(chan struct{}, max(r.maxConcurrency, 1))
+	var wg sync.WaitGroup
+	for i, doc := range docs {
+		wg.Add(1)
+		go func(i int, doc schema.Document) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			prompt := fmt.Sprintf(_pointwiseTemplate, query, r.truncate(doc.PageContent))
+			completion, err := llms.GenerateFromSinglePrompt(ctx, r.llm, prompt, r.callOptions...)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			score, err := parseScore(completion)
+			results[i] = rerankers.Result{Index: i, Score: score}
+			errs[i] = err
+		}(i, doc)
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (r *Reranker) rankList(ctx context.Context, query string, docs []schema.Document) ([]rerankers.Result, error) {
+	var list strings.Builder
+	for i, doc := range docs {
+		fmt.Fprintf(&list, "[%d] %s\n\n", i+1, r.truncate(doc.PageContent))
+	}
+	prompt := fmt.Sprintf(_listwiseTemplate, len(docs), query, list.String())
+	completion, err := llms.GenerateFromSinglePrompt(ctx, r.llm, prompt, r.callOptions...)
+	if err != nil {
+		return nil, err
+	}
+	return parseRanking(completion, len(docs)), nil
+}
+
+func (r *Reranker) truncate(text string) string {
+	if r.maxDocumentLength <= 0 {
+		return text
+	}
+	runes := []rune(text)
+	if len(runes) <= r.maxDocumentLength {
+		return text
+	}
+	return string(runes[:r.maxDocumentLength])
+}
+
+// parseScore returns the first number of a pointwise completion, scaled from
+// 0-10 to 0-1.
+func parseScore(completion string) (float32, error) {
+	match := _numberRegexp.FindString(completion)
+	if match == "" {
+		return 0, fmt.Errorf("%w: no score in %q", ErrInvalidResponse, completion)
+	}
+	score, err := strconv.ParseFloat(match, 32)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrInvalidResponse, err)
+	}
+	return float32(min(max(score, 0), 10) / 10), nil //nolint:gomnd
+}
+
+// parseRanking returns the results of a listwise completion. Identifiers
+// that are out of range or repeated are ignored, and documents the model
+// left out are ranked last in their original order.
+func parseRanking(completion string, n int) []rerankers.Result {
+	order := make([]int, 0, n)
+	seen := make([]bool, n)
+	for _, match := range _integerRegexp.FindAllString(completion, -1) {
+		id, err := strconv.Atoi(match)
+		if err != nil || id < 1 || id > n || seen[id-1] {
+			continue
+		}
+		seen[id-1] = true
+		order = append(order, id-1)
+	}
+	for i := range seen {
+		if !seen[i] {
+			order = append(order, i)
+		}
+	}
+
+	results := make([]rerankers.Result, n)
+	for rank, index := range order {
+		results[rank] = rerankers.Result{Index: index, Score: float32(n-rank) / float32(n)}
+	}
+	return results
+}
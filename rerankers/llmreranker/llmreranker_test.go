@@ -0,0 +1,86 @@
+package llmreranker_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/rerankers"
+	"github.com/tmc/langchaingo/rerankers/llmreranker"
+	"github.com/tmc/langchaingo/schema"
+)
+
+// promptModel answers with respond(prompt).
+type promptModel struct {
+	respond func(prompt string) string
+
+	mu    sync.Mutex
+	calls int
+}
+
+func (m *promptModel) GenerateContent(_ context.Context, messages []llms.MessageContent, _ ...llms.CallOption) (*llms.ContentResponse, error) { //nolint:lll
+	m.mu.Lock()
+	m.calls++
+	m.mu.Unlock()
+	prompt := messages[0].Parts[0].(llms.TextContent).Text
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: m.respond(prompt)}}}, nil
+}
+
+func (m *promptModel) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+var docs = []schema.Document{
+	{PageContent: "pumps move water"},
+	{PageContent: "the XJ-200 pump replaces the XJ-100"},
+	{PageContent: "filters need cleaning"},
+}
+
+func TestPointwise(t *testing.T) {
+	t.Parallel()
+
+	model := &promptModel{respond: func(prompt string) string {
+		switch {
+		case strings.Contains(prompt, "XJ-200 pump"):
+			return "9"
+		case strings.Contains(prompt, "pumps"):
+			return "Score: 6.5"
+		default:
+			return "0"
+		}
+	}}
+	reranked, err := llmreranker.New(model, llmreranker.WithTopN(2)).Rerank(context.Background(), "XJ-200", docs)
+	require.NoError(t, err)
+	require.Equal(t, 3, model.calls)
+	require.Equal(t, []string{docs[1].PageContent, docs[0].PageContent}, rerankers.Contents(reranked))
+	require.InDelta(t, 0.9, reranked[0].Score, 1e-6)
+	require.InDelta(t, 0.65, reranked[1].Score, 1e-6)
+
+	model = &promptModel{respond: func(string) string { return "very relevant" }}
+	_, err = llmreranker.New(model).Rerank(context.Background(), "XJ-200", docs)
+	require.ErrorIs(t, err, llmreranker.ErrInvalidResponse)
+}
+
+func TestListwise(t *testing.T) {
+	t.Parallel()
+
+	var prompt string
+	model := &promptModel{respond: func(p string) string {
+		prompt = p
+		return "[2] > [9] > [2] > [3]"
+	}}
+	reranked, err := llmreranker.New(model, llmreranker.WithMode(llmreranker.ModeListwise),
+		llmreranker.WithMaxDocumentLength(10)).Rerank(context.Background(), "XJ-200", docs)
+	require.NoError(t, err)
+	require.Equal(t, 1, model.calls)
+	require.Contains(t, prompt, "[2] the XJ-200\n")
+	// Invalid and repeated identifiers are ignored and the omitted first
+	// document comes last.
+	require.Equal(t, []string{docs[1].PageContent, docs[2].PageContent, docs[0].PageContent},
+		rerankers.Contents(reranked))
+	require.InDelta(t, 1, reranked[0].Score, 1e-6)
+	require.InDelta(t, 1.0/3, reranked[2].Score, 1e-6)
+}
@@ -0,0 +1,63 @@
+package llmreranker
+
+import "github.com/tmc/langchaingo/llms"
+
+const (
+	// DefaultMaxConcurrency is the number of documents scored at the same
+	// time in ModePointwise when none is given.
+	DefaultMaxConcurrency = 4
+	// DefaultMaxDocumentLength is the number of characters of a document
+	// included in a prompt when none is given.
+	DefaultMaxDocumentLength = 2000
+)
+
+// Mode is the way documents are presented to the model.
+type Mode string
+
+const (
+	// ModePointwise asks the model to score each document from 0 to 10.
+	ModePointwise Mode = "pointwise"
+	// ModeListwise asks the model to order all the documents at once.
+	ModeListwise Mode = "listwise"
+)
+
+// Option is a function type that can be used to modify the reranker.
+type Option func(r *Reranker)
+
+// WithMode is an option for setting how documents are ranked. The default is
+// ModePointwise.
+func WithMode(mode Mode) Option {
+	return func(r *Reranker) {
+		r.mode = mode
+	}
+}
+
+// WithMaxConcurrency is an option for setting how many documents are scored
+// at the same time in ModePointwise.
+func WithMaxConcurrency(n int) Option {
+	return func(r *Reranker) {
+		r.maxConcurrency = n
+	}
+}
+
+// WithMaxDocumentLength is an option for setting how many characters of each
+// document are included in prompts. Zero or less includes whole documents.
+func WithMaxDocumentLength(n int) Option {
+	return func(r *Reranker) {
+		r.maxDocumentLength = n
+	}
+}
+
+// WithTopN is an option for returning only the n most relevant documents.
+func WithTopN(n int) Option {
+	return func(r *Reranker) {
+		r.topN = n
+	}
+}
+
+// WithCallOptions is an option for setting the options of the model calls.
+func WithCallOptions(options ...llms.CallOption) Option {
+	return func(r *Reranker) {
+		r.callOptions = options
+	}
+}
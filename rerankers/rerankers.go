@@ -0,0 +1,58 @@
+package rerankers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/tmc/langchaingo/schema"
+)
+
+// ErrInvalidResult is returned when a reranker returns a result for a
+// document that wasn't given.
+var ErrInvalidResult = errors.New("invalid rerank result")
+
+// Reranker is the interface for ordering documents by relevance to a query.
+type Reranker interface {
+	// Rerank returns the documents ordered from the most to the least relevant
+	// to the query, with their score set to the relevance score. A reranker
+	// may return fewer documents than given.
+	Rerank(ctx context.Context, query string, docs []schema.Document) ([]schema.Document, error)
+}
+
+// Result is the relevance score a reranker gave to the document at Index.
+type Result struct {
+	Index int
+	Score float32
+}
+
+// ApplyResults returns the documents of results ordered by decreasing score,
+// with their score set. It returns ErrInvalidResult if a result index is out
+// of the range of docs.
+func ApplyResults(docs []schema.Document, results []Result) ([]schema.Document, error) {
+	results = append([]Result(nil), results...)
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+
+	reranked := make([]schema.Document, len(results))
+	for i, result := range results {
+		if result.Index < 0 || result.Index >= len(docs) {
+			return nil, fmt.Errorf("%w: index %d for %d documents", ErrInvalidResult, result.Index, len(docs))
+		}
+		reranked[i] = docs[result.Index]
+		reranked[i].Score = result.Score
+	}
+	return reranked, nil
+}
+
+// Contents returns the page content of each document, as sent to rerank
+// endpoints.
+func Contents(docs []schema.Document) []string {
+	contents := make([]string, len(docs))
+	for i, doc := range docs {
+		contents[i] = doc.PageContent
+	}
+	return contents
+}
@@ -0,0 +1,60 @@
+package rerankers_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/rerankers"
+	"github.com/tmc/langchaingo/schema"
+)
+
+// lengthReranker ranks longer documents first.
+type lengthReranker struct{}
+
+func (lengthReranker) Rerank(_ context.Context, _ string, docs []schema.Document) ([]schema.Document, error) {
+	results := make([]rerankers.Result, len(docs))
+	for i, doc := range docs {
+		results[i] = rerankers.Result{Index: i, Score: float32(len(doc.PageContent))}
+	}
+	return rerankers.ApplyResults(docs, results)
+}
+
+type staticRetriever []schema.Document
+
+func (r staticRetriever) GetRelevantDocuments(context.Context, string) ([]schema.Document, error) {
+	return r, nil
+}
+
+func TestApplyResults(t *testing.T) {
+	t.Parallel()
+
+	docs := []schema.Document{{PageContent: "a"}, {PageContent: "b"}, {PageContent: "c"}}
+	reranked, err := rerankers.ApplyResults(docs, []rerankers.Result{
+		{Index: 2, Score: 0.1},
+		{Index: 0, Score: 0.9},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []schema.Document{{PageContent: "a", Score: 0.9}, {PageContent: "c", Score: 0.1}}, reranked)
+
+	_, err = rerankers.ApplyResults(docs, []rerankers.Result{{Index: 3}})
+	require.ErrorIs(t, err, rerankers.ErrInvalidResult)
+}
+
+func TestRetriever(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	retriever := staticRetriever{{PageContent: "a"}, {PageContent: "ccc"}, {PageContent: "bb"}}
+
+	docs, err := rerankers.ToRetriever(retriever, lengthReranker{}, 2).GetRelevantDocuments(ctx, "q")
+	require.NoError(t, err)
+	require.Equal(t, []string{"ccc", "bb"}, rerankers.Contents(docs))
+
+	docs, err = rerankers.ToRetriever(retriever, lengthReranker{}, 0).GetRelevantDocuments(ctx, "q")
+	require.NoError(t, err)
+	require.Len(t, docs, 3)
+
+	docs, err = rerankers.ToRetriever(staticRetriever{}, lengthReranker{}, 2).GetRelevantDocuments(ctx, "q")
+	require.NoError(t, err)
+	require.Empty(t, docs)
+}
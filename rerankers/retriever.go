@@ -0,0 +1,57 @@
+package rerankers
+
+import (
+	"context"
+
+	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/schema"
+)
+
+// Retriever is a retriever reranking the documents of another retriever.
+type Retriever struct {
+	CallbacksHandler callbacks.Handler
+	retriever        schema.Retriever
+	reranker         Reranker
+	topN             int
+}
+
+var _ schema.Retriever = Retriever{}
+
+// GetRelevantDocuments returns the top documents of the wrapped retriever
+// once reranked.
+func (r Retriever) GetRelevantDocuments(ctx context.Context, query string) ([]schema.Document, error) {
+	if r.CallbacksHandler != nil {
+		r.CallbacksHandler.HandleRetrieverStart(ctx, query)
+	}
+
+	docs, err := r.retriever.GetRelevantDocuments(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if len(docs) > 0 {
+		docs, err = r.reranker.Rerank(ctx, query, docs)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if r.topN > 0 && len(docs) > r.topN {
+		docs = docs[:r.topN]
+	}
+
+	if r.CallbacksHandler != nil {
+		r.CallbacksHandler.HandleRetrieverEnd(ctx, query, docs)
+	}
+
+	return docs, nil
+}
+
+// ToRetriever takes a retriever and a reranker and returns a retriever
+// returning the topN documents of the retriever once reranked, or all of them
+// if topN is not positive.
+func ToRetriever(retriever schema.Retriever, reranker Reranker, topN int) Retriever {
+	return Retriever{
+		retriever: retriever,
+		reranker:  reranker,
+		topN:      topN,
+	}
+}
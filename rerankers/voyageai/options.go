@@ -0,0 +1,69 @@
+package voyageai
+
+import (
+	"errors"
+	"net/http"
+)
+
+const (
+	tokenEnvVarName = "VOYAGEAI_API_KEY" //nolint:gosec
+
+	_defaultBaseURL = "https://api.voyageai.com/v1"
+	_defaultModel   = "rerank-2"
+)
+
+// ErrMissingToken is returned by New if no API key is given.
+var ErrMissingToken = errors.New("missing the VoyageAI API key, set it in the VOYAGEAI_API_KEY environment variable")
+
+// Option is a function type that can be used to modify the reranker.
+type Option func(r *Reranker)
+
+// WithToken is an option for providing the VoyageAI API key. If not set, it is
+// read from the VOYAGEAI_API_KEY environment variable.
+func WithToken(token string) Option {
+	return func(r *Reranker) {
+		r.api.Token = token
+	}
+}
+
+// WithModel is an option for providing the rerank model to use. The default
+// is "rerank-2".
+func WithModel(model string) Option {
+	return func(r *Reranker) {
+		r.Model = model
+	}
+}
+
+// WithBaseURL is an option for providing the API base URL. The default is
+// https://api.voyageai.com/v1.
+func WithBaseURL(baseURL string) Option {
+	return func(r *Reranker) {
+		r.api.BaseURL = baseURL
+	}
+}
+
+// WithHTTPClient is an option for providing a custom http client.
+func WithHTTPClient(client *http.Client) Option {
+	return func(r *Reranker) {
+		r.api.HTTPClient = client
+	}
+}
+
+// WithTopN is an option for returning only the n most relevant documents.
+func WithTopN(n int) Option {
+	return func(r *Reranker) {
+		r.TopN = n
+	}
+}
+
+func applyOptions(opts ...Option) (*Reranker, error) {
+	r := &Reranker{Model: _defaultModel}
+	for _, opt := range opts {
+		opt(r)
+	}
+	r.api.SetDefaults(tokenEnvVarName, "", _defaultBaseURL)
+	if r.api.Token == "" {
+		return nil, ErrMissingToken
+	}
+	return r, nil
+}
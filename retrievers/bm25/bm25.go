@@ -0,0 +1,137 @@
+package bm25
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/schema"
+)
+
+// Retriever is a keyword retriever ranking documents with Okapi BM25. It is
+// safe for concurrent use.
+type Retriever struct {
+	CallbacksHandler callbacks.Handler
+
+	numDocuments int
+	k1           float64
+	b            float64
+	tokenize     func(text string) []string
+
+	mu          sync.RWMutex
+	docs        []schema.Document
+	termFreqs   []map[string]int
+	lengths     []int
+	totalLength int
+	docFreqs    map[string]int
+}
+
+var _ schema.Retriever = (*Retriever)(nil)
+
+// New creates a BM25 retriever indexing docs.
+func New(docs []schema.Document, opts ...Option) *Retriever {
+	r := &Retriever{
+		numDocuments: DefaultNumDocuments,
+		k1:           DefaultK1,
+		b:            DefaultB,
+		tokenize:     Tokenize,
+		docFreqs:     make(map[string]int),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	r.AddDocuments(docs)
+	return r
+}
+
+// AddDocuments adds documents to the index.
+func (r *Retriever) AddDocuments(docs []schema.Document) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, doc := range docs {
+		terms := r.tokenize(doc.PageContent)
+		freqs := make(map[string]int, len(terms))
+		for _, term := range terms {
+			freqs[term]++
+		}
+		for term := range freqs {
+			r.docFreqs[term]++
+		}
+		r.docs = append(r.docs, doc)
+		r.termFreqs = append(r.termFreqs, freqs)
+		r.lengths = append(r.lengths, len(terms))
+		r.totalLength += len(terms)
+	}
+}
+
+// Len returns the number of documents in the index.
+func (r *Retriever) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.docs)
+}
+
+// GetRelevantDocuments returns the documents with the best BM25 score for the
+// query, best first, with the score set. Documents sharing no term with the
+// query are never returned.
+func (r *Retriever) GetRelevantDocuments(ctx context.Context, query string) ([]schema.Document, error) {
+	if r.CallbacksHandler != nil {
+		r.CallbacksHandler.HandleRetrieverStart(ctx, query)
+	}
+
+	docs := r.search(query)
+
+	if r.CallbacksHandler != nil {
+		r.CallbacksHandler.HandleRetrieverEnd(ctx, query, docs)
+	}
+	return docs, nil
+}
+
+func (r *Retriever) search(query string) []schema.Document {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if len(r.docs) == 0 {
+		return nil
+	}
+	avgLength := float64(r.totalLength) / float64(len(r.docs))
+	scores := make([]float64, len(r.docs))
+	for _, term := range r.tokenize(query) {
+		docFreq := r.docFreqs[term]
+		if docFreq == 0 {
+			continue
+		}
+		idf := math.Log(1 + (float64(len(r.docs)-docFreq)+0.5)/(float64(docFreq)+0.5))
+		for i, freqs := range r.termFreqs {
+			tf := float64(freqs[term])
+			if tf == 0 {
+				continue
+			}
+			norm := 1 - r.b + r.b*float64(r.lengths[i])/avgLength
+			scores[i] += idf * tf * (r.k1 + 1) / (tf + r.k1*norm)
+		}
+	}
+
+	indexes := make([]int, 0, len(r.docs))
+	for i, score := range scores {
+		if score > 0 {
+			indexes = append(indexes, i)
+		}
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return scores[indexes[i]] > scores[indexes[j]]
+	})
+	if r.numDocuments > 0 && len(indexes) > r.numDocuments {
+		indexes = indexes[:r.numDocuments]
+	}
+
+	docs := make([]schema.Document, len(indexes))
+	for i, index := range indexes {
+		docs[i] = r.docs[index]
+		docs[i].Score = float32(scores[index])
+	}
+	return docs
+}
@@ -0,0 +1,71 @@
+package bm25_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/retrievers/bm25"
+	"github.com/tmc/langchaingo/schema"
+)
+
+var docs = []schema.Document{
+	{PageContent: "Replace the XJ-200 filter every six months."},
+	{PageContent: "Error E1001 means the filter is clogged."},
+	{PageContent: "The pump is quiet and the pump is efficient."},
+	{PageContent: "Clean the pump housing when error E2002 appears."},
+}
+
+func TestRetriever(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	r := bm25.New(docs)
+	require.Equal(t, 4, r.Len())
+
+	found, err := r.GetRelevantDocuments(ctx, "xj-200 part?")
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	require.Equal(t, docs[0].PageContent, found[0].PageContent)
+	require.Positive(t, found[0].Score)
+
+	found, err = r.GetRelevantDocuments(ctx, "pump")
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+	// Repetitions of the term rank the third document first.
+	require.Equal(t, docs[2].PageContent, found[0].PageContent)
+	require.Greater(t, found[0].Score, found[1].Score)
+
+	found, err = r.GetRelevantDocuments(ctx, "nothing matches")
+	require.NoError(t, err)
+	require.Empty(t, found)
+
+	r.AddDocuments([]schema.Document{{PageContent: "E1001 E1001 E1001"}})
+	found, err = r.GetRelevantDocuments(ctx, "E1001")
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+	require.Equal(t, "E1001 E1001 E1001", found[0].PageContent)
+}
+
+func TestRetrieverOptions(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	found, err := bm25.New(docs, bm25.WithNumDocuments(1)).GetRelevantDocuments(ctx, "filter error")
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	require.Equal(t, docs[1].PageContent, found[0].PageContent)
+
+	// Without length normalization and with saturation at the first
+	// occurrence, both pump documents score the same.
+	found, err = bm25.New(docs, bm25.WithB(0), bm25.WithK1(0)).GetRelevantDocuments(ctx, "pump")
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+	require.InDelta(t, found[0].Score, found[1].Score, 1e-6)
+}
+
+func TestTokenize(t *testing.T) {
+	t.Parallel()
+	require.Equal(t, []string{"check", "part", "xj-200", "v1.2", "now"},
+		bm25.Tokenize("Check part XJ-200 (v1.2), now."))
+	require.Empty(t, bm25.Tokenize(" -- ... "))
+}
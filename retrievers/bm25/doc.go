@@ -0,0 +1,6 @@
+// Package bm25 contains a keyword retriever ranking documents with the Okapi
+// BM25 function. The index is kept in memory and built in pure Go, which makes
+// it a good complement to a vector store retriever for queries containing
+// exact terms, such as part numbers or error codes. See the ensemble package
+// to combine both.
+package bm25
@@ -0,0 +1,69 @@
+package bm25
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	// DefaultNumDocuments is the number of documents returned when none is given.
+	DefaultNumDocuments = 4
+	// DefaultK1 is the default term frequency saturation parameter.
+	DefaultK1 = 1.5
+	// DefaultB is the default document length normalization parameter.
+	DefaultB = 0.75
+)
+
+// Option is a function type that can be used to modify the retriever.
+type Option func(r *Retriever)
+
+// WithNumDocuments is an option for setting the number of documents returned.
+func WithNumDocuments(n int) Option {
+	return func(r *Retriever) {
+		r.numDocuments = n
+	}
+}
+
+// WithK1 is an option for setting the k1 parameter, which controls how fast
+// the score of a term saturates as it repeats in a document.
+func WithK1(k1 float64) Option {
+	return func(r *Retriever) {
+		r.k1 = k1
+	}
+}
+
+// WithB is an option for setting the b parameter, between 0 and 1, which
+// controls how much scores are normalized by document length.
+func WithB(b float64) Option {
+	return func(r *Retriever) {
+		r.b = b
+	}
+}
+
+// WithTokenizer is an option for setting the function splitting documents and
+// queries into terms. The default is Tokenize.
+func WithTokenizer(tokenize func(text string) []string) Option {
+	return func(r *Retriever) {
+		r.tokenize = tokenize
+	}
+}
+
+// Tokenize lower cases text and splits it into terms of letters and digits.
+// Dashes, underscores and dots inside a term are kept, so identifiers such as
+// "XJ-200" or "E1001.3" are single terms.
+func Tokenize(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !isJoiner(r)
+	})
+	terms := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if term := strings.TrimFunc(field, isJoiner); term != "" {
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+func isJoiner(r rune) bool {
+	return r == '-' || r == '_' || r == '.'
+}
@@ -0,0 +1,59 @@
+package compression
+
+import (
+	"context"
+
+	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/schema"
+)
+
+// Compressor is the interface for trimming documents to the passages
+// relevant to a query.
+type Compressor interface {
+	// CompressDocuments returns the documents trimmed to their passages
+	// relevant to the query, leaving out documents with none.
+	CompressDocuments(ctx context.Context, query string, docs []schema.Document) ([]schema.Document, error)
+}
+
+// Retriever is a retriever compressing the documents of another retriever.
+type Retriever struct {
+	CallbacksHandler callbacks.Handler
+	retriever        schema.Retriever
+	compressor       Compressor
+}
+
+var _ schema.Retriever = Retriever{}
+
+// GetRelevantDocuments returns the documents of the wrapped retriever once
+// compressed.
+func (r Retriever) GetRelevantDocuments(ctx context.Context, query string) ([]schema.Document, error) {
+	if r.CallbacksHandler != nil {
+		r.CallbacksHandler.HandleRetrieverStart(ctx, query)
+	}
+
+	docs, err := r.retriever.GetRelevantDocuments(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if len(docs) > 0 {
+		docs, err = r.compressor.CompressDocuments(ctx, query, docs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if r.CallbacksHandler != nil {
+		r.CallbacksHandler.HandleRetrieverEnd(ctx, query, docs)
+	}
+
+	return docs, nil
+}
+
+// ToRetriever takes a retriever and a compressor and returns a retriever
+// compressing the documents of the retriever.
+func ToRetriever(retriever schema.Retriever, compressor Compressor) Retriever {
+	return Retriever{
+		retriever:  retriever,
+		compressor: compressor,
+	}
+}
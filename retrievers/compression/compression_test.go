@@ -0,0 +1,102 @@
+package compression_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/retrievers/compression"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/textsplitter"
+)
+
+// extractModel answers with the first line of the context mentioning "pump",
+// or NO_OUTPUT.
+type extractModel struct{}
+
+func (extractModel) GenerateContent(_ context.Context, messages []llms.MessageContent, _ ...llms.CallOption) (*llms.ContentResponse, error) { //nolint:lll
+	prompt := messages[0].Parts[0].(llms.TextContent).Text
+	passage := prompt[strings.Index(prompt, ">>>\n")+4:]
+	answer := "NO_OUTPUT"
+	for _, line := range strings.Split(passage, "\n") {
+		if strings.Contains(line, "pump") {
+			answer = line
+			break
+		}
+	}
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: answer}}}, nil
+}
+
+func (m extractModel) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+// keywordEmbedder embeds a text as the count of each keyword in it.
+type keywordEmbedder struct{}
+
+var keywords = []string{"pump", "filter", "valve"}
+
+func (keywordEmbedder) EmbedDocuments(_ context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		vectors[i] = embed(text)
+	}
+	return vectors, nil
+}
+
+func (keywordEmbedder) EmbedQuery(_ context.Context, text string) ([]float32, error) {
+	return embed(text), nil
+}
+
+func embed(text string) []float32 {
+	v := make([]float32, len(keywords))
+	for i, kw := range keywords {
+		v[i] = float32(strings.Count(text, kw))
+	}
+	return v
+}
+
+type staticRetriever []schema.Document
+
+func (r staticRetriever) GetRelevantDocuments(context.Context, string) ([]schema.Document, error) {
+	return r, nil
+}
+
+var docs = staticRetriever{
+	{PageContent: "The pump moves water.\nThe filter stops sand.", Metadata: map[string]any{"source": "a"}},
+	{PageContent: "The valve closes.\nThe valve opens.", Metadata: map[string]any{"source": "b"}},
+}
+
+func TestLLMExtractor(t *testing.T) {
+	t.Parallel()
+
+	r := compression.ToRetriever(docs, compression.NewLLMExtractor(extractModel{}))
+	found, err := r.GetRelevantDocuments(context.Background(), "What does the pump do?")
+	require.NoError(t, err)
+	require.Equal(t, []schema.Document{
+		{PageContent: "The pump moves water.", Metadata: map[string]any{"source": "a"}},
+	}, found)
+}
+
+func TestEmbeddingsFilter(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	sentences := textsplitter.NewRecursiveCharacter(
+		textsplitter.WithSeparators([]string{"\n"}), textsplitter.WithChunkSize(10), textsplitter.WithChunkOverlap(0))
+	filter := compression.NewEmbeddingsFilter(keywordEmbedder{}, 0.5, compression.WithSplitter(sentences))
+
+	found, err := compression.ToRetriever(docs, filter).GetRelevantDocuments(ctx, "filter")
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	require.Equal(t, "The filter stops sand.", found[0].PageContent)
+	require.Equal(t, "a", found[0].Metadata["source"])
+	require.InDelta(t, 1, found[0].Score, 1e-6)
+
+	found, err = compression.ToRetriever(docs, filter).GetRelevantDocuments(ctx, "valve")
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	require.Equal(t, "The valve closes.\n\nThe valve opens.", found[0].PageContent)
+}
@@ -0,0 +1,8 @@
+// Package compression contains a contextual compression retriever, which
+// trims the documents of another retriever to the passages relevant to the
+// query with a Compressor, dropping documents with no relevant passage.
+//
+// Two compressors are provided: LLMExtractor asks a language model to
+// extract the relevant passages, and EmbeddingsFilter keeps the passages
+// whose embedding is similar enough to the embedding of the query.
+package compression
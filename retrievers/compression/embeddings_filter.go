@@ -0,0 +1,94 @@
+package compression
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/tmc/langchaingo/embeddings"
+	"github.com/tmc/langchaingo/schema"
+)
+
+// ErrEmbedderWrongNumberVectors is returned when the embedder returns a
+// number of vectors that is not equal to the number of passages given.
+var ErrEmbedderWrongNumberVectors = errors.New("number of vectors from embedder does not match number of passages")
+
+// EmbeddingsFilter is a compressor splitting documents into passages and
+// keeping the passages whose embedding has a cosine similarity with the
+// embedding of the query of at least a threshold.
+type EmbeddingsFilter struct {
+	embedder  embeddings.Embedder
+	threshold float32
+	opts      options
+}
+
+var _ Compressor = &EmbeddingsFilter{}
+
+// NewEmbeddingsFilter creates a compressor keeping the passages with a
+// similarity to the query of at least threshold.
+func NewEmbeddingsFilter(embedder embeddings.Embedder, threshold float32, opts ...Option) *EmbeddingsFilter {
+	return &EmbeddingsFilter{
+		embedder:  embedder,
+		threshold: threshold,
+		opts:      applyOptions(opts),
+	}
+}
+
+// CompressDocuments implements the Compressor interface. The score of the
+// documents returned is the similarity of their most similar passage.
+func (f *EmbeddingsFilter) CompressDocuments(
+	ctx context.Context,
+	query string,
+	docs []schema.Document,
+) ([]schema.Document, error) {
+	var passages []string
+	owners := make([]int, 0, len(docs))
+	for i, doc := range docs {
+		split, err := f.opts.splitter.SplitText(doc.PageContent)
+		if err != nil {
+			return nil, err
+		}
+		for _, passage := range split {
+			passages = append(passages, passage)
+			owners = append(owners, i)
+		}
+	}
+	if len(passages) == 0 {
+		return []schema.Document{}, nil
+	}
+
+	queryVector, err := f.embedder.EmbedQuery(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	vectors, err := f.embedder.EmbedDocuments(ctx, passages)
+	if err != nil {
+		return nil, err
+	}
+	if len(vectors) != len(passages) {
+		return nil, ErrEmbedderWrongNumberVectors
+	}
+
+	kept := make([][]string, len(docs))
+	scores := make([]float32, len(docs))
+	for i, vector := range vectors {
+		similarity := embeddings.CosineSimilarity(queryVector, vector)
+		if similarity < f.threshold {
+			continue
+		}
+		owner := owners[i]
+		kept[owner] = append(kept[owner], passages[i])
+		scores[owner] = max(scores[owner], similarity)
+	}
+
+	compressed := make([]schema.Document, 0, len(docs))
+	for i, doc := range docs {
+		if len(kept[i]) == 0 {
+			continue
+		}
+		doc.PageContent = strings.Join(kept[i], f.opts.separator)
+		doc.Score = scores[i]
+		compressed = append(compressed, doc)
+	}
+	return compressed, nil
+}
@@ -0,0 +1,81 @@
+package compression
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/schema"
+)
+
+// _noOutput is the answer of the model when a document has no relevant part.
+const _noOutput = "NO_OUTPUT"
+
+const _llmExtractorTemplate = `Given the following question and context, extract any part of the context *AS IS* that is relevant to answer the question. If none of the context is relevant return %s.
+
+Remember, *DO NOT* edit the extracted parts of the context.
+
+> Question: %s
+> Context:
+>>>
+%s
+>>>
+Extracted relevant parts:`
+
+// LLMExtractor is a compressor asking a language model to extract the parts
+// of each document relevant to the query.
+type LLMExtractor struct {
+	llm  llms.Model
+	opts options
+}
+
+var _ Compressor = &LLMExtractor{}
+
+// NewLLMExtractor creates a compressor extracting relevant passages with llm.
+func NewLLMExtractor(llm llms.Model, opts ...Option) *LLMExtractor {
+	return &LLMExtractor{
+		llm:  llm,
+		opts: applyOptions(opts),
+	}
+}
+
+// CompressDocuments implements the Compressor interface.
+func (e *LLMExtractor) CompressDocuments(
+	ctx context.Context,
+	query string,
+	docs []schema.Document,
+) ([]schema.Document, error) {
+	extracts := make([]string, len(docs))
+	errs := make([]error, len(docs))
+	sem := make(chan struct{}, max(e.opts.maxConcurrency, 1))
+	var wg sync.WaitGroup
+	for i, doc := range docs {
+		wg.Add(1)
+		go func(i int, doc schema.Document) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			prompt := fmt.Sprintf(_llmExtractorTemplate, _noOutput, query, doc.PageContent)
+			extracts[i], errs[i] = llms.GenerateFromSinglePrompt(ctx, e.llm, prompt, e.opts.callOptions...)
+		}(i, doc)
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	compressed := make([]schema.Document, 0, len(docs))
+	for i, doc := range docs {
+		extract := strings.TrimSpace(extracts[i])
+		if extract == "" || strings.Contains(extract, _noOutput) {
+			continue
+		}
+		doc.PageContent = extract
+		compressed = append(compressed, doc)
+	}
+	return compressed, nil
+}
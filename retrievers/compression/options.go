@@ -0,0 +1,74 @@
+package compression
+
+import (
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/textsplitter"
+)
+
+const (
+	// DefaultMaxConcurrency is the number of documents LLMExtractor
+	// compresses at the same time when none is given.
+	DefaultMaxConcurrency = 4
+	// DefaultPassageSize is the size, in characters, of the passages
+	// EmbeddingsFilter splits documents into when no splitter is given.
+	DefaultPassageSize = 300
+	// DefaultSeparator joins the passages kept from a document.
+	DefaultSeparator = "\n\n"
+)
+
+// Option is a function type that can be used to modify the compressors.
+type Option func(o *options)
+
+type options struct {
+	callOptions    []llms.CallOption
+	maxConcurrency int
+	splitter       textsplitter.TextSplitter
+	separator      string
+}
+
+func applyOptions(opts []Option) options {
+	o := options{
+		maxConcurrency: DefaultMaxConcurrency,
+		splitter: textsplitter.NewRecursiveCharacter(
+			textsplitter.WithChunkSize(DefaultPassageSize),
+			textsplitter.WithChunkOverlap(0),
+		),
+		separator: DefaultSeparator,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithCallOptions is an option for setting the options of the model calls of
+// LLMExtractor.
+func WithCallOptions(callOptions ...llms.CallOption) Option {
+	return func(o *options) {
+		o.callOptions = callOptions
+	}
+}
+
+// WithMaxConcurrency is an option for setting how many documents LLMExtractor
+// compresses at the same time.
+func WithMaxConcurrency(n int) Option {
+	return func(o *options) {
+		o.maxConcurrency = n
+	}
+}
+
+// WithSplitter is an option for setting how EmbeddingsFilter splits
+// documents into passages.
+func WithSplitter(splitter textsplitter.TextSplitter) Option {
+	return func(o *options) {
+		o.splitter = splitter
+	}
+}
+
+// WithSeparator is an option for setting the separator EmbeddingsFilter joins
+// the passages kept from a document with.
+func WithSeparator(separator string) Option {
+	return func(o *options) {
+		o.separator = separator
+	}
+}
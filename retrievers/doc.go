@@ -0,0 +1,19 @@
+// Package retrievers contains implementations of schema.Retriever that don't
+// depend on a vector store, and retrievers combining or wrapping other
+// retrievers.
+//
+// The implementations live in sub packages:
+//
+//   - bm25: a keyword retriever ranking documents with Okapi BM25, useful for
+//     exact terms such as identifiers and error codes that embeddings miss.
+//   - ensemble: a retriever merging the results of several retrievers with
+//     reciprocal rank fusion or weighted scores.
+//   - querytransform: retrievers rewriting the question with a language model
+//     before retrieving, with multiple queries, HyDE or decomposition.
+//   - parentdocument: a retriever searching small chunks and returning the
+//     larger documents they were split from.
+//   - compression: a retriever trimming documents to the passages relevant to
+//     the query, with a language model or embedding similarity.
+//   - selfquery: a retriever extracting a metadata filter from the question
+//     with a language model and searching a vector store with it.
+package retrievers
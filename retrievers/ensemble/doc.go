@@ -0,0 +1,6 @@
+// Package ensemble contains a retriever merging the results of several
+// retrievers, for example a bm25.Retriever for exact terms and a
+// vectorstores.Retriever for semantic similarity. Results are merged with
+// reciprocal rank fusion or a weighted sum of normalized scores, and
+// documents returned by several retrievers are deduplicated.
+package ensemble
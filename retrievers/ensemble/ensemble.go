@@ -0,0 +1,169 @@
+package ensemble
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/schema"
+)
+
+var (
+	// ErrNoRetrievers is returned by New if no retrievers are given.
+	ErrNoRetrievers = errors.New("no retrievers")
+	// ErrInvalidOptions is returned by New if the options are invalid.
+	ErrInvalidOptions = errors.New("invalid options")
+)
+
+// Retriever is a retriever querying several retrievers concurrently and
+// merging their results.
+type Retriever struct {
+	CallbacksHandler callbacks.Handler
+
+	retrievers   []schema.Retriever
+	weights      []float64
+	fusion       Fusion
+	rrfConstant  int
+	idKey        string
+	numDocuments int
+}
+
+var _ schema.Retriever = (*Retriever)(nil)
+
+// New creates an ensemble retriever merging the results of retrievers.
+func New(retrievers []schema.Retriever, opts ...Option) (*Retriever, error) {
+	if len(retrievers) == 0 {
+		return nil, ErrNoRetrievers
+	}
+	r := &Retriever{
+		retrievers:  retrievers,
+		fusion:      FusionReciprocalRank,
+		rrfConstant: DefaultRRFConstant,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if r.weights == nil {
+		r.weights = make([]float64, len(retrievers))
+		for i := range r.weights {
+			r.weights[i] = 1
+		}
+	}
+	if len(r.weights) != len(retrievers) {
+		return nil, fmt.Errorf("%w: %d weights for %d retrievers", ErrInvalidOptions, len(r.weights), len(retrievers))
+	}
+	if r.fusion != FusionReciprocalRank && r.fusion != FusionWeightedScore {
+		return nil, fmt.Errorf("%w: unknown fusion %q", ErrInvalidOptions, r.fusion)
+	}
+	return r, nil
+}
+
+// GetRelevantDocuments queries all the retrievers and returns their merged
+// documents, best first, with the score set to the fused score.
+func (r *Retriever) GetRelevantDocuments(ctx context.Context, query string) ([]schema.Document, error) {
+	if r.CallbacksHandler != nil {
+		r.CallbacksHandler.HandleRetrieverStart(ctx, query)
+	}
+
+	results := make([][]schema.Document, len(r.retrievers))
+	errs := make([]error, len(r.retrievers))
+	var wg sync.WaitGroup
+	for i, retriever := range r.retrievers {
+		wg.Add(1)
+		go func(i int, retriever schema.Retriever) {
+			defer wg.Done()
+			results[i], errs[i] = retriever.GetRelevantDocuments(ctx, query)
+		}(i, retriever)
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	docs := r.merge(results)
+
+	if r.CallbacksHandler != nil {
+		r.CallbacksHandler.HandleRetrieverEnd(ctx, query, docs)
+	}
+	return docs, nil
+}
+
+func (r *Retriever) merge(results [][]schema.Document) []schema.Document {
+	var docs []schema.Document
+	var scores []float64
+	positions := make(map[string]int)
+	for i, result := range results {
+		fused := r.scores(result)
+		for rank, doc := range result {
+			key := r.key(doc)
+			position, ok := positions[key]
+			if !ok {
+				position = len(docs)
+				positions[key] = position
+				docs = append(docs, doc)
+				scores = append(scores, 0)
+			}
+			scores[position] += r.weights[i] * fused[rank]
+		}
+	}
+
+	indexes := make([]int, len(docs))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return scores[indexes[i]] > scores[indexes[j]]
+	})
+	if r.numDocuments > 0 && len(indexes) > r.numDocuments {
+		indexes = indexes[:r.numDocuments]
+	}
+
+	merged := make([]schema.Document, len(indexes))
+	for i, index := range indexes {
+		merged[i] = docs[index]
+		merged[i].Score = float32(scores[index])
+	}
+	return merged
+}
+
+// scores returns the unweighted score of each document of a retriever result.
+func (r *Retriever) scores(result []schema.Document) []float64 {
+	scores := make([]float64, len(result))
+	if r.fusion == FusionReciprocalRank {
+		for rank := range result {
+			scores[rank] = 1 / float64(r.rrfConstant+rank+1)
+		}
+		return scores
+	}
+
+	if len(result) == 0 {
+		return scores
+	}
+	minScore, maxScore := result[0].Score, result[0].Score
+	for _, doc := range result {
+		minScore = min(minScore, doc.Score)
+		maxScore = max(maxScore, doc.Score)
+	}
+	for i, doc := range result {
+		if maxScore == minScore {
+			scores[i] = 1
+			continue
+		}
+		scores[i] = float64((doc.Score - minScore) / (maxScore - minScore))
+	}
+	return scores
+}
+
+// key returns the key documents are deduplicated by.
+func (r *Retriever) key(doc schema.Document) string {
+	if r.idKey != "" {
+		if id, ok := doc.Metadata[r.idKey]; ok {
+			return "id:" + fmt.Sprint(id)
+		}
+	}
+	return "content:" + doc.PageContent
+}
@@ -0,0 +1,127 @@
+package ensemble_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/retrievers/bm25"
+	"github.com/tmc/langchaingo/retrievers/ensemble"
+	"github.com/tmc/langchaingo/schema"
+)
+
+// staticRetriever always returns the same documents.
+type staticRetriever []schema.Document
+
+func (r staticRetriever) GetRelevantDocuments(context.Context, string) ([]schema.Document, error) {
+	return r, nil
+}
+
+type errRetriever struct{}
+
+func (errRetriever) GetRelevantDocuments(context.Context, string) ([]schema.Document, error) {
+	return nil, errors.New("boom")
+}
+
+func contents(docs []schema.Document) []string {
+	s := make([]string, len(docs))
+	for i, doc := range docs {
+		s[i] = doc.PageContent
+	}
+	return s
+}
+
+var (
+	keyword = staticRetriever{
+		{PageContent: "a", Score: 9},
+		{PageContent: "b", Score: 5},
+		{PageContent: "c", Score: 1},
+	}
+	semantic = staticRetriever{
+		{PageContent: "c", Score: 0.9},
+		{PageContent: "d", Score: 0.8},
+		{PageContent: "b", Score: 0.1},
+	}
+)
+
+func TestReciprocalRankFusion(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	r, err := ensemble.New([]schema.Retriever{keyword, semantic})
+	require.NoError(t, err)
+	docs, err := r.GetRelevantDocuments(ctx, "q")
+	require.NoError(t, err)
+	// c is 3rd and 1st, b 2nd and 3rd, a 1st, d 2nd.
+	require.Equal(t, []string{"c", "b", "a", "d"}, contents(docs))
+	require.InDelta(t, 1.0/63+1.0/61, docs[0].Score, 1e-6)
+
+	r, err = ensemble.New([]schema.Retriever{keyword, semantic},
+		ensemble.WithWeights(3, 1), ensemble.WithNumDocuments(2))
+	require.NoError(t, err)
+	docs, err = r.GetRelevantDocuments(ctx, "q")
+	require.NoError(t, err)
+	// The keyword ranks now weigh more, which puts b before c.
+	require.Equal(t, []string{"b", "c"}, contents(docs))
+}
+
+func TestWeightedScoreFusion(t *testing.T) {
+	t.Parallel()
+
+	r, err := ensemble.New([]schema.Retriever{keyword, semantic},
+		ensemble.WithFusion(ensemble.FusionWeightedScore), ensemble.WithWeights(0.5, 0.5))
+	require.NoError(t, err)
+	docs, err := r.GetRelevantDocuments(context.Background(), "q")
+	require.NoError(t, err)
+	// Normalized, a is 1, b is 0.5 and 0, c is 0 and 1, d is 0.875. Ties
+	// keep the order documents were first returned in.
+	require.Equal(t, []string{"a", "c", "d", "b"}, contents(docs))
+	require.InDelta(t, 0.5, docs[0].Score, 1e-6)
+	require.InDelta(t, 0.4375, docs[2].Score, 1e-6)
+}
+
+func TestDeduplicateByID(t *testing.T) {
+	t.Parallel()
+
+	first := staticRetriever{{PageContent: "chunk one", Metadata: map[string]any{"id": 1}}}
+	second := staticRetriever{
+		{PageContent: "chunk one, reformatted", Metadata: map[string]any{"id": 1}},
+		{PageContent: "chunk two"},
+	}
+	r, err := ensemble.New([]schema.Retriever{first, second}, ensemble.WithIDKey("id"))
+	require.NoError(t, err)
+	docs, err := r.GetRelevantDocuments(context.Background(), "q")
+	require.NoError(t, err)
+	require.Equal(t, []string{"chunk one", "chunk two"}, contents(docs))
+}
+
+func TestWithBM25(t *testing.T) {
+	t.Parallel()
+
+	keywords := bm25.New([]schema.Document{
+		{PageContent: "error E1001 means the filter is clogged"},
+		{PageContent: "the pump is efficient"},
+	})
+	r, err := ensemble.New([]schema.Retriever{keywords, staticRetriever{{PageContent: "the pump is efficient"}}})
+	require.NoError(t, err)
+	docs, err := r.GetRelevantDocuments(context.Background(), "E1001 pump")
+	require.NoError(t, err)
+	require.Equal(t, []string{"the pump is efficient", "error E1001 means the filter is clogged"}, contents(docs))
+}
+
+func TestNewErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := ensemble.New(nil)
+	require.ErrorIs(t, err, ensemble.ErrNoRetrievers)
+	_, err = ensemble.New([]schema.Retriever{keyword}, ensemble.WithWeights(1, 2))
+	require.ErrorIs(t, err, ensemble.ErrInvalidOptions)
+	_, err = ensemble.New([]schema.Retriever{keyword}, ensemble.WithFusion("max"))
+	require.ErrorIs(t, err, ensemble.ErrInvalidOptions)
+
+	r, err := ensemble.New([]schema.Retriever{keyword, errRetriever{}})
+	require.NoError(t, err)
+	_, err = r.GetRelevantDocuments(context.Background(), "q")
+	require.EqualError(t, err, "boom")
+}
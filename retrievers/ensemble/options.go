@@ -0,0 +1,62 @@
+package ensemble
+
+// DefaultRRFConstant is the constant added to ranks in reciprocal rank
+// fusion when none is given.
+const DefaultRRFConstant = 60
+
+// Fusion is the method used to merge the results of the retrievers.
+type Fusion string
+
+const (
+	// FusionReciprocalRank scores a document with the sum over the retrievers
+	// of weight / (constant + rank). It only uses ranks, so it works with
+	// retrievers whose scores aren't comparable.
+	FusionReciprocalRank Fusion = "reciprocal_rank"
+	// FusionWeightedScore scores a document with the weighted sum of its
+	// scores, min-max normalized per retriever.
+	FusionWeightedScore Fusion = "weighted_score"
+)
+
+// Option is a function type that can be used to modify the retriever.
+type Option func(r *Retriever)
+
+// WithWeights is an option for setting the weight of each retriever, in the
+// same order. By default all retrievers have a weight of 1.
+func WithWeights(weights ...float64) Option {
+	return func(r *Retriever) {
+		r.weights = weights
+	}
+}
+
+// WithFusion is an option for setting how results are merged. The default
+// is FusionReciprocalRank.
+func WithFusion(fusion Fusion) Option {
+	return func(r *Retriever) {
+		r.fusion = fusion
+	}
+}
+
+// WithRRFConstant is an option for setting the constant added to ranks in
+// reciprocal rank fusion. Higher values lower the advantage of top ranks.
+func WithRRFConstant(c int) Option {
+	return func(r *Retriever) {
+		r.rrfConstant = c
+	}
+}
+
+// WithIDKey is an option for deduplicating documents by the value of a
+// metadata key instead of by content. Documents without the key are still
+// deduplicated by content.
+func WithIDKey(key string) Option {
+	return func(r *Retriever) {
+		r.idKey = key
+	}
+}
+
+// WithNumDocuments is an option for setting the maximum number of documents
+// returned. By default all merged documents are returned.
+func WithNumDocuments(n int) Option {
+	return func(r *Retriever) {
+		r.numDocuments = n
+	}
+}
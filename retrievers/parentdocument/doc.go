@@ -0,0 +1,9 @@
+// Package parentdocument contains a retriever that searches small chunks of
+// documents but returns the larger documents they were split from.
+//
+// Small chunks embed more accurately, while language models answer better
+// with the surrounding context. The retriever splits each parent document
+// with a textsplitter.TextSplitter, stores the child chunks in a vector store
+// with the id of their parent in their metadata, and stores the parents in a
+// Docstore. Searches return the parents of the best matching chunks.
+package parentdocument
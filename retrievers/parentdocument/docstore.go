@@ -0,0 +1,76 @@
+package parentdocument
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/tmc/langchaingo/schema"
+)
+
+// ErrMismatchIDsAndDocuments is returned when the number of ids and documents
+// given to a Docstore don't match.
+var ErrMismatchIDsAndDocuments = errors.New("number of ids and documents does not match")
+
+// Docstore is the interface for storing documents by id.
+type Docstore interface {
+	// Get returns the documents stored with the given ids. Ids without a
+	// document are left out of the result.
+	Get(ctx context.Context, ids []string) (map[string]schema.Document, error)
+	// Set stores docs with the given ids, replacing any existing document.
+	Set(ctx context.Context, ids []string, docs []schema.Document) error
+	// Delete removes the documents with the given ids.
+	Delete(ctx context.Context, ids []string) error
+}
+
+// InMemoryDocstore is a Docstore keeping documents in memory. It is safe for
+// concurrent use.
+type InMemoryDocstore struct {
+	mu   sync.RWMutex
+	docs map[string]schema.Document
+}
+
+var _ Docstore = &InMemoryDocstore{}
+
+// NewInMemoryDocstore creates an empty in memory docstore.
+func NewInMemoryDocstore() *InMemoryDocstore {
+	return &InMemoryDocstore{docs: make(map[string]schema.Document)}
+}
+
+// Get implements the Docstore interface.
+func (s *InMemoryDocstore) Get(_ context.Context, ids []string) (map[string]schema.Document, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	docs := make(map[string]schema.Document, len(ids))
+	for _, id := range ids {
+		if doc, ok := s.docs[id]; ok {
+			docs[id] = doc
+		}
+	}
+	return docs, nil
+}
+
+// Set implements the Docstore interface.
+func (s *InMemoryDocstore) Set(_ context.Context, ids []string, docs []schema.Document) error {
+	if len(ids) != len(docs) {
+		return ErrMismatchIDsAndDocuments
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, id := range ids {
+		s.docs[id] = docs[i]
+	}
+	return nil
+}
+
+// Delete implements the Docstore interface.
+func (s *InMemoryDocstore) Delete(_ context.Context, ids []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, id := range ids {
+		delete(s.docs, id)
+	}
+	return nil
+}
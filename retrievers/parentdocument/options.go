@@ -0,0 +1,62 @@
+package parentdocument
+
+import (
+	"github.com/tmc/langchaingo/textsplitter"
+	"github.com/tmc/langchaingo/vectorstores"
+)
+
+const (
+	// DefaultIDKey is the metadata key of child chunks holding the id of
+	// their parent when none is given.
+	DefaultIDKey = "parent_id"
+	// DefaultNumChildren is the number of child chunks searched when none is
+	// given.
+	DefaultNumChildren = 10
+)
+
+// Option is a function type that can be used to modify the retriever.
+type Option func(r *Retriever)
+
+// WithParentSplitter is an option for splitting documents into parents
+// before splitting them into children, for documents too large to be given
+// to a model whole. The parents split from a document with an id get the id
+// followed by "-" and the index of the parent, others get a random id.
+func WithParentSplitter(splitter textsplitter.TextSplitter) Option {
+	return func(r *Retriever) {
+		r.parentSplitter = splitter
+	}
+}
+
+// WithIDKey is an option for setting the metadata key linking child chunks to
+// their parent. If a parent document already has a string value for this key,
+// it is used as its id.
+func WithIDKey(key string) Option {
+	return func(r *Retriever) {
+		r.idKey = key
+	}
+}
+
+// WithNumChildren is an option for setting the number of child chunks
+// searched in the vector store.
+func WithNumChildren(n int) Option {
+	return func(r *Retriever) {
+		r.numChildren = n
+	}
+}
+
+// WithNumDocuments is an option for setting the maximum number of parents
+// returned. By default the parents of all the child chunks found are
+// returned.
+func WithNumDocuments(n int) Option {
+	return func(r *Retriever) {
+		r.numDocuments = n
+	}
+}
+
+// WithVectorStoreOptions is an option for setting the options used when
+// adding child chunks to and searching the vector store.
+func WithVectorStoreOptions(options ...vectorstores.Option) Option {
+	return func(r *Retriever) {
+		r.storeOptions = options
+	}
+}
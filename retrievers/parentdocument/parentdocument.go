@@ -0,0 +1,172 @@
+package parentdocument
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/textsplitter"
+	"github.com/tmc/langchaingo/vectorstores"
+)
+
+// Retriever is a retriever searching child chunks in a vector store and
+// returning their parent documents from a docstore.
+type Retriever struct {
+	CallbacksHandler callbacks.Handler
+
+	store          vectorstores.VectorStore
+	docstore       Docstore
+	childSplitter  textsplitter.TextSplitter
+	parentSplitter textsplitter.TextSplitter
+	idKey          string
+	numChildren    int
+	numDocuments   int
+	storeOptions   []vectorstores.Option
+}
+
+var _ schema.Retriever = &Retriever{}
+
+// New creates a parent document retriever storing child chunks split with
+// childSplitter in store and parents in docstore.
+func New(
+	store vectorstores.VectorStore,
+	docstore Docstore,
+	childSplitter textsplitter.TextSplitter,
+	opts ...Option,
+) *Retriever {
+	r := &Retriever{
+		store:         store,
+		docstore:      docstore,
+		childSplitter: childSplitter,
+		idKey:         DefaultIDKey,
+		numChildren:   DefaultNumChildren,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// AddDocuments stores docs, or the parents they are split into with
+// WithParentSplitter, in the docstore and their child chunks in the vector
+// store. Each parent is stored with its id under the id key of its metadata.
+// It returns the ids of the parents.
+func (r *Retriever) AddDocuments(ctx context.Context, docs []schema.Document) ([]string, error) {
+	parents, err := r.splitParents(docs)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(parents))
+	var children []schema.Document
+	for i, parent := range parents {
+		id, ok := parent.Metadata[r.idKey].(string)
+		if !ok || id == "" {
+			id = uuid.NewString()
+		}
+		ids[i] = id
+		parents[i].Metadata = maps.Clone(parent.Metadata)
+		if parents[i].Metadata == nil {
+			parents[i].Metadata = map[string]any{}
+		}
+		parents[i].Metadata[r.idKey] = id
+
+		chunks, err := textsplitter.SplitDocuments(r.childSplitter, []schema.Document{parents[i]})
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, chunks...)
+	}
+
+	if err := r.docstore.Set(ctx, ids, parents); err != nil {
+		return nil, err
+	}
+	if len(children) == 0 {
+		return ids, nil
+	}
+	if _, err := r.store.AddDocuments(ctx, children, r.storeOptions...); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// splitParents returns the parents of docs, split with the parent splitter if
+// there is one. The splits of a document with an id get the id followed by
+// "-" and the index of the split.
+func (r *Retriever) splitParents(docs []schema.Document) ([]schema.Document, error) {
+	if r.parentSplitter == nil {
+		return slices.Clone(docs), nil
+	}
+
+	var parents []schema.Document
+	for _, doc := range docs {
+		splits, err := textsplitter.SplitDocuments(r.parentSplitter, []schema.Document{doc})
+		if err != nil {
+			return nil, err
+		}
+		id, _ := doc.Metadata[r.idKey].(string)
+		for i := range splits {
+			if id != "" {
+				splits[i].Metadata[r.idKey] = fmt.Sprintf("%s-%d", id, i)
+			} else {
+				delete(splits[i].Metadata, r.idKey)
+			}
+		}
+		parents = append(parents, splits...)
+	}
+	return parents, nil
+}
+
+// GetRelevantDocuments returns the parents of the child chunks most similar
+// to the query, in the order of their best chunk, with the score of that
+// chunk.
+func (r *Retriever) GetRelevantDocuments(ctx context.Context, query string) ([]schema.Document, error) {
+	if r.CallbacksHandler != nil {
+		r.CallbacksHandler.HandleRetrieverStart(ctx, query)
+	}
+
+	children, err := r.store.SimilaritySearch(ctx, query, r.numChildren, r.storeOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	scores := make(map[string]float32)
+	for _, child := range children {
+		id, ok := child.Metadata[r.idKey].(string)
+		if !ok {
+			continue
+		}
+		if _, seen := scores[id]; seen {
+			continue
+		}
+		scores[id] = child.Score
+		ids = append(ids, id)
+	}
+
+	parents, err := r.docstore.Get(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	docs := make([]schema.Document, 0, len(parents))
+	for _, id := range ids {
+		parent, ok := parents[id]
+		if !ok {
+			continue
+		}
+		parent.Score = scores[id]
+		docs = append(docs, parent)
+		if r.numDocuments > 0 && len(docs) == r.numDocuments {
+			break
+		}
+	}
+
+	if r.CallbacksHandler != nil {
+		r.CallbacksHandler.HandleRetrieverEnd(ctx, query, docs)
+	}
+	return docs, nil
+}
@@ -0,0 +1,107 @@
+package parentdocument_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/retrievers/parentdocument"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/textsplitter"
+	"github.com/tmc/langchaingo/vectorstores/inmemory"
+)
+
+// keywordEmbedder embeds a text as the count of each keyword in it.
+type keywordEmbedder struct{}
+
+var keywords = []string{"pump", "filter", "valve"}
+
+func (keywordEmbedder) EmbedDocuments(_ context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		vectors[i] = embed(text)
+	}
+	return vectors, nil
+}
+
+func (keywordEmbedder) EmbedQuery(_ context.Context, text string) ([]float32, error) {
+	return embed(text), nil
+}
+
+func embed(text string) []float32 {
+	v := make([]float32, len(keywords))
+	for i, kw := range keywords {
+		v[i] = float32(strings.Count(text, kw))
+	}
+	return v
+}
+
+var docs = []schema.Document{
+	{PageContent: "The pump moves water.\n\nThe filter stops sand.", Metadata: map[string]any{"source": "a"}},
+	{PageContent: "The valve closes.\n\nThe valve opens.", Metadata: map[string]any{"source": "b", "parent_id": "b"}},
+}
+
+func TestRetriever(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	store, err := inmemory.New(inmemory.WithEmbedder(keywordEmbedder{}))
+	require.NoError(t, err)
+	docstore := parentdocument.NewInMemoryDocstore()
+	splitter := textsplitter.NewRecursiveCharacter(textsplitter.WithChunkSize(25), textsplitter.WithChunkOverlap(0))
+	r := parentdocument.New(store, docstore, splitter, parentdocument.WithNumDocuments(1))
+
+	ids, err := r.AddDocuments(ctx, docs)
+	require.NoError(t, err)
+	require.Len(t, ids, 2)
+	require.Equal(t, "b", ids[1])
+	require.Equal(t, 4, store.Len(inmemory.DefaultNameSpace))
+
+	found, err := r.GetRelevantDocuments(ctx, "filter")
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	require.Equal(t, docs[0].PageContent, found[0].PageContent)
+	require.Equal(t, "a", found[0].Metadata["source"])
+	require.InDelta(t, 1, found[0].Score, 1e-6)
+
+	found, err = r.GetRelevantDocuments(ctx, "valve")
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	require.Equal(t, docs[1].PageContent, found[0].PageContent)
+
+	// Parents missing from the docstore are skipped.
+	require.NoError(t, docstore.Delete(ctx, []string{"b"}))
+	found, err = r.GetRelevantDocuments(ctx, "valve")
+	require.NoError(t, err)
+	require.Equal(t, docs[0].PageContent, found[0].PageContent)
+}
+
+func TestParentSplitter(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	store, err := inmemory.New(inmemory.WithEmbedder(keywordEmbedder{}))
+	require.NoError(t, err)
+	parents := textsplitter.NewRecursiveCharacter(textsplitter.WithChunkSize(25), textsplitter.WithChunkOverlap(0))
+	children := textsplitter.NewRecursiveCharacter(textsplitter.WithChunkSize(10), textsplitter.WithChunkOverlap(0))
+	r := parentdocument.New(store, parentdocument.NewInMemoryDocstore(), children,
+		parentdocument.WithParentSplitter(parents), parentdocument.WithIDKey("section"))
+
+	ids, err := r.AddDocuments(ctx, docs[:1])
+	require.NoError(t, err)
+	require.Len(t, ids, 2)
+	require.NotEqual(t, ids[0], ids[1])
+
+	doc := docs[0]
+	doc.Metadata = map[string]any{"section": "manual"}
+	ids, err = r.AddDocuments(ctx, []schema.Document{doc})
+	require.NoError(t, err)
+	require.Equal(t, []string{"manual-0", "manual-1"}, ids)
+	require.Equal(t, map[string]any{"section": "manual"}, doc.Metadata)
+
+	found, err := r.GetRelevantDocuments(ctx, "pump")
+	require.NoError(t, err)
+	require.NotEmpty(t, found)
+	require.Equal(t, "The pump moves water.", found[0].PageContent)
+}
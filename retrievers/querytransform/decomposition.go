@@ -0,0 +1,55 @@
+package querytransform
+
+import (
+	"context"
+
+	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/prompts"
+	"github.com/tmc/langchaingo/schema"
+)
+
+const _decompositionTemplate = `Break the question below into at most {{.n}} simpler sub-questions that can each be answered by looking up a single fact, and whose answers together answer the question.
+If the question is already simple, repeat it unchanged.
+Write one sub-question per line, without numbering or any other text.
+
+Question: {{.question}}`
+
+// Decomposition is a retriever splitting a multi-hop question into
+// sub-questions with a language model and returning the union of the
+// documents retrieved for each of them.
+type Decomposition struct {
+	CallbacksHandler callbacks.Handler
+	retriever        schema.Retriever
+	llm              llms.Model
+	opts             options
+}
+
+var _ schema.Retriever = &Decomposition{}
+
+// NewDecomposition creates a decomposition retriever wrapping retriever.
+func NewDecomposition(retriever schema.Retriever, llm llms.Model, opts ...Option) *Decomposition {
+	return &Decomposition{
+		retriever: retriever,
+		llm:       llm,
+		opts:      applyOptions(opts),
+	}
+}
+
+// GetRelevantDocuments returns the documents retrieved for each
+// sub-question, deduplicated by content.
+func (r *Decomposition) GetRelevantDocuments(ctx context.Context, question string) ([]schema.Document, error) {
+	text, err := generate(ctx, r.llm,
+		prompts.NewPromptTemplate(_decompositionTemplate, []string{"question", "n"}), question, r.opts)
+	if err != nil {
+		return nil, err
+	}
+	queries := parseQueries(text, r.opts.numQueries)
+	if r.opts.includeOriginal {
+		queries = append([]string{question}, queries...)
+	}
+	if len(queries) == 0 {
+		return nil, ErrNoQueries
+	}
+	return retrieveAll(ctx, r.retriever, r.CallbacksHandler, queries)
+}
@@ -0,0 +1,15 @@
+// Package querytransform contains retrievers that use a language model to
+// rewrite the question before passing it to another retriever:
+//
+//   - MultiQuery generates several paraphrases of the question and returns
+//     the union of their documents, which makes retrieval less sensitive to
+//     the wording of the question.
+//   - HyDE generates a hypothetical document answering the question and
+//     retrieves with it, so that a vector store compares documents with a
+//     document rather than with a question.
+//   - Decomposition splits a multi-hop question into simpler sub-questions
+//     and returns the union of their documents.
+//
+// The callbacks handler of these retrievers is called for each rewritten
+// query sent to the wrapped retriever.
+package querytransform
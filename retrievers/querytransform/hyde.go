@@ -0,0 +1,55 @@
+package querytransform
+
+import (
+	"context"
+	"strings"
+
+	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/prompts"
+	"github.com/tmc/langchaingo/schema"
+)
+
+const _hydeTemplate = `Write a short passage that answers the question below, as it could appear in a reference document.
+Write only the passage.
+
+Question: {{.question}}`
+
+// HyDE is a retriever implementing Hypothetical Document Embeddings: it
+// generates a document answering the question with a language model and
+// retrieves with that document instead of the question. With a
+// vectorstores.Retriever, the hypothetical document is what gets embedded.
+type HyDE struct {
+	CallbacksHandler callbacks.Handler
+	retriever        schema.Retriever
+	llm              llms.Model
+	opts             options
+}
+
+var _ schema.Retriever = &HyDE{}
+
+// NewHyDE creates a HyDE retriever wrapping retriever.
+func NewHyDE(retriever schema.Retriever, llm llms.Model, opts ...Option) *HyDE {
+	return &HyDE{
+		retriever: retriever,
+		llm:       llm,
+		opts:      applyOptions(opts),
+	}
+}
+
+// GetRelevantDocuments returns the documents retrieved for a hypothetical
+// document answering the question.
+func (r *HyDE) GetRelevantDocuments(ctx context.Context, question string) ([]schema.Document, error) {
+	text, err := generate(ctx, r.llm, prompts.NewPromptTemplate(_hydeTemplate, []string{"question"}), question, r.opts)
+	if err != nil {
+		return nil, err
+	}
+	query := strings.TrimSpace(text)
+	if r.opts.includeOriginal {
+		query = question + "\n\n" + query
+	}
+	if query == "" {
+		return nil, ErrNoQueries
+	}
+	return retrieve(ctx, r.retriever, r.CallbacksHandler, query)
+}
@@ -0,0 +1,55 @@
+package querytransform
+
+import (
+	"context"
+
+	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/prompts"
+	"github.com/tmc/langchaingo/schema"
+)
+
+const _multiQueryTemplate = `You are helping a search engine find documents answering a question.
+Write {{.n}} different versions of the question below, using different wording and points of view, so that documents missed by one version can be found by another.
+Write one version per line, without numbering or any other text.
+
+Question: {{.question}}`
+
+// MultiQuery is a retriever generating paraphrases of the question with a
+// language model and returning the union of the documents retrieved for
+// each of them.
+type MultiQuery struct {
+	CallbacksHandler callbacks.Handler
+	retriever        schema.Retriever
+	llm              llms.Model
+	opts             options
+}
+
+var _ schema.Retriever = &MultiQuery{}
+
+// NewMultiQuery creates a multi-query retriever wrapping retriever.
+func NewMultiQuery(retriever schema.Retriever, llm llms.Model, opts ...Option) *MultiQuery {
+	return &MultiQuery{
+		retriever: retriever,
+		llm:       llm,
+		opts:      applyOptions(opts),
+	}
+}
+
+// GetRelevantDocuments returns the documents retrieved for each generated
+// query, deduplicated by content.
+func (r *MultiQuery) GetRelevantDocuments(ctx context.Context, question string) ([]schema.Document, error) {
+	text, err := generate(ctx, r.llm, prompts.NewPromptTemplate(_multiQueryTemplate, []string{"question", "n"}),
+		question, r.opts)
+	if err != nil {
+		return nil, err
+	}
+	queries := parseQueries(text, r.opts.numQueries)
+	if r.opts.includeOriginal {
+		queries = append([]string{question}, queries...)
+	}
+	if len(queries) == 0 {
+		return nil, ErrNoQueries
+	}
+	return retrieveAll(ctx, r.retriever, r.CallbacksHandler, queries)
+}
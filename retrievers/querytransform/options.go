@@ -0,0 +1,63 @@
+package querytransform
+
+import (
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/prompts"
+)
+
+// DefaultNumQueries is the number of queries MultiQuery generates, and the
+// maximum number of sub-questions of Decomposition, when none is given.
+const DefaultNumQueries = 3
+
+// Option is a function type that can be used to modify the retrievers.
+type Option func(o *options)
+
+type options struct {
+	prompt          *prompts.PromptTemplate
+	numQueries      int
+	includeOriginal bool
+	callOptions     []llms.CallOption
+}
+
+func applyOptions(opts []Option) options {
+	o := options{
+		numQueries: DefaultNumQueries,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithPrompt is an option for replacing the prompt used to rewrite the
+// question. The prompt is given the "question" and, except for HyDE, the
+// number of queries to write as "n". MultiQuery and Decomposition expect one
+// query per line in the answer.
+func WithPrompt(prompt prompts.PromptTemplate) Option {
+	return func(o *options) {
+		o.prompt = &prompt
+	}
+}
+
+// WithNumQueries is an option for setting the number of queries MultiQuery
+// generates, or the maximum number of sub-questions of Decomposition.
+func WithNumQueries(n int) Option {
+	return func(o *options) {
+		o.numQueries = n
+	}
+}
+
+// WithIncludeOriginal is an option for also retrieving with the original
+// question. For HyDE, the question is prepended to the hypothetical document.
+func WithIncludeOriginal(include bool) Option {
+	return func(o *options) {
+		o.includeOriginal = include
+	}
+}
+
+// WithCallOptions is an option for setting the options of the model calls.
+func WithCallOptions(callOptions ...llms.CallOption) Option {
+	return func(o *options) {
+		o.callOptions = callOptions
+	}
+}
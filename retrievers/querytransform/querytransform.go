@@ -0,0 +1,118 @@
+package querytransform
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/prompts"
+	"github.com/tmc/langchaingo/schema"
+)
+
+// ErrNoQueries is returned when the model doesn't write any query.
+var ErrNoQueries = errors.New("no queries generated")
+
+var _listMarkerRegexp = regexp.MustCompile(`^\s*(?:[-*•]|\d+[.)])\s*`)
+
+// generate returns the answer of llm to the prompt of o, or to defaultPrompt
+// if there is none, for question.
+func generate(
+	ctx context.Context,
+	llm llms.Model,
+	defaultPrompt prompts.PromptTemplate,
+	question string,
+	o options,
+) (string, error) {
+	prompt := defaultPrompt
+	if o.prompt != nil {
+		prompt = *o.prompt
+	}
+	text, err := prompt.Format(map[string]any{
+		"question": question,
+		"n":        o.numQueries,
+	})
+	if err != nil {
+		return "", err
+	}
+	return llms.GenerateFromSinglePrompt(ctx, llm, text, o.callOptions...)
+}
+
+// parseQueries returns the distinct lines of text, without list markers, up
+// to n of them if n is positive.
+func parseQueries(text string, n int) []string {
+	var queries []string
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(text, "\n") {
+		query := strings.TrimSpace(_listMarkerRegexp.ReplaceAllString(line, ""))
+		if query == "" || seen[query] {
+			continue
+		}
+		seen[query] = true
+		queries = append(queries, query)
+		if n > 0 && len(queries) == n {
+			break
+		}
+	}
+	return queries
+}
+
+// retrieveAll retrieves documents for all the queries concurrently, calling
+// handler for each of them, and returns the union of the documents in query
+// order, deduplicated by content.
+func retrieveAll(
+	ctx context.Context,
+	retriever schema.Retriever,
+	handler callbacks.Handler,
+	queries []string,
+) ([]schema.Document, error) {
+	results := make([][]schema.Document, len(queries))
+	errs := make([]error, len(queries))
+	var wg sync.WaitGroup
+	for i, query := range queries {
+		wg.Add(1)
+		go func(i int, query string) {
+			defer wg.Done()
+			results[i], errs[i] = retrieve(ctx, retriever, handler, query)
+		}(i, query)
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	var docs []schema.Document
+	seen := make(map[string]bool)
+	for _, result := range results {
+		for _, doc := range result {
+			if seen[doc.PageContent] {
+				continue
+			}
+			seen[doc.PageContent] = true
+			docs = append(docs, doc)
+		}
+	}
+	return docs, nil
+}
+
+func retrieve(
+	ctx context.Context,
+	retriever schema.Retriever,
+	handler callbacks.Handler,
+	query string,
+) ([]schema.Document, error) {
+	if handler != nil {
+		handler.HandleRetrieverStart(ctx, query)
+	}
+	docs, err := retriever.GetRelevantDocuments(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if handler != nil {
+		handler.HandleRetrieverEnd(ctx, query, docs)
+	}
+	return docs, nil
+}
@@ -0,0 +1,130 @@
+package querytransform_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/prompts"
+	"github.com/tmc/langchaingo/retrievers/querytransform"
+	"github.com/tmc/langchaingo/schema"
+)
+
+// staticModel always answers with response and records its prompts.
+type staticModel struct {
+	response string
+	prompts  []string
+}
+
+func (m *staticModel) GenerateContent(_ context.Context, messages []llms.MessageContent, _ ...llms.CallOption) (*llms.ContentResponse, error) { //nolint:lll
+	m.prompts = append(m.prompts, messages[0].Parts[0].(llms.TextContent).Text)
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: m.response}}}, nil
+}
+
+func (m *staticModel) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+// wordRetriever returns a document for each word of the query.
+type wordRetriever struct{}
+
+func (wordRetriever) GetRelevantDocuments(_ context.Context, query string) ([]schema.Document, error) {
+	var docs []schema.Document
+	for _, word := range strings.Fields(query) {
+		docs = append(docs, schema.Document{PageContent: word})
+	}
+	return docs, nil
+}
+
+// recordingHandler records the queries of retriever callbacks.
+type recordingHandler struct {
+	callbacks.SimpleHandler
+
+	mu     sync.Mutex
+	starts []string
+	ends   []string
+}
+
+func (h *recordingHandler) HandleRetrieverStart(_ context.Context, query string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.starts = append(h.starts, query)
+}
+
+func (h *recordingHandler) HandleRetrieverEnd(_ context.Context, query string, _ []schema.Document) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.ends = append(h.ends, query)
+}
+
+func contents(docs []schema.Document) []string {
+	s := make([]string, len(docs))
+	for i, doc := range docs {
+		s[i] = doc.PageContent
+	}
+	return s
+}
+
+func TestMultiQuery(t *testing.T) {
+	t.Parallel()
+
+	model := &staticModel{response: "1. pump price\n2. pump cost\n\n- pump price\n3. water pump\n4. ignored"}
+	handler := &recordingHandler{}
+	r := querytransform.NewMultiQuery(wordRetriever{}, model, querytransform.WithIncludeOriginal(true))
+	r.CallbacksHandler = handler
+
+	docs, err := r.GetRelevantDocuments(context.Background(), "XJ-200 pump")
+	require.NoError(t, err)
+	require.Equal(t, []string{"XJ-200", "pump", "price", "cost", "water"}, contents(docs))
+	require.Contains(t, model.prompts[0], "Write 3 different versions")
+	require.Contains(t, model.prompts[0], "Question: XJ-200 pump")
+	require.ElementsMatch(t, []string{"XJ-200 pump", "pump price", "pump cost", "water pump"}, handler.starts)
+	require.ElementsMatch(t, handler.starts, handler.ends)
+
+	_, err = querytransform.NewMultiQuery(wordRetriever{}, &staticModel{response: "\n"}).
+		GetRelevantDocuments(context.Background(), "q")
+	require.ErrorIs(t, err, querytransform.ErrNoQueries)
+}
+
+func TestDecomposition(t *testing.T) {
+	t.Parallel()
+
+	model := &staticModel{response: "Who makes the XJ-200?\nWhere is that maker based?"}
+	handler := &recordingHandler{}
+	r := querytransform.NewDecomposition(wordRetriever{}, model, querytransform.WithNumQueries(5))
+	r.CallbacksHandler = handler
+
+	docs, err := r.GetRelevantDocuments(context.Background(), "Where is the maker of the XJ-200 based?")
+	require.NoError(t, err)
+	require.Equal(t, []string{"Who", "makes", "the", "XJ-200?", "Where", "is", "that", "maker", "based?"}, contents(docs))
+	require.Contains(t, model.prompts[0], "at most 5 simpler sub-questions")
+	require.ElementsMatch(t, []string{"Who makes the XJ-200?", "Where is that maker based?"}, handler.starts)
+}
+
+func TestHyDE(t *testing.T) {
+	t.Parallel()
+
+	model := &staticModel{response: " The XJ-200 pumps water. "}
+	handler := &recordingHandler{}
+	r := querytransform.NewHyDE(wordRetriever{}, model)
+	r.CallbacksHandler = handler
+
+	docs, err := r.GetRelevantDocuments(context.Background(), "What does the XJ-200 do?")
+	require.NoError(t, err)
+	require.Equal(t, []string{"The", "XJ-200", "pumps", "water."}, contents(docs))
+	require.Equal(t, []string{"The XJ-200 pumps water."}, handler.starts)
+	require.Equal(t, []string{"The XJ-200 pumps water."}, handler.ends)
+
+	prompt := prompts.NewPromptTemplate("Answer: {{.question}}", []string{"question"})
+	r = querytransform.NewHyDE(wordRetriever{}, model,
+		querytransform.WithPrompt(prompt), querytransform.WithIncludeOriginal(true))
+	r.CallbacksHandler = handler
+	docs, err = r.GetRelevantDocuments(context.Background(), "XJ-200?")
+	require.NoError(t, err)
+	require.Equal(t, []string{"XJ-200?", "The", "XJ-200", "pumps", "water."}, contents(docs))
+	require.Equal(t, "Answer: XJ-200?", model.prompts[1])
+}
@@ -0,0 +1,13 @@
+// Package selfquery contains a retriever that uses a language model to turn
+// a question into a search query and a metadata filter, and searches a vector
+// store with both.
+//
+// The model is told what the documents contain and which metadata attributes
+// can be filtered on, and answers with the query and a filter.Expr in its
+// JSON form. For the question "manuals about pumps published after 2020" it
+// may search for "pumps" among the documents matching
+//
+//	filter.And(filter.Eq("kind", "manual"), filter.Gt("year", 2020))
+//
+// which every vector store of this module translates to its native filters.
+package selfquery
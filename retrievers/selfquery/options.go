@@ -0,0 +1,46 @@
+package selfquery
+
+import (
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/prompts"
+	"github.com/tmc/langchaingo/vectorstores"
+)
+
+// DefaultNumDocuments is the number of documents returned when none is given.
+const DefaultNumDocuments = 4
+
+// Option is a function type that can be used to modify the retriever.
+type Option func(r *Retriever)
+
+// WithNumDocuments is an option for setting the number of documents returned.
+func WithNumDocuments(n int) Option {
+	return func(r *Retriever) {
+		r.numDocuments = n
+	}
+}
+
+// WithVectorStoreOptions is an option for setting the options of the vector
+// store search, such as vectorstores.WithNameSpace. A filters option is
+// replaced by the generated filter.
+func WithVectorStoreOptions(options ...vectorstores.Option) Option {
+	return func(r *Retriever) {
+		r.storeOptions = options
+	}
+}
+
+// WithPrompt is an option for replacing the prompt used to structure the
+// question. The prompt is given the "question", the description of the
+// document "contents" and the description of the "attributes", and must ask
+// for the JSON object described in the package documentation.
+func WithPrompt(prompt prompts.PromptTemplate) Option {
+	return func(r *Retriever) {
+		r.prompt = prompt
+	}
+}
+
+// WithCallOptions is an option for setting the options of the model call.
+func WithCallOptions(callOptions ...llms.CallOption) Option {
+	return func(r *Retriever) {
+		r.callOptions = callOptions
+	}
+}
@@ -0,0 +1,209 @@
+package selfquery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/prompts"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/vectorstores"
+	"github.com/tmc/langchaingo/vectorstores/filter"
+)
+
+var (
+	// ErrInvalidResponse is returned when the model response isn't a valid
+	// structured query.
+	ErrInvalidResponse = errors.New("invalid model response")
+	// ErrUnknownAttribute is returned when the generated filter uses an
+	// attribute that wasn't described to the model.
+	ErrUnknownAttribute = errors.New("unknown attribute")
+)
+
+const _template = `Your goal is to structure the user's question to match the request schema below, to search a collection of documents.
+
+The documents contain: {{.contents}}
+
+The documents have the following metadata attributes:
+{{.attributes}}
+Answer with a JSON object with two keys:
+- "query": the text to compare with the contents of the documents, without the conditions on the attributes. Use an empty string if nothing is left to compare.
+- "filter": a condition on the attributes, or null if the question has none.
+
+A condition is either a comparison {"op": OP, "key": ATTRIBUTE, "value": VALUE}, where OP is one of "eq", "ne", "gt", "gte", "lt", "lte", or "in" and "nin" with a list VALUE,
+or a combination {"op": OP, "operands": [CONDITION, ...]}, where OP is "and", "or", or "not" with a single operand.
+Only use the attributes listed above, with values of their type.
+
+Example question: manuals about pumps published after 2020
+Example answer: {"query": "pumps", "filter": {"op": "and", "operands": [{"op": "eq", "key": "kind", "value": "manual"}, {"op": "gt", "key": "year", "value": 2020}]}}
+
+Question: {{.question}}
+Answer:`
+
+// AttributeInfo describes a metadata attribute the model can filter on.
+type AttributeInfo struct {
+	// Name is the metadata key.
+	Name string
+	// Type is the type of the values, such as "string", "integer" or
+	// "boolean".
+	Type string
+	// Description tells the model what the attribute means and, if useful,
+	// which values it takes.
+	Description string
+}
+
+// Query is a question structured by the model.
+type Query struct {
+	// Query is the text to search for.
+	Query string `json:"query"`
+	// Filter is the metadata filter, nil if the question has none.
+	Filter *filter.Expr `json:"filter"`
+}
+
+// Retriever is a retriever searching a vector store with the query and
+// metadata filter a language model extracts from the question.
+type Retriever struct {
+	CallbacksHandler callbacks.Handler
+
+	llm              llms.Model
+	store            vectorstores.VectorStore
+	documentContents string
+	attributes       []AttributeInfo
+	prompt           prompts.PromptTemplate
+	numDocuments     int
+	storeOptions     []vectorstores.Option
+	callOptions      []llms.CallOption
+}
+
+var _ schema.Retriever = &Retriever{}
+
+// New creates a self-query retriever searching store, whose documents are
+// described by documentContents and have the given metadata attributes.
+func New(
+	llm llms.Model,
+	store vectorstores.VectorStore,
+	documentContents string,
+	attributes []AttributeInfo,
+	opts ...Option,
+) *Retriever {
+	r := &Retriever{
+		llm:              llm,
+		store:            store,
+		documentContents: documentContents,
+		attributes:       attributes,
+		prompt:           prompts.NewPromptTemplate(_template, []string{"contents", "attributes", "question"}),
+		numDocuments:     DefaultNumDocuments,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// StructureQuery asks the model for the query and filter of the question.
+// The filter is validated and only uses the attributes of the retriever.
+func (r *Retriever) StructureQuery(ctx context.Context, question string) (Query, error) {
+	var attributes strings.Builder
+	for _, attribute := range r.attributes {
+		fmt.Fprintf(&attributes, "- %s (%s): %s\n", attribute.Name, attribute.Type, attribute.Description)
+	}
+	prompt, err := r.prompt.Format(map[string]any{
+		"contents":   r.documentContents,
+		"attributes": attributes.String(),
+		"question":   question,
+	})
+	if err != nil {
+		return Query{}, err
+	}
+	completion, err := llms.GenerateFromSinglePrompt(ctx, r.llm, prompt, r.callOptions...)
+	if err != nil {
+		return Query{}, err
+	}
+
+	query, err := parseQuery(completion)
+	if err != nil {
+		return Query{}, err
+	}
+	if query.Filter != nil {
+		if err := r.checkAttributes(*query.Filter); err != nil {
+			return Query{}, err
+		}
+	}
+	return query, nil
+}
+
+// GetRelevantDocuments returns the documents most similar to the structured
+// query among those matching its filter. If the model leaves no text to
+// search for, the question is searched for instead.
+func (r *Retriever) GetRelevantDocuments(ctx context.Context, question string) ([]schema.Document, error) {
+	if r.CallbacksHandler != nil {
+		r.CallbacksHandler.HandleRetrieverStart(ctx, question)
+	}
+
+	query, err := r.StructureQuery(ctx, question)
+	if err != nil {
+		return nil, err
+	}
+	text := query.Query
+	if strings.TrimSpace(text) == "" {
+		text = question
+	}
+	options := r.storeOptions
+	if query.Filter != nil {
+		options = append(append([]vectorstores.Option{}, r.storeOptions...), vectorstores.WithFilters(*query.Filter))
+	}
+	docs, err := vectorstores.ToRetriever(r.store, r.numDocuments, options...).GetRelevantDocuments(ctx, text)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.CallbacksHandler != nil {
+		r.CallbacksHandler.HandleRetrieverEnd(ctx, question, docs)
+	}
+	return docs, nil
+}
+
+func (r *Retriever) checkAttributes(e filter.Expr) error {
+	known := make(map[string]bool, len(r.attributes))
+	for _, attribute := range r.attributes {
+		known[attribute.Name] = true
+	}
+	for _, key := range e.Keys() {
+		if !known[key] {
+			return fmt.Errorf("%w: %q", ErrUnknownAttribute, key)
+		}
+	}
+	return nil
+}
+
+// parseQuery parses the JSON object of a completion, ignoring any text or
+// code fence around it.
+func parseQuery(completion string) (Query, error) {
+	start := strings.Index(completion, "{")
+	end := strings.LastIndex(completion, "}")
+	if start < 0 || end < start {
+		return Query{}, fmt.Errorf("%w: no JSON object in %q", ErrInvalidResponse, completion)
+	}
+
+	var raw struct {
+		Query  string          `json:"query"`
+		Filter json.RawMessage `json:"filter"`
+	}
+	if err := json.Unmarshal([]byte(completion[start:end+1]), &raw); err != nil {
+		return Query{}, fmt.Errorf("%w: %w", ErrInvalidResponse, err)
+	}
+	query := Query{Query: raw.Query}
+	if len(raw.Filter) == 0 || string(raw.Filter) == "null" {
+		return query, nil
+	}
+	e, err := filter.Parse(raw.Filter)
+	if err != nil {
+		return Query{}, fmt.Errorf("%w: %w", ErrInvalidResponse, err)
+	}
+	query.Filter = &e
+	return query, nil
+}
@@ -0,0 +1,110 @@
+package selfquery_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/retrievers/selfquery"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/vectorstores"
+	"github.com/tmc/langchaingo/vectorstores/filter"
+)
+
+// staticModel always answers with response and records its prompts.
+type staticModel struct {
+	response string
+	prompts  []string
+}
+
+func (m *staticModel) GenerateContent(_ context.Context, messages []llms.MessageContent, _ ...llms.CallOption) (*llms.ContentResponse, error) { //nolint:lll
+	m.prompts = append(m.prompts, messages[0].Parts[0].(llms.TextContent).Text)
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: m.response}}}, nil
+}
+
+func (m *staticModel) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+// recordingStore records the searches made on it.
+type recordingStore struct {
+	query   string
+	options vectorstores.Options
+}
+
+func (s *recordingStore) AddDocuments(context.Context, []schema.Document, ...vectorstores.Option) ([]string, error) {
+	return nil, nil
+}
+
+func (s *recordingStore) SimilaritySearch(
+	_ context.Context, query string, numDocuments int, options ...vectorstores.Option,
+) ([]schema.Document, error) {
+	s.query = query
+	s.options = vectorstores.Options{}
+	for _, opt := range options {
+		opt(&s.options)
+	}
+	return make([]schema.Document, numDocuments), nil
+}
+
+var attributes = []selfquery.AttributeInfo{
+	{Name: "kind", Type: "string", Description: "the kind of document, manual or report"},
+	{Name: "year", Type: "integer", Description: "the year the document was published"},
+}
+
+func TestGetRelevantDocuments(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	model := &staticModel{response: "```json\n" + `{"query": "pumps", "filter": {"op": "and", "operands": [
+		{"op": "eq", "key": "kind", "value": "manual"},
+		{"op": "gt", "key": "year", "value": 2020}
+	]}}` + "\n```"}
+	store := &recordingStore{}
+	r := selfquery.New(model, store, "technical documentation", attributes,
+		selfquery.WithNumDocuments(2),
+		selfquery.WithVectorStoreOptions(vectorstores.WithNameSpace("docs")),
+	)
+
+	docs, err := r.GetRelevantDocuments(ctx, "manuals about pumps published after 2020")
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	require.Equal(t, "pumps", store.query)
+	require.Equal(t, "docs", store.options.NameSpace)
+	require.Equal(t, filter.And(filter.Eq("kind", "manual"), filter.Gt("year", 2020.0)), store.options.Filters)
+
+	require.Len(t, model.prompts, 1)
+	require.Contains(t, model.prompts[0], "The documents contain: technical documentation")
+	require.Contains(t, model.prompts[0], "- year (integer): the year the document was published")
+	require.Contains(t, model.prompts[0], "Question: manuals about pumps published after 2020")
+}
+
+func TestGetRelevantDocumentsWithoutFilter(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	store := &recordingStore{}
+	r := selfquery.New(&staticModel{response: `{"query": "", "filter": null}`}, store, "technical documentation", attributes)
+
+	docs, err := r.GetRelevantDocuments(ctx, "pumps")
+	require.NoError(t, err)
+	require.Len(t, docs, selfquery.DefaultNumDocuments)
+	require.Equal(t, "pumps", store.query)
+	require.Nil(t, store.options.Filters)
+}
+
+func TestStructureQueryErrors(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	for response, wantErr := range map[string]error{
+		`no filter`: selfquery.ErrInvalidResponse,
+		`{"query": "pumps", "filter": {"op": "like", "key": "kind", "value": "m%"}}`:  selfquery.ErrInvalidResponse,
+		`{"query": "pumps", "filter": {"op": "eq", "key": "author", "value": "ann"}}`: selfquery.ErrUnknownAttribute,
+	} {
+		r := selfquery.New(&staticModel{response: response}, &recordingStore{}, "technical documentation", attributes)
+		_, err := r.StructureQuery(ctx, "pumps")
+		require.ErrorIs(t, err, wantErr, response)
+	}
+}
@@ -6,3 +6,13 @@ type Document struct {
 	Metadata    map[string]any
 	Score       float32
 }
+
+// DocumentIterator iterates over documents produced one at a time, such as by
+// a lazy document loader, so that they don't all have to be held in memory.
+type DocumentIterator interface {
+	// Next returns the next document, or io.EOF when there are no more.
+	Next() (Document, error)
+	// Close releases the resources of the iterator. Next returns io.EOF
+	// after Close.
+	Close() error
+}
@@ -0,0 +1,369 @@
+package textsplitter
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Metadata keys set by CodeSplitter, in addition to MetadataChunkIndex,
+// MetadataStartIndex and MetadataEndIndex.
+const (
+	// MetadataLanguage is the language of the code.
+	MetadataLanguage = "language"
+	// MetadataStartLine is the line, counted from 1, the chunk starts on.
+	MetadataStartLine = "start_line"
+	// MetadataEndLine is the line the chunk ends on, included.
+	MetadataEndLine = "end_line"
+	// MetadataSymbols is the comma separated names of the symbols defined in
+	// the chunk, if any.
+	MetadataSymbols = "symbols"
+)
+
+// CodeSplitter is a text splitter for source code, splitting on the syntactic
+// boundaries of its language, such as functions, types and classes, before
+// blank lines, lines and words.
+//
+// Go code is split on the declarations found by go/parser, and the symbols of
+// a chunk are the declarations it overlaps, with methods named "Type.Method".
+// Go code that doesn't parse, and code of the other languages, is split with
+// the separators of SeparatorsForLanguage, and the symbols of a chunk are the
+// functions, types and classes whose definition starts in it.
+type CodeSplitter struct {
+	Language     Language
+	ChunkSize    int
+	ChunkOverlap int
+	LenFunc      func(string) int
+}
+
+var _ ChunkSplitter = CodeSplitter{}
+
+// NewCodeSplitter creates a new code splitter for a language. The chunk size,
+// chunk overlap and length function can be set with options.
+func NewCodeSplitter(language Language, opts ...Option) CodeSplitter {
+	options := DefaultOptions()
+	for _, o := range opts {
+		o(&options)
+	}
+
+	return CodeSplitter{
+		Language:     language,
+		ChunkSize:    options.ChunkSize,
+		ChunkOverlap: options.ChunkOverlap,
+		LenFunc:      options.LenFunc,
+	}
+}
+
+// SplitText splits code into multiple text.
+func (s CodeSplitter) SplitText(text string) ([]string, error) {
+	chunks, err := s.SplitTextChunks(text)
+	if err != nil {
+		return nil, err
+	}
+
+	texts := make([]string, len(chunks))
+	for i, chunk := range chunks {
+		texts[i] = chunk.Text
+	}
+	return texts, nil
+}
+
+// SplitTextChunks splits code like SplitText, and describes each chunk with
+// its language, index, rune offsets, lines and symbols.
+func (s CodeSplitter) SplitTextChunks(text string) ([]Chunk, error) {
+	separators, err := SeparatorsForLanguage(s.Language)
+	if err != nil {
+		return nil, err
+	}
+
+	var spans []span
+	symbols, ok := s.goSymbols(text)
+	if ok {
+		// Declarations too long are split on their statements.
+		spans = s.mergeParts(text, goUnits(text, symbols), func(unit span) []span {
+			return s.splitSpan(text, unit, _goDeclSeparators)
+		})
+	} else {
+		symbols = s.definedSymbols(text)
+		spans = s.splitSpan(text, span{0, len(text)}, separators)
+	}
+
+	lines := lineOffsets(text)
+	starts, ends := runeOffsets{text: text}, runeOffsets{text: text}
+	chunks := make([]Chunk, len(spans))
+	for i, sp := range spans {
+		metadata := map[string]any{
+			MetadataLanguage:   string(s.Language),
+			MetadataChunkIndex: i,
+			MetadataStartIndex: starts.of(sp.start),
+			MetadataEndIndex:   ends.of(sp.end),
+			MetadataStartLine:  lineOf(lines, sp.start),
+			MetadataEndLine:    lineOf(lines, sp.end-1),
+		}
+		if names := symbolsIn(symbols, sp); len(names) > 0 {
+			metadata[MetadataSymbols] = strings.Join(names, ",")
+		}
+		chunks[i] = Chunk{Text: text[sp.start:sp.end], Metadata: metadata}
+	}
+	return chunks, nil
+}
+
+// _goDeclSeparators are the separators of the statements of a Go declaration.
+var _goDeclSeparators = []string{ //nolint:gochecknoglobals
+	"\n\tif ", "\n\tfor ", "\n\tswitch ", "\n\tselect ", "\n\tdefer ", "\n\treturn ",
+	"\n\n", "\n", " ", "",
+}
+
+// span is the byte range [start, end) of a text.
+type span struct {
+	start, end int
+}
+
+// symbol is a symbol defined in the span of a text.
+type symbol struct {
+	name string
+	span
+}
+
+// goSymbols returns the declarations of Go code, or false if it isn't Go code
+// that parses.
+func (s CodeSplitter) goSymbols(text string) ([]symbol, bool) {
+	if s.Language != LanguageGo {
+		return nil, false
+	}
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", text, parser.ParseComments)
+	if err != nil {
+		return nil, false
+	}
+
+	offset := func(pos token.Pos) int { return fset.Position(pos).Offset }
+	symbols := make([]symbol, 0, len(file.Decls))
+	for _, decl := range file.Decls {
+		start, end := offset(decl.Pos()), offset(decl.End())
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Doc != nil {
+				start = offset(d.Doc.Pos())
+			}
+			symbols = append(symbols, symbol{name: goFuncName(d), span: span{start, end}})
+		case *ast.GenDecl:
+			if d.Doc != nil {
+				start = offset(d.Doc.Pos())
+			}
+			names := goSpecNames(d)
+			if len(names) == 0 {
+				// Imports have no symbol but still delimit the code.
+				symbols = append(symbols, symbol{span: span{start, end}})
+			}
+			for _, name := range names {
+				symbols = append(symbols, symbol{name: name, span: span{start, end}})
+			}
+		}
+	}
+	return symbols, true
+}
+
+func goFuncName(d *ast.FuncDecl) string {
+	if d.Recv == nil || len(d.Recv.List) == 0 {
+		return d.Name.Name
+	}
+
+	recv := d.Recv.List[0].Type
+	for {
+		switch t := recv.(type) {
+		case *ast.StarExpr:
+			recv = t.X
+			continue
+		case *ast.IndexExpr:
+			recv = t.X
+			continue
+		case *ast.IndexListExpr:
+			recv = t.X
+			continue
+		case *ast.Ident:
+			return t.Name + "." + d.Name.Name
+		}
+		return d.Name.Name
+	}
+}
+
+func goSpecNames(d *ast.GenDecl) []string {
+	var names []string
+	for _, spec := range d.Specs {
+		switch sp := spec.(type) {
+		case *ast.TypeSpec:
+			names = append(names, sp.Name.Name)
+		case *ast.ValueSpec:
+			for _, name := range sp.Names {
+				if name.Name != "_" {
+					names = append(names, name.Name)
+				}
+			}
+		}
+	}
+	return names
+}
+
+// goUnits partitions Go code into the spans of its declarations, each
+// starting at the beginning of the line of its declaration or doc comment.
+// Code before the first declaration, such as the package clause, is a span
+// of its own.
+func goUnits(text string, symbols []symbol) []span {
+	var units []span
+	start := 0
+	for _, sym := range symbols {
+		next := strings.LastIndexByte(text[:sym.start], '\n') + 1
+		if next > start {
+			units = append(units, span{start, next})
+			start = next
+		}
+	}
+	return append(units, span{start, len(text)})
+}
+
+// definedSymbols returns the symbols defined on the lines of code, spanning
+// the line of their definition.
+func (s CodeSplitter) definedSymbols(text string) []symbol {
+	var symbols []symbol
+	start := 0
+	for start < len(text) {
+		end := strings.IndexByte(text[start:], '\n')
+		if end < 0 {
+			end = len(text)
+		} else {
+			end += start
+		}
+		if name := definedSymbol(s.Language, text[start:end]); name != "" {
+			symbols = append(symbols, symbol{name: name, span: span{start, end}})
+		}
+		start = end + 1
+	}
+	return symbols
+}
+
+// symbolsIn returns the names of the symbols overlapping sp, without
+// duplicates.
+func symbolsIn(symbols []symbol, sp span) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, sym := range symbols {
+		if sym.name == "" || seen[sym.name] || sym.end <= sp.start || sym.start >= sp.end {
+			continue
+		}
+		seen[sym.name] = true
+		names = append(names, sym.name)
+	}
+	return names
+}
+
+// splitSpan splits a span of text into chunks no longer than the chunk size,
+// when possible, on the first separator found in it. Separators are kept at
+// the start of the part following them. Parts that fit are merged, and the
+// others are split recursively on the next separators.
+func (s CodeSplitter) splitSpan(text string, sp span, separators []string) []span {
+	if s.LenFunc(text[sp.start:sp.end]) <= s.ChunkSize || len(separators) == 0 {
+		return s.mergeSpans(text, []span{sp})
+	}
+
+	separator, rest := separators[len(separators)-1], []string(nil)
+	for i, sep := range separators {
+		if sep == "" || strings.Contains(text[sp.start:sp.end], sep) {
+			separator, rest = sep, separators[i+1:]
+			break
+		}
+	}
+
+	var parts []span
+	if separator == "" {
+		for i := sp.start; i < sp.end; {
+			_, size := utf8.DecodeRuneInString(text[i:sp.end])
+			parts = append(parts, span{i, i + size})
+			i += size
+		}
+	} else {
+		start := sp.start
+		for {
+			i := strings.Index(text[start+1:sp.end], separator)
+			if i < 0 {
+				break
+			}
+			parts = append(parts, span{start, start + 1 + i})
+			start += 1 + i
+		}
+		parts = append(parts, span{start, sp.end})
+	}
+
+	return s.mergeParts(text, parts, func(part span) []span {
+		return s.splitSpan(text, part, rest)
+	})
+}
+
+// mergeParts merges the consecutive parts that fit in the chunk size, and
+// splits the others with split.
+func (s CodeSplitter) mergeParts(text string, parts []span, split func(span) []span) []span {
+	var chunks, fitting []span
+	for _, part := range parts {
+		if s.LenFunc(text[part.start:part.end]) <= s.ChunkSize {
+			fitting = append(fitting, part)
+			continue
+		}
+		chunks = append(chunks, s.mergeSpans(text, fitting)...)
+		chunks = append(chunks, split(part)...)
+		fitting = nil
+	}
+	return append(chunks, s.mergeSpans(text, fitting)...)
+}
+
+// mergeSpans merges consecutive spans into chunks up to the chunk size, each
+// starting with the end of the previous chunk up to the chunk overlap, and
+// trims them of surrounding white space.
+func (s CodeSplitter) mergeSpans(text string, pieces []span) []span {
+	var chunks []span
+	add := func(start, end int) {
+		for start < end {
+			r, size := utf8.DecodeRuneInString(text[start:end])
+			if !unicode.IsSpace(r) {
+				break
+			}
+			start += size
+		}
+		for end > start {
+			r, size := utf8.DecodeLastRuneInString(text[start:end])
+			if !unicode.IsSpace(r) {
+				break
+			}
+			end -= size
+		}
+		if end > start {
+			chunks = append(chunks, span{start, end})
+		}
+	}
+
+	var current []span
+	for _, piece := range pieces {
+		if len(current) > 0 && s.LenFunc(text[current[0].start:piece.end]) > s.ChunkSize {
+			end := current[len(current)-1].end
+			add(current[0].start, end)
+			for len(current) > 0 && (s.LenFunc(text[current[0].start:end]) > s.ChunkOverlap ||
+				s.LenFunc(text[current[0].start:piece.end]) > s.ChunkSize) {
+				current = current[1:]
+			}
+		}
+		current = append(current, piece)
+	}
+	if len(current) > 0 {
+		add(current[0].start, current[len(current)-1].end)
+	}
+	return chunks
+}
+
+// lineOf returns the line, counted from 1, of a byte offset of a text given
+// the offsets of its lines.
+func lineOf(lines []int, offset int) int {
+	return sort.Search(len(lines), func(i int) bool { return lines[i] > offset })
+}
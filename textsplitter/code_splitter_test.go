@@ -0,0 +1,192 @@
+package textsplitter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/schema"
+)
+
+const _goSource = `// Package greet greets.
+package greet
+
+import "fmt"
+
+// Greeter greets.
+type Greeter struct {
+	Name string
+}
+
+// Greet says hello.
+func (g *Greeter) Greet() string {
+	return fmt.Sprintf("hello %s", g.Name)
+}
+
+const (
+	A = 1
+	B = 2
+)
+
+func count() int {
+	x := 0
+	for i := 0; i < 10; i++ {
+		x += i
+	}
+	return x
+}
+`
+
+// requireChunksMatchSource checks that chunks are the text between their
+// offsets, on the lines of their metadata.
+func requireChunksMatchSource(t *testing.T, text string, chunks []Chunk) {
+	t.Helper()
+	runes := []rune(text)
+	for i, chunk := range chunks {
+		start := chunk.Metadata[MetadataStartIndex].(int)
+		end := chunk.Metadata[MetadataEndIndex].(int)
+		require.Equal(t, chunk.Text, string(runes[start:end]))
+		require.Equal(t, i, chunk.Metadata[MetadataChunkIndex])
+		require.Equal(t, strings.Count(string(runes[:start]), "\n")+1, chunk.Metadata[MetadataStartLine])
+		require.Equal(t, strings.Count(string(runes[:end]), "\n")+1, chunk.Metadata[MetadataEndLine])
+	}
+}
+
+func TestCodeSplitterGo(t *testing.T) {
+	t.Parallel()
+
+	splitter := NewCodeSplitter(LanguageGo, WithChunkSize(100), WithChunkOverlap(0))
+	chunks, err := splitter.SplitTextChunks(_goSource)
+	require.NoError(t, err)
+	requireChunksMatchSource(t, _goSource, chunks)
+
+	symbols := make([]any, len(chunks))
+	for i, chunk := range chunks {
+		symbols[i] = chunk.Metadata[MetadataSymbols]
+		require.Equal(t, "go", chunk.Metadata[MetadataLanguage])
+	}
+	require.Equal(t, []any{nil, "Greeter", "Greeter.Greet", "A,B", "count"}, symbols)
+	require.Equal(t, "// Greet says hello.\nfunc (g *Greeter) Greet() string {\n"+
+		"\treturn fmt.Sprintf(\"hello %s\", g.Name)\n}", chunks[2].Text)
+	require.Equal(t, 11, chunks[2].Metadata[MetadataStartLine])
+	require.Equal(t, 14, chunks[2].Metadata[MetadataEndLine])
+
+	// A declaration longer than the chunk size is split on statements, and
+	// each part keeps its symbol.
+	splitter.ChunkSize = 40
+	chunks, err = splitter.SplitTextChunks(_goSource)
+	require.NoError(t, err)
+	requireChunksMatchSource(t, _goSource, chunks)
+	last := chunks[len(chunks)-1]
+	require.Equal(t, "return x\n}", last.Text)
+	require.Equal(t, "count", last.Metadata[MetadataSymbols])
+}
+
+func TestCodeSplitterInvalidGo(t *testing.T) {
+	t.Parallel()
+
+	// Code that doesn't parse is split with the separators of the language.
+	text := "func a() {\n\treturn\n}\n\nfunc b() {\n\treturn\n"
+	chunks, err := NewCodeSplitter(LanguageGo, WithChunkSize(20), WithChunkOverlap(0)).SplitTextChunks(text)
+	require.NoError(t, err)
+	requireChunksMatchSource(t, text, chunks)
+	require.Len(t, chunks, 2)
+	require.Equal(t, "func b() {\n\treturn", chunks[1].Text)
+	require.Equal(t, "b", chunks[1].Metadata[MetadataSymbols])
+}
+
+func TestCodeSplitterLanguages(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		language  Language
+		chunkSize int
+		text      string
+		symbols   []any
+	}{
+		{
+			language:  LanguagePython,
+			chunkSize: 40,
+			text: "import os\n\nclass Foo:\n    def bar(self):\n        return 1\n\n" +
+				"    async def baz(self):\n        return 2\n\n\ndef main():\n    print(Foo().bar())\n",
+			// The class is too long to merge with the import, and the body of baz
+			// is split from it, not being split on a definition.
+			symbols: []any{nil, "Foo", "bar", "baz", nil, "main"},
+		},
+		{
+			language:  LanguageJavaScript,
+			chunkSize: 40,
+			text: "const a = 1;\n\nfunction add(x, y) {\n  return x + y;\n}\n\n" +
+				"export const mul = (x, y) => {\n  return x * y;\n};\n\nclass Calc {\n  run() {}\n}\n",
+			symbols: []any{nil, "add", "mul", nil, "Calc"},
+		},
+		{
+			language:  LanguageJava,
+			chunkSize: 60,
+			text: "class Main {\n}\n\npublic static void main(String[] args) {\n  if (args.length > 0) {\n" +
+				"    return helper(args);\n  }\n}\n",
+			// The call to helper is not a definition.
+			symbols: []any{"Main", "main", nil},
+		},
+	}
+
+	for _, tc := range testCases {
+		chunks, err := NewCodeSplitter(tc.language, WithChunkSize(tc.chunkSize), WithChunkOverlap(0)).SplitTextChunks(tc.text)
+		require.NoError(t, err)
+		requireChunksMatchSource(t, tc.text, chunks)
+
+		symbols := make([]any, len(chunks))
+		for i, chunk := range chunks {
+			symbols[i] = chunk.Metadata[MetadataSymbols]
+		}
+		require.Equal(t, tc.symbols, symbols, tc.language)
+	}
+}
+
+func TestCodeSplitterOverlap(t *testing.T) {
+	t.Parallel()
+
+	text := "a b c d e f g h i j"
+	texts, err := NewCodeSplitter(LanguagePython, WithChunkSize(7), WithChunkOverlap(3)).SplitText(text)
+	require.NoError(t, err)
+	// The separator starting a part counts in the chunk size.
+	require.Equal(t, []string{"a b c d", "d e f", "f g h", "h i j"}, texts)
+}
+
+func TestCodeSplitterDocuments(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewCodeSplitter("cobol").SplitText("IDENTIFICATION DIVISION.")
+	require.ErrorIs(t, err, ErrUnsupportedLanguage)
+
+	language, ok := LanguageFromExtension(".GO")
+	require.True(t, ok)
+	_, ok = LanguageFromExtension(".md")
+	require.False(t, ok)
+
+	docs, err := SplitDocuments(NewCodeSplitter(language, WithChunkSize(1000)), []schema.Document{{
+		PageContent: "package main\n\n// π ≈ 3.14\nfunc main() {}\n",
+		Metadata:    map[string]any{"source": "main.go"},
+	}})
+	require.NoError(t, err)
+	require.Equal(t, []schema.Document{{
+		PageContent: "package main\n\n// π ≈ 3.14\nfunc main() {}",
+		Metadata: map[string]any{
+			"source":           "main.go",
+			MetadataLanguage:   "go",
+			MetadataSymbols:    "main",
+			MetadataChunkIndex: 0,
+			MetadataStartIndex: 0,
+			MetadataEndIndex:   40,
+			MetadataStartLine:  1,
+			MetadataEndLine:    4,
+		},
+	}}, docs)
+
+	separators, err := SeparatorsForLanguage(LanguagePython)
+	require.NoError(t, err)
+	separators[0] = "changed"
+	separators, err = SeparatorsForLanguage(LanguagePython)
+	require.NoError(t, err)
+	require.Equal(t, "\nclass ", separators[0])
+}
@@ -9,6 +9,10 @@ The main components of this package are:
 - TextSplitter interface: a common interface for splitting texts into smaller chunks.
 - RecursiveCharacter: a text splitter that recursively splits texts by different characters (separators)
 combined with chunk size and overlap settings.
+- CodeSplitter: a text splitter for source code that splits on functions, types and classes, using go/parser
+for Go and separators per language otherwise, and describes chunks with their lines and symbols.
+- ChunkSplitter interface: an optional interface for splitters that describe their chunks in metadata,
+such as the markdown splitter with the headers of the section a chunk comes from.
 - Helper functions: utility functions for creating documents out of split texts and rejoining them if necessary.
 
 Using the TextSplitter interface, developers can implement custom
@@ -0,0 +1,194 @@
+package textsplitter
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// ErrUnsupportedLanguage is returned by the code splitter for a language it
+// has no separators for.
+var ErrUnsupportedLanguage = errors.New("unsupported language")
+
+// Language is a programming language known to the code splitter.
+type Language string
+
+// Languages known to the code splitter.
+const (
+	LanguageGo         Language = "go"
+	LanguagePython     Language = "python"
+	LanguageJavaScript Language = "js"
+	LanguageTypeScript Language = "ts"
+	LanguageJava       Language = "java"
+	LanguageKotlin     Language = "kotlin"
+	LanguageScala      Language = "scala"
+	LanguageC          Language = "c"
+	LanguageCpp        Language = "cpp"
+	LanguageCSharp     Language = "csharp"
+	LanguageRust       Language = "rust"
+	LanguageRuby       Language = "ruby"
+	LanguagePHP        Language = "php"
+	LanguageSwift      Language = "swift"
+)
+
+// languageSeparators are the separators of each language, from the most to the
+// least significant syntactic boundary, as in LangChain.
+var languageSeparators = map[Language][]string{ //nolint:gochecknoglobals
+	LanguageGo: {
+		"\nfunc ", "\nvar ", "\nconst ", "\ntype ",
+		"\nif ", "\nfor ", "\nswitch ", "\ncase ",
+		"\n\n", "\n", " ", "",
+	},
+	LanguagePython: {
+		"\nclass ", "\ndef ", "\n\tdef ", "\n    def ",
+		"\n\n", "\n", " ", "",
+	},
+	LanguageJavaScript: {
+		"\nfunction ", "\nconst ", "\nlet ", "\nvar ", "\nclass ",
+		"\nif ", "\nfor ", "\nwhile ", "\nswitch ", "\ncase ", "\ndefault ",
+		"\n\n", "\n", " ", "",
+	},
+	LanguageTypeScript: {
+		"\nenum ", "\ninterface ", "\nnamespace ", "\ntype ", "\nclass ",
+		"\nfunction ", "\nconst ", "\nlet ", "\nvar ",
+		"\nif ", "\nfor ", "\nwhile ", "\nswitch ", "\ncase ", "\ndefault ",
+		"\n\n", "\n", " ", "",
+	},
+	LanguageJava: {
+		"\nclass ", "\npublic ", "\nprotected ", "\nprivate ", "\nstatic ",
+		"\nif ", "\nfor ", "\nwhile ", "\nswitch ", "\ncase ",
+		"\n\n", "\n", " ", "",
+	},
+	LanguageKotlin: {
+		"\nclass ", "\npublic ", "\nprotected ", "\nprivate ", "\ninternal ", "\ncompanion ",
+		"\nfun ", "\nval ", "\nvar ",
+		"\nif ", "\nfor ", "\nwhile ", "\nwhen ", "\ncase ", "\nelse ",
+		"\n\n", "\n", " ", "",
+	},
+	LanguageScala: {
+		"\nclass ", "\nobject ", "\ndef ", "\nval ", "\nvar ",
+		"\nif ", "\nfor ", "\nwhile ", "\nmatch ", "\ncase ",
+		"\n\n", "\n", " ", "",
+	},
+	LanguageC: {
+		"\nstruct ", "\nvoid ", "\nint ", "\nfloat ", "\ndouble ",
+		"\nif ", "\nfor ", "\nwhile ", "\nswitch ", "\ncase ",
+		"\n\n", "\n", " ", "",
+	},
+	LanguageCpp: {
+		"\nclass ", "\nvoid ", "\nint ", "\nfloat ", "\ndouble ",
+		"\nif ", "\nfor ", "\nwhile ", "\nswitch ", "\ncase ",
+		"\n\n", "\n", " ", "",
+	},
+	LanguageCSharp: {
+		"\ninterface ", "\nenum ", "\nimplements ", "\ndelegate ", "\nevent ",
+		"\nclass ", "\nabstract ", "\npublic ", "\nprotected ", "\nprivate ", "\nstatic ",
+		"\nreturn ", "\nif ", "\ncontinue ", "\nfor ", "\nforeach ", "\nwhile ", "\nswitch ",
+		"\nbreak ", "\ncase ", "\nelse ", "\ntry ", "\nthrow ", "\nfinally ", "\ncatch ",
+		"\n\n", "\n", " ", "",
+	},
+	LanguageRust: {
+		"\nfn ", "\nconst ", "\nlet ",
+		"\nif ", "\nwhile ", "\nfor ", "\nloop ", "\nmatch ",
+		"\n\n", "\n", " ", "",
+	},
+	LanguageRuby: {
+		"\ndef ", "\nclass ",
+		"\nif ", "\nunless ", "\nwhile ", "\nfor ", "\ndo ", "\nbegin ", "\nrescue ",
+		"\n\n", "\n", " ", "",
+	},
+	LanguagePHP: {
+		"\nfunction ", "\nclass ",
+		"\nif ", "\nforeach ", "\nwhile ", "\ndo ", "\nswitch ", "\ncase ",
+		"\n\n", "\n", " ", "",
+	},
+	LanguageSwift: {
+		"\nfunc ", "\nclass ", "\nstruct ", "\nenum ",
+		"\nif ", "\nfor ", "\nwhile ", "\ndo ", "\nswitch ", "\ncase ",
+		"\n\n", "\n", " ", "",
+	},
+}
+
+// SeparatorsForLanguage returns the separators the code splitter uses for a
+// language, which can also be given to a RecursiveCharacter splitter with
+// WithSeparators and WithKeepSeparator.
+func SeparatorsForLanguage(language Language) ([]string, error) {
+	separators, ok := languageSeparators[language]
+	if !ok {
+		return nil, ErrUnsupportedLanguage
+	}
+	return append([]string(nil), separators...), nil
+}
+
+// languageExtensions are the file extensions of each language.
+var languageExtensions = map[string]Language{ //nolint:gochecknoglobals
+	".go":    LanguageGo,
+	".py":    LanguagePython,
+	".js":    LanguageJavaScript,
+	".jsx":   LanguageJavaScript,
+	".mjs":   LanguageJavaScript,
+	".cjs":   LanguageJavaScript,
+	".ts":    LanguageTypeScript,
+	".tsx":   LanguageTypeScript,
+	".java":  LanguageJava,
+	".kt":    LanguageKotlin,
+	".kts":   LanguageKotlin,
+	".scala": LanguageScala,
+	".c":     LanguageC,
+	".h":     LanguageC,
+	".cc":    LanguageCpp,
+	".cpp":   LanguageCpp,
+	".cxx":   LanguageCpp,
+	".hpp":   LanguageCpp,
+	".cs":    LanguageCSharp,
+	".rs":    LanguageRust,
+	".rb":    LanguageRuby,
+	".php":   LanguagePHP,
+	".swift": LanguageSwift,
+}
+
+// LanguageFromExtension returns the language of the files with a file
+// extension, such as ".go".
+func LanguageFromExtension(extension string) (Language, bool) {
+	language, ok := languageExtensions[strings.ToLower(extension)]
+	return language, ok
+}
+
+// Definition patterns capture the name of a symbol defined on a line.
+var (
+	_keywordDefinition = regexp.MustCompile( //nolint:gochecknoglobals
+		`^\s*(?:(?:export|default|public|private|protected|internal|static|final|abstract|sealed|open|data|` +
+			`override|virtual|partial|async|unsafe|extern|inline|pub(?:\([\w ]*\))?)\s+)*` +
+			`(?:class|struct|interface|enum|trait|object|module|impl|fn|def|func|fun|function\*?)\s+([A-Za-z_$][\w$]*)`)
+	_jsDefinition = regexp.MustCompile( //nolint:gochecknoglobals
+		`^\s*(?:export\s+)?(?:const|let|var)\s+([A-Za-z_$][\w$]*)\s*=\s*(?:async\s*)?(?:function\b|\([^)]*\)\s*=>|[A-Za-z_$][\w$]*\s*=>)`)
+	_cDefinition = regexp.MustCompile( //nolint:gochecknoglobals
+		`^\s*(?:[\w:<>\[\],*&]+\s+)+[*&]*([A-Za-z_]\w*)\s*\([^;]*$`)
+)
+
+// _notSymbols are keywords matched by the C-like definition pattern.
+var _notSymbols = map[string]bool{ //nolint:gochecknoglobals
+	"if": true, "for": true, "foreach": true, "while": true, "switch": true, "catch": true,
+	"return": true, "new": true, "else": true, "throw": true, "using": true, "lock": true,
+}
+
+// definedSymbol returns the name of the symbol defined on a line of code, or
+// an empty string.
+func definedSymbol(language Language, line string) string {
+	if m := _keywordDefinition.FindStringSubmatch(line); m != nil {
+		return m[1]
+	}
+
+	switch language { //nolint:exhaustive
+	case LanguageJavaScript, LanguageTypeScript:
+		if m := _jsDefinition.FindStringSubmatch(line); m != nil {
+			return m[1]
+		}
+	case LanguageJava, LanguageC, LanguageCpp, LanguageCSharp:
+		m := _cDefinition.FindStringSubmatch(line)
+		if m != nil && !_notSymbols[m[1]] && !_notSymbols[strings.Fields(line)[0]] {
+			return m[1]
+		}
+	}
+	return ""
+}
@@ -23,6 +23,7 @@ func NewMarkdownTextSplitter(opts ...Option) *MarkdownTextSplitter {
 		SecondSplitter: options.SecondSplitter,
 		CodeBlocks:     options.CodeBlocks,
 		ReferenceLinks: options.ReferenceLinks,
+		HeaderMetadata: options.HeaderMetadata,
 	}
 
 	if sp.SecondSplitter == nil {
@@ -40,7 +41,20 @@ func NewMarkdownTextSplitter(opts ...Option) *MarkdownTextSplitter {
 	return sp
 }
 
-var _ TextSplitter = (*MarkdownTextSplitter)(nil)
+var _ ChunkSplitter = (*MarkdownTextSplitter)(nil)
+
+// Metadata keys set by MarkdownTextSplitter.SplitTextChunks. The headers of
+// a chunk are set under "h1" to "h6", by level.
+const (
+	// MetadataChunkIndex is the index of the chunk in the text.
+	MetadataChunkIndex = "chunk_index"
+	// MetadataStartIndex is the offset in runes, not bytes, of the start of
+	// the text the chunk was split from.
+	MetadataStartIndex = "start_index"
+	// MetadataEndIndex is the offset in runes, not bytes, of the end of the
+	// text the chunk was split from.
+	MetadataEndIndex = "end_index"
+)
 
 // MarkdownTextSplitter markdown header text splitter.
 //
@@ -53,10 +67,47 @@ type MarkdownTextSplitter struct {
 	SecondSplitter TextSplitter
 	CodeBlocks     bool
 	ReferenceLinks bool
+	// HeaderMetadata makes SplitTextChunks describe the chunks in metadata
+	HeaderMetadata bool
 }
 
 // SplitText splits a text into multiple text.
 func (sp MarkdownTextSplitter) SplitText(text string) ([]string, error) {
+	mc := sp.split(text)
+	return mc.chunks, nil
+}
+
+// SplitTextChunks splits a text like SplitText. If HeaderMetadata is set, it
+// describes each chunk with the headers of the section it comes from, its
+// index and the rune offsets of the markdown it was split from. The offsets
+// don't include the header prepended to the chunk.
+func (sp MarkdownTextSplitter) SplitTextChunks(text string) ([]Chunk, error) {
+	mc := sp.split(text)
+
+	starts, ends := runeOffsets{text: text}, runeOffsets{text: text}
+	chunks := make([]Chunk, len(mc.chunks))
+	for i, chunk := range mc.chunks {
+		if !sp.HeaderMetadata {
+			chunks[i] = Chunk{Text: chunk}
+			continue
+		}
+		info := mc.chunkInfos[i]
+		metadata := map[string]any{
+			MetadataChunkIndex: i,
+			MetadataStartIndex: starts.of(info.start),
+			MetadataEndIndex:   ends.of(info.end),
+		}
+		for level, header := range info.headers {
+			if header != "" {
+				metadata[fmt.Sprintf("h%d", level+1)] = header
+			}
+		}
+		chunks[i] = Chunk{Text: chunk, Metadata: metadata}
+	}
+	return chunks, nil
+}
+
+func (sp MarkdownTextSplitter) split(text string) *markdownContext {
 	mdParser := markdown.New(markdown.XHTMLOutput(true))
 	tokens := mdParser.Parse([]byte(text))
 
@@ -69,11 +120,33 @@ func (sp MarkdownTextSplitter) SplitText(text string) ([]string, error) {
 		secondSplitter:   sp.SecondSplitter,
 		renderCodeBlocks: sp.CodeBlocks,
 		useInlineContent: !sp.ReferenceLinks,
+		text:             text,
+		lineOffsets:      lineOffsets(text),
 	}
+	mc.splitText()
 
-	chunks := mc.splitText()
+	return mc
+}
 
-	return chunks, nil
+// lineOffsets returns the byte offset of the start of each line of text,
+// followed by the length of text.
+func lineOffsets(text string) []int {
+	offsets := []int{0}
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\n' {
+			offsets = append(offsets, i+1)
+		}
+	}
+	if offsets[len(offsets)-1] != len(text) {
+		offsets = append(offsets, len(text))
+	}
+	return offsets
+}
+
+// chunkInfo describes where a chunk comes from.
+type chunkInfo struct {
+	start, end int
+	headers    [6]string
 }
 
 // markdownContext the helper.
@@ -118,6 +191,24 @@ type markdownContext struct {
 
 	// useInlineContent determines whether the default inline content is rendered
 	useInlineContent bool
+
+	// text represents the markdown source and lineOffsets the offsets of its
+	// lines; they are only set on the root context, which records where its
+	// chunks come from in chunkInfos
+	text        string
+	lineOffsets []int
+	chunkInfos  []chunkInfo
+
+	// blockLines represents the line range of the current top-level block,
+	// snippetLines the line range of curSnippet and headerLines the line
+	// range of the current header
+	blockLines      [2]int
+	snippetLines    [2]int
+	hasSnippetLines bool
+	headerLines     [2]int
+
+	// headers represents the current header of each level
+	headers [6]string
 }
 
 // splitText splits Markdown text.
@@ -126,6 +217,9 @@ type markdownContext struct {
 func (mc *markdownContext) splitText() []string {
 	for idx := mc.startAt; idx < mc.endAt; {
 		token := mc.tokens[idx]
+		if lines, ok := blockLines(token); ok {
+			mc.blockLines = lines
+		}
 		switch token.(type) {
 		case *markdown.HeadingOpen:
 			mc.onMDHeader()
@@ -202,6 +296,14 @@ func (mc *markdownContext) onMDHeader() {
 
 	mc.applyToChunks() // change header, apply to chunks
 
+	if level := header.HLevel - 1; level >= 0 && level < len(mc.headers) {
+		mc.headers[level] = inline.Content
+		for i := level + 1; i < len(mc.headers); i++ {
+			mc.headers[i] = ""
+		}
+	}
+	mc.headerLines = header.Map
+
 	hm := repeatString(header.HLevel, "#")
 	mc.hTitle = fmt.Sprintf("%s %s", hm, inline.Content)
 	mc.hTitlePrepended = false
@@ -569,6 +671,8 @@ func (mc *markdownContext) onMDHr() {
 
 // joinSnippet join sub snippet to current total snippet.
 func (mc *markdownContext) joinSnippet(snippet string) {
+	defer mc.extendSnippetLines()
+
 	if mc.curSnippet == "" {
 		mc.curSnippet = snippet
 		return
@@ -583,13 +687,26 @@ func (mc *markdownContext) joinSnippet(snippet string) {
 	}
 }
 
+// extendSnippetLines extends the line range of the current snippet with the
+// current block.
+func (mc *markdownContext) extendSnippetLines() {
+	if !mc.hasSnippetLines {
+		mc.snippetLines = mc.blockLines
+		mc.hasSnippetLines = true
+		return
+	}
+	mc.snippetLines[1] = max(mc.snippetLines[1], mc.blockLines[1])
+}
+
 // applyToChunks applies current snippet to chunks.
 func (mc *markdownContext) applyToChunks() {
 	defer func() {
 		mc.curSnippet = ""
+		mc.hasSnippetLines = false
 	}()
 
 	var chunks []string
+	resplit := false
 	if mc.curSnippet != "" {
 		// check whether current chunk is over ChunkSize，if so, re-split current chunk
 		if utf8.RuneCountInString(mc.curSnippet) <= mc.chunkSize+mc.chunkOverlap {
@@ -597,12 +714,14 @@ func (mc *markdownContext) applyToChunks() {
 		} else {
 			// split current snippet to chunks
 			chunks, _ = mc.secondSplitter.SplitText(mc.curSnippet)
+			resplit = true
 		}
 	}
 
 	// if there is only H1/H2 and so on, just apply the `Header Title` to chunks
 	if len(chunks) == 0 && mc.hTitle != "" && !mc.hTitlePrepended {
 		mc.chunks = append(mc.chunks, mc.hTitle)
+		mc.addChunkInfo(mc.headerLines, "")
 		mc.hTitlePrepended = true
 		return
 	}
@@ -612,6 +731,12 @@ func (mc *markdownContext) applyToChunks() {
 			continue
 		}
 
+		source := ""
+		if resplit {
+			source = chunk
+		}
+		mc.addChunkInfo(mc.snippetLines, source)
+
 		mc.hTitlePrepended = true
 		if mc.hTitle != "" && !strings.Contains(mc.curSnippet, mc.hTitle) {
 			// prepend `Header Title` to chunk
@@ -621,6 +746,41 @@ func (mc *markdownContext) applyToChunks() {
 	}
 }
 
+// addChunkInfo records where the next chunk comes from: the given line range
+// or, if source is found in it, the range of source.
+func (mc *markdownContext) addChunkInfo(lines [2]int, source string) {
+	if mc.lineOffsets == nil {
+		return
+	}
+
+	last := len(mc.lineOffsets) - 1
+	start := mc.lineOffsets[min(max(lines[0], 0), last)]
+	end := mc.lineOffsets[min(max(lines[1], 0), last)]
+	for end > start && (mc.text[end-1] == '\n' || mc.text[end-1] == '\r') {
+		end--
+	}
+
+	// Chunks re-split from a snippet are searched from the overlap with the
+	// previous one, if it comes from the same lines.
+	if source != "" {
+		from := start
+		if n := len(mc.chunkInfos); n > 0 && mc.chunkInfos[n-1].start >= start && mc.chunkInfos[n-1].end <= end {
+			prev := mc.chunkInfos[n-1]
+			from = prev.end
+			for i := 0; i < mc.chunkOverlap && from > prev.start+1; i++ {
+				_, size := utf8.DecodeLastRuneInString(mc.text[:from])
+				from -= size
+			}
+		}
+		if i := strings.Index(mc.text[from:end], source); i >= 0 {
+			start = from + i
+			end = start + len(source)
+		}
+	}
+
+	mc.chunkInfos = append(mc.chunkInfos, chunkInfo{start: start, end: end, headers: mc.headers})
+}
+
 // splitInline splits inline
 //
 // format: Link/Image/Text
@@ -714,6 +874,33 @@ func (mc *markdownContext) inlineOnImage(image *markdown.Image) string {
 	return fmt.Sprintf(`![%s](%s "%s")`, label, image.Src, image.Title)
 }
 
+// blockLines returns the line range of a block token.
+func blockLines(token markdown.Token) ([2]int, bool) {
+	switch t := token.(type) {
+	case *markdown.HeadingOpen:
+		return t.Map, true
+	case *markdown.TableOpen:
+		return t.Map, true
+	case *markdown.ParagraphOpen:
+		return t.Map, true
+	case *markdown.BlockquoteOpen:
+		return t.Map, true
+	case *markdown.BulletListOpen:
+		return t.Map, true
+	case *markdown.OrderedListOpen:
+		return t.Map, true
+	case *markdown.ListItemOpen:
+		return t.Map, true
+	case *markdown.CodeBlock:
+		return t.Map, true
+	case *markdown.Fence:
+		return t.Map, true
+	case *markdown.Hr:
+		return t.Map, true
+	}
+	return [2]int{}, false
+}
+
 // closeTypes represents the close operation type for each open operation type.
 var closeTypes = map[reflect.Type]reflect.Type{ //nolint:gochecknoglobals
 	reflect.TypeOf(&markdown.HeadingOpen{}):     reflect.TypeOf(&markdown.HeadingClose{}),
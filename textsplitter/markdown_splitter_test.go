@@ -2,6 +2,7 @@ package textsplitter
 
 import (
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -455,3 +456,117 @@ func TestMarkdownHeaderTextSplitter_SplitInline(t *testing.T) {
 		})
 	}
 }
+
+func TestMarkdownTextSplitter_SplitTextChunks(t *testing.T) {
+	t.Parallel()
+
+	text := `# Guide
+
+Intro paragraph.
+
+## Install
+
+Run the installer.
+
+### Linux
+
+Use the package manager.
+
+## Usage
+
+- first
+- second
+`
+	splitter := NewMarkdownTextSplitter(WithChunkSize(64), WithChunkOverlap(0), WithHeaderMetadata(true))
+	chunks, err := splitter.SplitTextChunks(text)
+	require.NoError(t, err)
+
+	texts, err := splitter.SplitText(text)
+	require.NoError(t, err)
+	require.Len(t, chunks, len(texts))
+
+	expected := []struct {
+		text     string
+		source   string
+		metadata map[string]any
+	}{
+		{"# Guide\nIntro paragraph.", "Intro paragraph.", map[string]any{"h1": "Guide"}},
+		{"## Install\nRun the installer.", "Run the installer.", map[string]any{"h1": "Guide", "h2": "Install"}},
+		{
+			"### Linux\nUse the package manager.", "Use the package manager.",
+			map[string]any{"h1": "Guide", "h2": "Install", "h3": "Linux"},
+		},
+		{"## Usage\n- first\n- second", "- first\n- second", map[string]any{"h1": "Guide", "h2": "Usage"}},
+	}
+	require.Len(t, chunks, len(expected))
+	for i, e := range expected {
+		require.Equal(t, e.text, chunks[i].Text)
+		require.Equal(t, texts[i], chunks[i].Text)
+		require.Equal(t, i, chunks[i].Metadata[MetadataChunkIndex])
+		start := chunks[i].Metadata[MetadataStartIndex].(int)
+		end := chunks[i].Metadata[MetadataEndIndex].(int)
+		require.Equal(t, e.source, string([]rune(text)[start:end]))
+		for key, value := range e.metadata {
+			require.Equal(t, value, chunks[i].Metadata[key], key)
+		}
+		require.Len(t, chunks[i].Metadata, 3+len(e.metadata))
+	}
+}
+
+func TestMarkdownTextSplitter_SplitTextChunksResplit(t *testing.T) {
+	t.Parallel()
+
+	text := "# Title\n\n" + strings.Repeat("word ", 30) + "\n"
+	splitter := NewMarkdownTextSplitter(WithChunkSize(40), WithChunkOverlap(0), WithHeaderMetadata(true))
+	chunks, err := splitter.SplitTextChunks(text)
+	require.NoError(t, err)
+	require.Greater(t, len(chunks), 1)
+
+	prevEnd := 0
+	for _, chunk := range chunks {
+		start := chunk.Metadata[MetadataStartIndex].(int)
+		end := chunk.Metadata[MetadataEndIndex].(int)
+		require.GreaterOrEqual(t, start, prevEnd)
+		require.Equal(t, "# Title\n"+string([]rune(text)[start:end]), chunk.Text)
+		require.Equal(t, "Title", chunk.Metadata["h1"])
+		prevEnd = end
+	}
+}
+
+func TestMarkdownTextSplitter_SplitTextChunksRuneOffsets(t *testing.T) {
+	t.Parallel()
+
+	text := "# Café\n\nCrème brûlée.\n\n# 日本\n\n寿司と天ぷら。\n"
+	splitter := NewMarkdownTextSplitter(WithChunkSize(64), WithChunkOverlap(0), WithHeaderMetadata(true))
+	chunks, err := splitter.SplitTextChunks(text)
+	require.NoError(t, err)
+	require.Len(t, chunks, 2)
+	require.Equal(t, 8, chunks[0].Metadata[MetadataStartIndex])
+	require.Equal(t, 21, chunks[0].Metadata[MetadataEndIndex])
+	require.Equal(t, 29, chunks[1].Metadata[MetadataStartIndex])
+	require.Equal(t, 36, chunks[1].Metadata[MetadataEndIndex])
+	for _, chunk := range chunks {
+		start := chunk.Metadata[MetadataStartIndex].(int)
+		end := chunk.Metadata[MetadataEndIndex].(int)
+		require.True(t, strings.HasSuffix(chunk.Text, string([]rune(text)[start:end])))
+	}
+}
+
+func TestCreateDocumentsWithChunkMetadata(t *testing.T) {
+	t.Parallel()
+
+	splitter := NewMarkdownTextSplitter(WithChunkSize(64), WithChunkOverlap(0), WithHeaderMetadata(true))
+	docs, err := SplitDocuments(splitter, []schema.Document{{
+		PageContent: "# A\n\none\n\n# B\n\ntwo\n",
+		Metadata:    map[string]any{"source": "doc.md", MetadataChunkIndex: "overridden"},
+	}})
+	require.NoError(t, err)
+	require.Equal(t, []schema.Document{
+		{PageContent: "# A\none", Metadata: map[string]any{
+			"source": "doc.md", "h1": "A", MetadataChunkIndex: 0, MetadataStartIndex: 5, MetadataEndIndex: 8,
+		}},
+		{PageContent: "# B\ntwo", Metadata: map[string]any{
+			"source": "doc.md", "h1": "B", MetadataChunkIndex: 1, MetadataStartIndex: 15, MetadataEndIndex: 18,
+		}},
+	}, docs)
+}
@@ -16,6 +16,7 @@ type Options struct {
 	SecondSplitter    TextSplitter
 	CodeBlocks        bool
 	ReferenceLinks    bool
+	HeaderMetadata    bool
 }
 
 // DefaultOptions returns the default options for all text splitter.
@@ -121,6 +122,16 @@ func WithReferenceLinks(referenceLinks bool) Option {
 	}
 }
 
+// WithHeaderMetadata sets whether the markdown splitter describes each chunk
+// in the metadata of its document with the headers of the section it comes
+// from, under "h1" to "h6", its index and the offsets of the markdown it was
+// split from.
+func WithHeaderMetadata(headerMetadata bool) Option {
+	return func(o *Options) {
+		o.HeaderMetadata = headerMetadata
+	}
+}
+
 // WithKeepSeparator sets whether the separators should be kept in the resulting
 // split text or not. When it is set to True, the separators are included in the
 // resulting split text. When it is set to False, the separators are not included
@@ -25,9 +25,44 @@ func SplitDocuments(textSplitter TextSplitter, documents []schema.Document) ([]s
 	return CreateDocuments(textSplitter, texts, metadatas)
 }
 
+// SplitDocumentsLazy returns an iterator over the chunks of the documents of
+// it, reading a document from it only when the chunks of the previous one
+// have been consumed. Closing the returned iterator closes it.
+func SplitDocumentsLazy(textSplitter TextSplitter, it schema.DocumentIterator) schema.DocumentIterator {
+	return &splitIterator{textSplitter: textSplitter, source: it}
+}
+
+type splitIterator struct {
+	textSplitter TextSplitter
+	source       schema.DocumentIterator
+	chunks       []schema.Document
+}
+
+func (s *splitIterator) Next() (schema.Document, error) {
+	for len(s.chunks) == 0 {
+		doc, err := s.source.Next()
+		if err != nil {
+			return schema.Document{}, err
+		}
+		if s.chunks, err = SplitDocuments(s.textSplitter, []schema.Document{doc}); err != nil {
+			return schema.Document{}, err
+		}
+	}
+	chunk := s.chunks[0]
+	s.chunks = s.chunks[1:]
+	return chunk, nil
+}
+
+func (s *splitIterator) Close() error {
+	s.chunks = nil
+	return s.source.Close()
+}
+
 // CreateDocuments creates documents from texts and metadatas with a text splitter. If
 // the length of the metadatas is zero, the result documents will contain no metadata.
-// Otherwise, the numbers of texts and metadatas must match.
+// Otherwise, the numbers of texts and metadatas must match. If the text splitter is a
+// ChunkSplitter, the metadata of each chunk is added to the metadata of its document,
+// taking precedence over the given metadata.
 func CreateDocuments(textSplitter TextSplitter, texts []string, metadatas []map[string]any) ([]schema.Document, error) {
 	if len(metadatas) == 0 {
 		metadatas = make([]map[string]any, len(texts))
@@ -40,20 +75,23 @@ func CreateDocuments(textSplitter TextSplitter, texts []string, metadatas []map[
 	documents := make([]schema.Document, 0)
 
 	for i := 0; i < len(texts); i++ {
-		chunks, err := textSplitter.SplitText(texts[i])
+		chunks, err := splitChunks(textSplitter, texts[i])
 		if err != nil {
 			return nil, err
 		}
 
 		for _, chunk := range chunks {
 			// Copy the document metadata
-			curMetadata := make(map[string]any, len(metadatas[i]))
+			curMetadata := make(map[string]any, len(metadatas[i])+len(chunk.Metadata))
 			for key, value := range metadatas[i] {
 				curMetadata[key] = value
 			}
+			for key, value := range chunk.Metadata {
+				curMetadata[key] = value
+			}
 
 			documents = append(documents, schema.Document{
-				PageContent: chunk,
+				PageContent: chunk.Text,
 				Metadata:    curMetadata,
 			})
 		}
@@ -1,6 +1,58 @@
 package textsplitter
 
+import "unicode/utf8"
+
 // TextSplitter is the standard interface for splitting texts.
 type TextSplitter interface {
 	SplitText(text string) ([]string, error)
 }
+
+// Chunk is a piece of a text split by a text splitter, with metadata about
+// where it comes from.
+type Chunk struct {
+	Text     string
+	Metadata map[string]any
+}
+
+// ChunkSplitter is a TextSplitter that also describes the chunks it splits a
+// text into. CreateDocuments and SplitDocuments add the metadata of the chunks
+// to the metadata of the documents they create.
+type ChunkSplitter interface {
+	TextSplitter
+	SplitTextChunks(text string) ([]Chunk, error)
+}
+
+// splitChunks splits text into chunks with the text splitter, with metadata
+// if it is a ChunkSplitter.
+func splitChunks(textSplitter TextSplitter, text string) ([]Chunk, error) {
+	if cs, ok := textSplitter.(ChunkSplitter); ok {
+		return cs.SplitTextChunks(text)
+	}
+
+	texts, err := textSplitter.SplitText(text)
+	if err != nil {
+		return nil, err
+	}
+	chunks := make([]Chunk, len(texts))
+	for i, t := range texts {
+		chunks[i] = Chunk{Text: t}
+	}
+	return chunks, nil
+}
+
+// runeOffsets converts byte offsets in a text to rune offsets. Converting
+// increasing offsets only counts the runes since the previous one.
+type runeOffsets struct {
+	text  string
+	bytes int
+	runes int
+}
+
+func (o *runeOffsets) of(offset int) int {
+	if offset < o.bytes {
+		o.bytes, o.runes = 0, 0
+	}
+	o.runes += utf8.RuneCountInString(o.text[o.bytes:offset])
+	o.bytes = offset
+	return o.runes
+}
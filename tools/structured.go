@@ -0,0 +1,91 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/tmc/langchaingo/jsonschema"
+)
+
+// ErrInvalidArguments is returned by structured tools when their input is not a JSON object
+// matching their parameters.
+var ErrInvalidArguments = errors.New("invalid tool arguments")
+
+// StructuredTool is a tool whose input is a JSON object described by a JSON schema. Agents that
+// support function calling give the schema to the model and pass the arguments the model
+// generates to Call as a JSON encoded object.
+type StructuredTool interface {
+	Tool
+	// Parameters returns the JSON schema of the arguments of the tool.
+	Parameters() jsonschema.Definition
+}
+
+// Func is a StructuredTool that decodes its arguments into a value of type T before calling
+// a function. Create one with NewFunc.
+type Func[T any] struct {
+	name        string
+	description string
+	parameters  jsonschema.Definition
+	fn          func(ctx context.Context, args T) (string, error)
+}
+
+var _ StructuredTool = Func[struct{}]{}
+
+// NewFunc creates a structured tool calling fn. The parameters of the tool are derived from T
+// with jsonschema.Reflect, so T is usually a struct with json and description tags.
+func NewFunc[T any](
+	name, description string,
+	fn func(ctx context.Context, args T) (string, error),
+) (Func[T], error) {
+	var zero T
+	parameters, err := jsonschema.Reflect(zero)
+	if err != nil {
+		return Func[T]{}, err
+	}
+	if parameters.Type != jsonschema.Object {
+		return Func[T]{}, fmt.Errorf("%w: arguments of %s must be an object, got %T",
+			jsonschema.ErrUnsupportedType, name, zero)
+	}
+	return Func[T]{
+		name:        name,
+		description: description,
+		parameters:  parameters,
+		fn:          fn,
+	}, nil
+}
+
+// Name returns the name of the tool.
+func (f Func[T]) Name() string {
+	return f.name
+}
+
+// Description returns the description of the tool.
+func (f Func[T]) Description() string {
+	return f.description
+}
+
+// Parameters returns the JSON schema of T.
+func (f Func[T]) Parameters() jsonschema.Definition {
+	return f.parameters
+}
+
+// Call decodes the JSON object input into a T and calls the function of the tool with it.
+func (f Func[T]) Call(ctx context.Context, input string) (string, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(input), &fields); err != nil {
+		return "", fmt.Errorf("%w for %s: %w", ErrInvalidArguments, f.name, err)
+	}
+	for _, name := range f.parameters.Required {
+		if _, ok := fields[name]; !ok {
+			return "", fmt.Errorf("%w for %s: missing required argument %q", ErrInvalidArguments, f.name, name)
+		}
+	}
+
+	var args T
+	if err := json.Unmarshal([]byte(input), &args); err != nil {
+		return "", fmt.Errorf("%w for %s: %w", ErrInvalidArguments, f.name, err)
+	}
+	return f.fn(ctx, args)
+}
@@ -0,0 +1,46 @@
+package tools_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/jsonschema"
+	"github.com/tmc/langchaingo/tools"
+)
+
+type weatherArgs struct {
+	City string `json:"city" description:"the city to get the weather for"`
+	Unit string `json:"unit,omitempty" enum:"celsius,fahrenheit"`
+}
+
+func TestFunc(t *testing.T) {
+	t.Parallel()
+
+	tool, err := tools.NewFunc("weather", "gets the weather", func(_ context.Context, args weatherArgs) (string, error) {
+		return fmt.Sprintf("sunny in %s (%s)", args.City, args.Unit), nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, jsonschema.Definition{
+		Type: jsonschema.Object,
+		Properties: map[string]jsonschema.Definition{
+			"city": {Type: jsonschema.String, Description: "the city to get the weather for"},
+			"unit": {Type: jsonschema.String, Enum: []string{"celsius", "fahrenheit"}},
+		},
+		Required: []string{"city"},
+	}, tool.Parameters())
+
+	out, err := tool.Call(context.Background(), `{"city": "Oslo", "unit": "celsius"}`)
+	require.NoError(t, err)
+	require.Equal(t, "sunny in Oslo (celsius)", out)
+
+	_, err = tool.Call(context.Background(), `{"unit": "celsius"}`)
+	require.ErrorIs(t, err, tools.ErrInvalidArguments)
+
+	_, err = tool.Call(context.Background(), `Oslo`)
+	require.ErrorIs(t, err, tools.ErrInvalidArguments)
+
+	_, err = tools.NewFunc("bad", "", func(context.Context, string) (string, error) { return "", nil })
+	require.ErrorIs(t, err, jsonschema.ErrUnsupportedType)
+}
@@ -39,6 +39,13 @@ var (
 	ErrAssertingContent = errors.New(
 		"couldn't assert content to string",
 	)
+	// ErrAssertingContentVector ContentVector is stored as a collection of numbers.
+	ErrAssertingContentVector = errors.New(
+		"couldn't assert contentVector to a vector",
+	)
+	// ErrInvalidFilters is returned when the filters are neither an OData
+	// filter string nor a valid filter.Expr.
+	ErrInvalidFilters = errors.New("invalid filters")
 )
 
 // New creates a vectorstore for azure AI search
@@ -55,7 +62,10 @@ func New(opts ...Option) (Store, error) {
 	return s, nil
 }
 
-var _ vectorstores.VectorStore = &Store{}
+var (
+	_ vectorstores.VectorStore    = &Store{}
+	_ vectorstores.VectorSearcher = &Store{}
+)
 
 // AddDocuments adds the text and metadata from the documents to the Chroma collection associated with 'Store'.
 // and returns the ids of the added documents.
@@ -100,11 +110,38 @@ func (s *Store) SimilaritySearch(
 	numDocuments int,
 	options ...vectorstores.Option,
 ) ([]schema.Document, error) {
+	result, err := s.similaritySearch(ctx, query, numDocuments, false, options...)
+	return result.Documents, err
+}
+
+// SimilaritySearchVectors does the same as SimilaritySearch, also returning
+// the embedding of the query and the stored embeddings of the documents. The
+// contentVector field of the index must be retrievable.
+func (s *Store) SimilaritySearchVectors(
+	ctx context.Context,
+	query string,
+	numDocuments int,
+	options ...vectorstores.Option,
+) (vectorstores.VectorSearchResult, error) {
+	return s.similaritySearch(ctx, query, numDocuments, true, options...)
+}
+
+func (s *Store) similaritySearch(
+	ctx context.Context,
+	query string,
+	numDocuments int,
+	withVector bool,
+	options ...vectorstores.Option,
+) (vectorstores.VectorSearchResult, error) {
 	opts := s.getOptions(options...)
+	filters, err := getFilters(opts)
+	if err != nil {
+		return vectorstores.VectorSearchResult{}, err
+	}
 
 	queryVector, err := s.embedder.EmbedQuery(ctx, query)
 	if err != nil {
-		return nil, err
+		return vectorstores.VectorSearchResult{}, err
 	}
 
 	payload := SearchDocumentsRequestInput{
@@ -113,32 +150,36 @@ func (s *Store) SimilaritySearch(
 			Value:  queryVector,
 			K:      numDocuments,
 		}},
-	}
-
-	if filter, ok := opts.Filters.(string); ok {
-		payload.Filter = filter
+		Filter: filters,
 	}
 
 	searchResults := SearchDocumentsRequestOuput{}
 	if err := s.SearchDocuments(ctx, opts.NameSpace, payload, &searchResults); err != nil {
-		return nil, err
+		return vectorstores.VectorSearchResult{}, err
 	}
 
-	output := []schema.Document{}
+	result := vectorstores.VectorSearchResult{QueryVector: queryVector, Documents: []schema.Document{}}
 	for _, searchResult := range searchResults.Value {
 		doc, err := assertResultValues(searchResult)
 		if err != nil {
-			return output, err
+			return result, err
 		}
 
 		if opts.ScoreThreshold > 0 && opts.ScoreThreshold > doc.Score {
 			continue
 		}
 
-		output = append(output, *doc)
+		if withVector {
+			vector, err := assertResultVector(searchResult)
+			if err != nil {
+				return result, err
+			}
+			result.Vectors = append(result.Vectors, vector)
+		}
+		result.Documents = append(result.Documents, *doc)
 	}
 
-	return output, nil
+	return result, nil
 }
 
 func assertResultValues(searchResult map[string]interface{}) (*schema.Document, error) {
@@ -170,3 +211,19 @@ func assertResultValues(searchResult map[string]interface{}) (*schema.Document,
 		Score:       score,
 	}, nil
 }
+
+func assertResultVector(searchResult map[string]interface{}) ([]float32, error) {
+	values, ok := searchResult["contentVector"].([]interface{})
+	if !ok {
+		return nil, ErrAssertingContentVector
+	}
+	vector := make([]float32, len(values))
+	for i, value := range values {
+		f, ok := value.(float64)
+		if !ok {
+			return nil, ErrAssertingContentVector
+		}
+		vector[i] = float32(f)
+	}
+	return vector, nil
+}
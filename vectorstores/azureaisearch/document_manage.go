@@ -0,0 +1,179 @@
+package azureaisearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/vectorstores"
+)
+
+var _ vectorstores.DocumentManager = &Store{}
+
+// searchPageSize is the number of documents requested per page when listing
+// the documents matching a filter.
+const searchPageSize = 1000
+
+// DeleteDocuments deletes the documents with the given ids from the index
+// named by the name space, or all documents matching the OData filter string
+// or filter.Expr if ids is empty.
+func (s *Store) DeleteDocuments(ctx context.Context, ids []string, options ...vectorstores.Option) error {
+	opts := s.getOptions(options...)
+	if len(ids) == 0 {
+		filters, err := getFilters(opts)
+		if err != nil {
+			return err
+		}
+		if filters == "" {
+			return vectorstores.ErrNoDocumentsSelected
+		}
+		if ids, err = s.searchIDs(ctx, opts.NameSpace, filters); err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+	}
+
+	documents := make([]map[string]interface{}, 0, len(ids))
+	for _, id := range ids {
+		documents = append(documents, map[string]interface{}{
+			"@search.action": "delete",
+			"id":             id,
+		})
+	}
+	return s.indexDocumentsAPIRequest(ctx, opts.NameSpace, documents)
+}
+
+// UpsertDocuments uploads the documents under ids to the index named by the
+// name space, replacing any documents with the same ids.
+func (s *Store) UpsertDocuments(
+	ctx context.Context,
+	ids []string,
+	docs []schema.Document,
+	options ...vectorstores.Option,
+) ([]string, error) {
+	if len(ids) != len(docs) {
+		return nil, vectorstores.ErrMismatchedIDs
+	}
+	opts := s.getOptions(options...)
+
+	texts := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		texts = append(texts, doc.PageContent)
+	}
+
+	vectors, err := s.embedder.EmbedDocuments(ctx, texts)
+	if err != nil {
+		return nil, err
+	}
+	if len(vectors) != len(docs) {
+		return nil, ErrNumberOfVectorDoesNotMatch
+	}
+
+	resultIDs := make([]string, 0, len(docs))
+	for i, doc := range docs {
+		id := ids[i]
+		if id == "" {
+			id = uuid.NewString()
+		}
+		if err = s.UploadDocument(ctx, id, opts.NameSpace, doc.PageContent, vectors[i], doc.Metadata); err != nil {
+			return resultIDs, err
+		}
+		resultIDs = append(resultIDs, id)
+	}
+	return resultIDs, nil
+}
+
+// GetByIDs returns the documents stored under ids in the index named by the
+// name space. Ids must not contain commas.
+func (s *Store) GetByIDs(ctx context.Context, ids []string, options ...vectorstores.Option) ([]schema.Document, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	opts := s.getOptions(options...)
+
+	payload := SearchDocumentsRequestInput{
+		Filter: fmt.Sprintf("search.in(id, '%s', ',')", strings.ReplaceAll(strings.Join(ids, ","), "'", "''")),
+		Select: "id,content,metadata",
+		Top:    len(ids),
+	}
+	searchResults := SearchDocumentsRequestOuput{}
+	if err := s.SearchDocuments(ctx, opts.NameSpace, payload, &searchResults); err != nil {
+		return nil, err
+	}
+
+	found := make(map[string]schema.Document, len(searchResults.Value))
+	for _, searchResult := range searchResults.Value {
+		doc, err := assertResultValues(searchResult)
+		if err != nil {
+			return nil, err
+		}
+		doc.Score = 0
+		if id, ok := searchResult["id"].(string); ok {
+			found[id] = *doc
+		}
+	}
+
+	docs := make([]schema.Document, 0, len(found))
+	for _, id := range ids {
+		if doc, ok := found[id]; ok {
+			docs = append(docs, doc)
+		}
+	}
+	return docs, nil
+}
+
+// searchIDs returns the ids of all the documents in the index matching filter.
+func (s *Store) searchIDs(ctx context.Context, indexName, filter string) ([]string, error) {
+	ids := []string{}
+	for {
+		payload := SearchDocumentsRequestInput{
+			Filter: filter,
+			Select: "id",
+			Top:    searchPageSize,
+			Skip:   len(ids),
+		}
+		searchResults := SearchDocumentsRequestOuput{}
+		if err := s.SearchDocuments(ctx, indexName, payload, &searchResults); err != nil {
+			return nil, err
+		}
+		for _, searchResult := range searchResults.Value {
+			if id, ok := searchResult["id"].(string); ok {
+				ids = append(ids, id)
+			}
+		}
+		if len(searchResults.Value) < searchPageSize {
+			return ids, nil
+		}
+	}
+}
+
+// indexDocumentsAPIRequest sends a batch of document actions to azure AI search.
+func (s *Store) indexDocumentsAPIRequest(ctx context.Context, indexName string, documents []map[string]interface{}) error {
+	URL := fmt.Sprintf("%s/indexes/%s/docs/index?api-version=2020-06-30", s.azureAISearchEndpoint, indexName)
+
+	body, err := json.Marshal(map[string]interface{}{
+		"value": documents,
+	})
+	if err != nil {
+		return fmt.Errorf("err marshalling body for azure ai search: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, URL, bytes.NewBuffer(body))
+	if err != nil {
+		return fmt.Errorf("err setting request for azure ai search index documents: %w", err)
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+	if s.azureAISearchAPIKey != "" {
+		req.Header.Add("api-key", s.azureAISearchAPIKey)
+	}
+
+	return s.httpDefaultSend(req, "azure ai search index documents", nil)
+}
@@ -0,0 +1,81 @@
+package azureaisearch
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/tmc/langchaingo/vectorstores"
+	"github.com/tmc/langchaingo/vectorstores/filter"
+)
+
+var _odataOperators = map[filter.Operator]string{ //nolint:gochecknoglobals
+	filter.OpEq:  "eq",
+	filter.OpNe:  "ne",
+	filter.OpGt:  "gt",
+	filter.OpGte: "ge",
+	filter.OpLt:  "lt",
+	filter.OpLte: "le",
+}
+
+// getFilters returns the OData filter of the options. Filters are either an
+// OData filter string or a filter.Expr, whose keys must be filterable fields
+// of the index; the default index stores the metadata as a single string and
+// can't be filtered on.
+func getFilters(opts vectorstores.Options) (string, error) {
+	switch filters := opts.Filters.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return filters, nil
+	case filter.Expr:
+		if err := filters.Validate(); err != nil {
+			return "", fmt.Errorf("%w: %w", ErrInvalidFilters, err)
+		}
+		return exprOData(filters), nil
+	default:
+		return "", fmt.Errorf("%w: unsupported type %T", ErrInvalidFilters, opts.Filters)
+	}
+}
+
+// exprOData translates e to an OData filter expression, or "" for an
+// expression matching everything.
+func exprOData(e filter.Expr) string {
+	if e.MatchesAll() {
+		return ""
+	}
+	switch e.Op { //nolint:exhaustive
+	case filter.OpAnd, filter.OpOr:
+		operands := make([]string, 0, len(e.Operands))
+		for _, operand := range e.Operands {
+			// Operands matching everything are left out of And, and Or has none.
+			if odata := exprOData(operand); odata != "" {
+				operands = append(operands, "("+odata+")")
+			}
+		}
+		return strings.Join(operands, " "+string(e.Op)+" ")
+	case filter.OpNot:
+		return "not (" + exprOData(e.Operands[0]) + ")"
+	case filter.OpIn:
+		values := e.Values()
+		operands := make([]string, len(values))
+		for i, value := range values {
+			operands[i] = e.Key + " eq " + odataLiteral(value)
+		}
+		return strings.Join(operands, " or ")
+	case filter.OpNin:
+		return "not (" + exprOData(filter.In(e.Key, e.Value)) + ")"
+	}
+	return e.Key + " " + _odataOperators[e.Op] + " " + odataLiteral(e.Value)
+}
+
+func odataLiteral(value any) string {
+	switch v := value.(type) {
+	case string:
+		return "'" + strings.ReplaceAll(v, "'", "''") + "'"
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	number, _ := filter.Number(value)
+	return strconv.FormatFloat(number, 'f', -1, 64)
+}
@@ -0,0 +1,73 @@
+package azureaisearch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/vectorstores"
+	"github.com/tmc/langchaingo/vectorstores/filter"
+)
+
+func TestGetFilters(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		expr filter.Expr
+		want string
+	}{
+		{
+			"not over and",
+			filter.Not(filter.And(filter.Eq("kind", "manual"), filter.Gt("year", 2020))),
+			"not ((kind eq 'manual') and (year gt 2020))",
+		},
+		{
+			"not over or",
+			filter.Not(filter.Or(filter.Eq("kind", "manual"), filter.Lt("year", 2020))),
+			"not ((kind eq 'manual') or (year lt 2020))",
+		},
+		{
+			"nin",
+			filter.Nin("lang", []string{"de", "fr"}),
+			"not (lang eq 'de' or lang eq 'fr')",
+		},
+		{
+			"numeric range",
+			filter.And(filter.Gte("year", 2020), filter.Lte("year", 2024.5)),
+			"(year ge 2020) and (year le 2024.5)",
+		},
+		{
+			"string quoting",
+			filter.Eq("title", `the "Dune" saga's end`),
+			`title eq 'the "Dune" saga''s end'`,
+		},
+		{
+			"matches everything",
+			filter.Or(filter.Eq("kind", "manual"), filter.And(filter.Nin("lang", []string{}))),
+			"",
+		},
+		{
+			"and leaves out operands matching everything",
+			filter.And(filter.Eq("kind", "manual"), filter.Nin("lang", []string{})),
+			"(kind eq 'manual')",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			odata, err := getFilters(vectorstores.Options{Filters: tt.expr})
+			require.NoError(t, err)
+			require.Equal(t, tt.want, odata)
+		})
+	}
+
+	for _, e := range []filter.Expr{
+		filter.In("kind", []string{}),
+		filter.Or(),
+		filter.And(filter.Eq("kind", "manual"), filter.Not(filter.And())),
+	} {
+		_, err := getFilters(vectorstores.Options{Filters: e})
+		require.ErrorIs(t, err, ErrInvalidFilters)
+		require.ErrorIs(t, err, filter.ErrInvalidFilter)
+	}
+}
@@ -7,11 +7,13 @@ import (
 
 	chromago "github.com/amikos-tech/chroma-go"
 	"github.com/amikos-tech/chroma-go/openai"
+	chromaopenapi "github.com/amikos-tech/chroma-go/swagger"
 	chromatypes "github.com/amikos-tech/chroma-go/types"
 	"github.com/google/uuid"
 	"github.com/tmc/langchaingo/embeddings"
 	"github.com/tmc/langchaingo/schema"
 	"github.com/tmc/langchaingo/vectorstores"
+	"github.com/tmc/langchaingo/vectorstores/filter"
 	"golang.org/x/exp/maps"
 )
 
@@ -21,6 +23,7 @@ var (
 	ErrNewClient                = errors.New("error creating collection")
 	ErrAddDocument              = errors.New("error adding document")
 	ErrRemoveCollection         = errors.New("error resetting collection")
+	ErrDeleteDocuments          = errors.New("error deleting documents")
 	ErrUnsupportedOptions       = errors.New("unsupported options")
 )
 
@@ -39,7 +42,10 @@ type Store struct {
 	includes     []chromatypes.QueryEnum
 }
 
-var _ vectorstores.VectorStore = Store{}
+var (
+	_ vectorstores.DocumentManager = Store{}
+	_ vectorstores.VectorSearcher  = Store{}
+)
 
 // New creates an active client connection to the (specified, or default) collection in the Chroma server
 // and returns the `Store` object needed by the other accessors.
@@ -103,10 +109,116 @@ func (s Store) AddDocuments(ctx context.Context,
 	}
 
 	ids := make([]string, len(docs))
+	for docIdx := range docs {
+		ids[docIdx] = uuid.New().String() // TODO (noodnik2): find & use something more meaningful
+	}
+	texts, metadatas := s.textsAndMetadatas(docs, nameSpace)
+
+	col := s.collection
+	if _, addErr := col.Add(ctx, nil, metadatas, texts, ids); addErr != nil {
+		return nil, fmt.Errorf("%w: %w", ErrAddDocument, addErr)
+	}
+	return ids, nil
+}
+
+// DeleteDocuments deletes the documents with the given ids from the
+// collection, or all documents matching the filters if ids is empty. Filters
+// are Chroma where clauses or filter.Expr values.
+func (s Store) DeleteDocuments(ctx context.Context, ids []string, options ...vectorstores.Option) error {
+	opts := s.getOptions(options...)
+	if len(ids) == 0 && opts.Filters == nil {
+		return vectorstores.ErrNoDocumentsSelected
+	}
+	if len(ids) > 0 {
+		// Filters only apply when deleting without ids; the name space
+		// filter still scopes the ids.
+		opts.Filters = nil
+	}
+
+	where, err := s.getNamespacedFilter(opts)
+	if err != nil {
+		return err
+	}
+	if _, err := s.collection.Delete(ctx, ids, where, nil); err != nil {
+		return fmt.Errorf("%w: %w", ErrDeleteDocuments, err)
+	}
+	return nil
+}
+
+// UpsertDocuments adds the documents to the collection under ids, replacing
+// any documents already stored under the same ids.
+func (s Store) UpsertDocuments(ctx context.Context,
+	ids []string,
+	docs []schema.Document,
+	options ...vectorstores.Option,
+) ([]string, error) {
+	if len(ids) != len(docs) {
+		return nil, vectorstores.ErrMismatchedIDs
+	}
+	opts := s.getOptions(options...)
+	if opts.Embedder != nil || opts.ScoreThreshold != 0 || opts.Filters != nil {
+		return nil, ErrUnsupportedOptions
+	}
+
+	nameSpace := s.getNameSpace(opts)
+	if nameSpace != "" && s.nameSpaceKey == "" {
+		return nil, fmt.Errorf("%w: nameSpace without nameSpaceKey", ErrUnsupportedOptions)
+	}
+
+	ids = append([]string(nil), ids...)
+	for docIdx := range ids {
+		if ids[docIdx] == "" {
+			ids[docIdx] = uuid.New().String()
+		}
+	}
+	texts, metadatas := s.textsAndMetadatas(docs, nameSpace)
+
+	if _, err := s.collection.Upsert(ctx, nil, metadatas, texts, ids); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrAddDocument, err)
+	}
+	return ids, nil
+}
+
+// GetByIDs returns the documents stored under ids in the collection.
+func (s Store) GetByIDs(ctx context.Context, ids []string, options ...vectorstores.Option) ([]schema.Document, error) {
+	opts := s.getOptions(options...)
+
+	where, err := s.getNamespacedFilter(opts)
+	if err != nil {
+		return nil, err
+	}
+	gr, err := s.collection.Get(ctx, where, nil, ids,
+		[]chromatypes.QueryEnum{chromatypes.IDocuments, chromatypes.IMetadatas})
+	if err != nil {
+		return nil, err
+	}
+	if len(gr.Ids) != len(gr.Documents) || len(gr.Ids) != len(gr.Metadatas) {
+		return nil, fmt.Errorf("%w: gr.Ids[%d], gr.Documents[%d], gr.Metadatas[%d]",
+			ErrUnexpectedResponseLength, len(gr.Ids), len(gr.Documents), len(gr.Metadatas))
+	}
+
+	found := make(map[string]schema.Document, len(gr.Ids))
+	for i, id := range gr.Ids {
+		found[id] = schema.Document{
+			PageContent: gr.Documents[i],
+			Metadata:    gr.Metadatas[i],
+		}
+	}
+	docs := make([]schema.Document, 0, len(found))
+	for _, id := range ids {
+		if doc, ok := found[id]; ok {
+			docs = append(docs, doc)
+		}
+	}
+	return docs, nil
+}
+
+// textsAndMetadatas splits docs into the texts and metadatas Chroma expects,
+// adding the name space to the metadata if set.
+func (s Store) textsAndMetadatas(docs []schema.Document, nameSpace string) ([]string, []map[string]any) {
 	texts := make([]string, len(docs))
 	metadatas := make([]map[string]any, len(docs))
 	for docIdx, doc := range docs {
-		ids[docIdx] = uuid.New().String() // TODO (noodnik2): find & use something more meaningful
 		texts[docIdx] = doc.PageContent
 		mc := make(map[string]any, 0)
 		maps.Copy(mc, doc.Metadata)
@@ -115,53 +227,157 @@ func (s Store) AddDocuments(ctx context.Context,
 			metadatas[docIdx][s.nameSpaceKey] = nameSpace
 		}
 	}
-
-	col := s.collection
-	if _, addErr := col.Add(ctx, nil, metadatas, texts, ids); addErr != nil {
-		return nil, fmt.Errorf("%w: %w", ErrAddDocument, addErr)
-	}
-	return ids, nil
+	return texts, metadatas
 }
 
 func (s Store) SimilaritySearch(ctx context.Context, query string, numDocuments int,
 	options ...vectorstores.Option,
 ) ([]schema.Document, error) {
+	result, err := s.similaritySearch(ctx, query, numDocuments, false, options...)
+	return result.Documents, err
+}
+
+// SimilaritySearchVectors does the same as SimilaritySearch, also returning
+// the embedding of the query and the stored embeddings of the documents.
+func (s Store) SimilaritySearchVectors(ctx context.Context, query string, numDocuments int,
+	options ...vectorstores.Option,
+) (vectorstores.VectorSearchResult, error) {
+	return s.similaritySearch(ctx, query, numDocuments, true, options...)
+}
+
+// similaritySearch queries the collection for the documents most similar to
+// the query, with the embeddings of the query and the documents if
+// withVector is true.
+func (s Store) similaritySearch(ctx context.Context, query string, numDocuments int, withVector bool,
+	options ...vectorstores.Option,
+) (vectorstores.VectorSearchResult, error) {
+	var result vectorstores.VectorSearchResult
 	opts := s.getOptions(options...)
 
 	if opts.Embedder != nil {
 		// embedder is not used by this method, so shouldn't ever be specified
-		return nil, fmt.Errorf("%w: Embedder", ErrUnsupportedOptions)
+		return result, fmt.Errorf("%w: Embedder", ErrUnsupportedOptions)
 	}
 
 	scoreThreshold, stErr := s.getScoreThreshold(opts)
 	if stErr != nil {
-		return nil, stErr
+		return result, stErr
 	}
 
-	filter := s.getNamespacedFilter(opts)
-	qr, queryErr := s.collection.Query(ctx, []string{query}, int32(numDocuments), filter, nil, s.includes)
-	if queryErr != nil {
-		return nil, queryErr
+	where, err := s.getNamespacedFilter(opts)
+	if err != nil {
+		return result, err
+	}
+
+	var qr *chromago.QueryResults
+	var vectors [][]*chromatypes.Embedding
+	if withVector {
+		result.QueryVector, qr, vectors, err = s.queryVectors(ctx, query, numDocuments, where)
+	} else {
+		qr, err = s.collection.Query(ctx, []string{query}, int32(numDocuments), where, nil, s.includes)
+	}
+	if err != nil {
+		return result, err
 	}
 
 	if len(qr.Documents) != len(qr.Metadatas) || len(qr.Metadatas) != len(qr.Distances) {
-		return nil, fmt.Errorf("%w: qr.Documents[%d], qr.Metadatas[%d], qr.Distances[%d]",
+		return result, fmt.Errorf("%w: qr.Documents[%d], qr.Metadatas[%d], qr.Distances[%d]",
 			ErrUnexpectedResponseLength, len(qr.Documents), len(qr.Metadatas), len(qr.Distances))
 	}
-	var sDocs []schema.Document
+	if withVector && !sameLengths(qr.Documents, vectors) {
+		return result, fmt.Errorf("%w: qr.Documents[%d], embeddings[%d]",
+			ErrUnexpectedResponseLength, len(qr.Documents), len(vectors))
+	}
 	for docsI := range qr.Documents {
 		for docI := range qr.Documents[docsI] {
-			if score := 1.0 - qr.Distances[docsI][docI]; score >= scoreThreshold {
-				sDocs = append(sDocs, schema.Document{
-					Metadata:    qr.Metadatas[docsI][docI],
-					PageContent: qr.Documents[docsI][docI],
-					Score:       score,
-				})
+			score := 1.0 - qr.Distances[docsI][docI]
+			if score < scoreThreshold {
+				continue
+			}
+			result.Documents = append(result.Documents, schema.Document{
+				Metadata:    qr.Metadatas[docsI][docI],
+				PageContent: qr.Documents[docsI][docI],
+				Score:       score,
+			})
+			if withVector {
+				result.Vectors = append(result.Vectors, embeddingToFloat32(vectors[docsI][docI]))
 			}
 		}
 	}
 
-	return sDocs, nil
+	return result, nil
+}
+
+// queryVectors embeds the query and queries the collection with it,
+// including the embeddings of the documents found, which the query results
+// of chroma-go leave out.
+func (s Store) queryVectors(ctx context.Context, query string, numDocuments int, where map[string]any,
+) ([]float32, *chromago.QueryResults, [][]*chromatypes.Embedding, error) {
+	queryVector, err := s.collection.EmbeddingFunction.EmbedQuery(ctx, query)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	includes := s.includes
+	if len(includes) == 0 {
+		includes = []chromatypes.QueryEnum{chromatypes.IDocuments, chromatypes.IMetadatas, chromatypes.IDistances}
+	}
+	includes = append(append([]chromatypes.QueryEnum(nil), includes...), chromatypes.IEmbeddings)
+	include := make([]chromaopenapi.IncludeInner, len(includes))
+	for i := range includes {
+		include[i] = chromaopenapi.IncludeInner{String: (*string)(&includes[i])}
+	}
+
+	nResults := int32(numDocuments)
+	qr, _, err := s.collection.ApiClient.DefaultApi.GetNearestNeighbors(ctx, s.collection.ID).
+		QueryEmbedding(chromaopenapi.QueryEmbedding{
+			Where:           where,
+			NResults:        &nResults,
+			Include:         include,
+			QueryEmbeddings: []chromaopenapi.EmbeddingsInner{queryVector.ToAPI()},
+		}).Execute()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	vectors := make([][]*chromatypes.Embedding, len(qr.Embeddings))
+	for i, embeddings := range qr.Embeddings {
+		vectors[i] = chromago.APIEmbeddingsToEmbeddings(embeddings)
+	}
+	return embeddingToFloat32(queryVector), &chromago.QueryResults{
+		Documents: qr.Documents,
+		Ids:       qr.Ids,
+		Metadatas: qr.Metadatas,
+		Distances: qr.Distances,
+	}, vectors, nil
+}
+
+// sameLengths reports whether the embeddings have the shape of the documents.
+func sameLengths(documents [][]string, embeddings [][]*chromatypes.Embedding) bool {
+	if len(documents) != len(embeddings) {
+		return false
+	}
+	for i := range documents {
+		if len(documents[i]) != len(embeddings[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// embeddingToFloat32 returns the values of a Chroma embedding as float32.
+func embeddingToFloat32(embedding *chromatypes.Embedding) []float32 {
+	if values := embedding.GetFloat32(); values != nil {
+		return *values
+	}
+	var vector []float32
+	if values := embedding.GetInt32(); values != nil {
+		vector = make([]float32, len(*values))
+		for i, v := range *values {
+			vector[i] = float32(v)
+		}
+	}
+	return vector
 }
 
 func (s Store) RemoveCollection() error {
@@ -197,18 +413,32 @@ func (s Store) getNameSpace(opts vectorstores.Options) string {
 	return s.nameSpace
 }
 
-func (s Store) getNamespacedFilter(opts vectorstores.Options) map[string]any {
-	filter, _ := opts.Filters.(map[string]any)
+// getNamespacedFilter returns the where clause of the filters, which are
+// either a Chroma where clause or a filter.Expr, restricted to the name space.
+func (s Store) getNamespacedFilter(opts vectorstores.Options) (map[string]any, error) {
+	var where map[string]any
+	switch f := opts.Filters.(type) {
+	case nil:
+	case map[string]any:
+		where = f
+	case filter.Expr:
+		var err error
+		if where, err = filter.ToOperatorMap(f); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("%w: filters of type %T", ErrUnsupportedOptions, opts.Filters)
+	}
 
 	nameSpace := s.getNameSpace(opts)
 	if nameSpace == "" || s.nameSpaceKey == "" {
-		return filter
+		return where, nil
 	}
 
 	nameSpaceFilter := map[string]any{s.nameSpaceKey: nameSpace}
-	if filter == nil {
-		return nameSpaceFilter
+	if where == nil {
+		return nameSpaceFilter, nil
 	}
 
-	return map[string]any{"$and": []map[string]any{nameSpaceFilter, filter}}
+	return map[string]any{"$and": []map[string]any{nameSpaceFilter, where}}, nil
 }
@@ -64,6 +64,12 @@ func TestChromaGoStoreRest(t *testing.T) {
 	country := docs[0].Metadata["country"]
 	require.NoError(t, err)
 	require.Equal(t, "japan", country)
+
+	result, err := s.SimilaritySearchVectors(context.Background(), "japan", 2)
+	require.NoError(t, err)
+	require.Len(t, result.Documents, 2)
+	require.Len(t, result.Vectors, 2)
+	require.Len(t, result.Vectors[0], len(result.QueryVector))
 }
 
 func TestChromaStoreRestWithScoreThreshold(t *testing.T) {
@@ -7,6 +7,9 @@ The main components of this package are:
 - VectorStore interface: a common interface for saving and querying vector embeddings of documents.
 - Options: a set of options for similarity search and document addition.
 - Retriever: a retriever for vector stores that implements the schema.Retriever interface.
+- Deleter, Upserter and Getter: optional interfaces for deleting, upserting and getting documents by id.
+- VectorSearcher: an optional interface for searches returning embeddings, used by MaxMarginalRelevanceSearch.
+- filter: a subpackage with metadata filter expressions that every vector store translates to its native filters.
 
 The package provides a flexible way to handle different types of vector stores
 by using the VectorStore interface as an abstraction.
@@ -0,0 +1,17 @@
+/*
+Package filter contains a backend neutral expression for filtering documents
+by their metadata, which vector stores translate to their native filters.
+
+Expressions are built with the comparison functions Eq, Ne, Gt, Gte, Lt, Lte,
+In and Nin, and combined with And, Or and Not:
+
+	vectorstores.WithFilters(filter.And(
+		filter.Eq("kind", "manual"),
+		filter.Gte("year", 2020),
+		filter.Not(filter.In("language", []string{"de", "fr"})),
+	))
+
+Expressions marshal to and from JSON, which lets a language model write them,
+see retrievers/selfquery.
+*/
+package filter
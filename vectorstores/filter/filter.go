@@ -0,0 +1,313 @@
+package filter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrInvalidFilter is returned when a filter expression is malformed or uses
+// an operator a vector store can't translate.
+var ErrInvalidFilter = errors.New("invalid filter")
+
+// Operator is the operator of an expression.
+type Operator string
+
+// Comparison operators, comparing the metadata value of a key with a value.
+const (
+	OpEq  Operator = "eq"
+	OpNe  Operator = "ne"
+	OpGt  Operator = "gt"
+	OpGte Operator = "gte"
+	OpLt  Operator = "lt"
+	OpLte Operator = "lte"
+	OpIn  Operator = "in"
+	OpNin Operator = "nin"
+)
+
+// Logical operators, combining expressions.
+const (
+	OpAnd Operator = "and"
+	OpOr  Operator = "or"
+	OpNot Operator = "not"
+)
+
+// Expr is a filter expression. Comparisons have a Key and a Value, which is a
+// slice for OpIn and OpNin. Logical expressions have Operands, exactly one for
+// OpNot.
+//
+// An expression matching no document, such as Or() or In with no values, is
+// invalid, as is any expression containing one: not every vector store can
+// express it, so they all return an error wrapping ErrInvalidFilter for it.
+// An expression matching every document, such as And() or Nin with no
+// values, is valid and filters nothing.
+type Expr struct {
+	Op       Operator `json:"op"`
+	Key      string   `json:"key,omitempty"`
+	Value    any      `json:"value,omitempty"`
+	Operands []Expr   `json:"operands,omitempty"`
+}
+
+func comparison(op Operator, key string, value any) Expr {
+	return Expr{Op: op, Key: key, Value: value}
+}
+
+// Eq matches documents whose metadata value for key equals value.
+func Eq(key string, value any) Expr { return comparison(OpEq, key, value) }
+
+// Ne matches documents whose metadata value for key doesn't equal value.
+func Ne(key string, value any) Expr { return comparison(OpNe, key, value) }
+
+// Gt matches documents whose metadata value for key is greater than value.
+func Gt(key string, value any) Expr { return comparison(OpGt, key, value) }
+
+// Gte matches documents whose metadata value for key is greater than or
+// equal to value.
+func Gte(key string, value any) Expr { return comparison(OpGte, key, value) }
+
+// Lt matches documents whose metadata value for key is less than value.
+func Lt(key string, value any) Expr { return comparison(OpLt, key, value) }
+
+// Lte matches documents whose metadata value for key is less than or equal
+// to value.
+func Lte(key string, value any) Expr { return comparison(OpLte, key, value) }
+
+// In matches documents whose metadata value for key is one of values, which
+// must be a slice.
+func In(key string, values any) Expr { return comparison(OpIn, key, values) }
+
+// Nin matches documents whose metadata value for key is none of values, which
+// must be a slice.
+func Nin(key string, values any) Expr { return comparison(OpNin, key, values) }
+
+// And matches documents matching all the expressions.
+func And(exprs ...Expr) Expr { return Expr{Op: OpAnd, Operands: exprs} }
+
+// Or matches documents matching any of the expressions.
+func Or(exprs ...Expr) Expr { return Expr{Op: OpOr, Operands: exprs} }
+
+// Not matches documents not matching the expression.
+func Not(expr Expr) Expr { return Expr{Op: OpNot, Operands: []Expr{expr}} }
+
+// IsComparison reports whether the operator of e is a comparison.
+func (e Expr) IsComparison() bool {
+	switch e.Op { //nolint:exhaustive
+	case OpEq, OpNe, OpGt, OpGte, OpLt, OpLte, OpIn, OpNin:
+		return true
+	default:
+		return false
+	}
+}
+
+// Values returns the values of an OpIn or OpNin comparison.
+func (e Expr) Values() []any {
+	v := reflect.ValueOf(e.Value)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil
+	}
+	values := make([]any, v.Len())
+	for i := range values {
+		values[i] = v.Index(i).Interface()
+	}
+	return values
+}
+
+// Validate returns an error wrapping ErrInvalidFilter if e is malformed.
+func (e Expr) Validate() error {
+	switch e.Op {
+	case OpEq, OpNe, OpGt, OpGte, OpLt, OpLte:
+		if e.Key == "" {
+			return fmt.Errorf("%w: %s without key", ErrInvalidFilter, e.Op)
+		}
+		if !isScalar(e.Value) {
+			return fmt.Errorf("%w: value of %s on %q must be a string, number or boolean", ErrInvalidFilter, e.Op, e.Key)
+		}
+	case OpIn, OpNin:
+		if e.Key == "" {
+			return fmt.Errorf("%w: %s without key", ErrInvalidFilter, e.Op)
+		}
+		kind := reflect.ValueOf(e.Value).Kind()
+		if kind != reflect.Slice && kind != reflect.Array {
+			return fmt.Errorf("%w: value of %s on %q must be a list", ErrInvalidFilter, e.Op, e.Key)
+		}
+		values := e.Values()
+		for _, v := range values {
+			if !isScalar(v) {
+				return fmt.Errorf("%w: values of %s on %q must be strings, numbers or booleans", ErrInvalidFilter, e.Op, e.Key)
+			}
+		}
+		if len(values) == 0 && e.Op == OpIn {
+			return fmt.Errorf("%w: in on %q without values matches nothing", ErrInvalidFilter, e.Key)
+		}
+	case OpAnd, OpOr:
+		if len(e.Operands) == 0 && e.Op == OpOr {
+			return fmt.Errorf("%w: or without operands matches nothing", ErrInvalidFilter)
+		}
+		for _, operand := range e.Operands {
+			if err := operand.Validate(); err != nil {
+				return err
+			}
+		}
+	case OpNot:
+		if len(e.Operands) != 1 {
+			return fmt.Errorf("%w: not must have one operand", ErrInvalidFilter)
+		}
+		if e.Operands[0].MatchesAll() {
+			return fmt.Errorf("%w: not of an expression matching everything matches nothing", ErrInvalidFilter)
+		}
+		return e.Operands[0].Validate()
+	default:
+		return fmt.Errorf("%w: unknown operator %q", ErrInvalidFilter, e.Op)
+	}
+	return nil
+}
+
+// MatchesAll reports whether e matches every document, like And() or Nin
+// with no values, which vector stores translate to no filter.
+func (e Expr) MatchesAll() bool {
+	switch e.Op { //nolint:exhaustive
+	case OpAnd:
+		for _, operand := range e.Operands {
+			if !operand.MatchesAll() {
+				return false
+			}
+		}
+		return true
+	case OpOr:
+		for _, operand := range e.Operands {
+			if operand.MatchesAll() {
+				return true
+			}
+		}
+		return false
+	case OpNin:
+		return len(e.Values()) == 0
+	default:
+		return false
+	}
+}
+
+// Keys returns the metadata keys used in e, in order of appearance.
+func (e Expr) Keys() []string {
+	var keys []string
+	seen := make(map[string]bool)
+	var walk func(e Expr)
+	walk = func(e Expr) {
+		if e.Key != "" && !seen[e.Key] {
+			seen[e.Key] = true
+			keys = append(keys, e.Key)
+		}
+		for _, operand := range e.Operands {
+			walk(operand)
+		}
+	}
+	walk(e)
+	return keys
+}
+
+// Parse parses and validates an expression in its JSON form, for example
+// {"op": "and", "operands": [{"op": "eq", "key": "kind", "value": "manual"}]}.
+func Parse(data []byte) (Expr, error) {
+	var e Expr
+	if err := json.Unmarshal(data, &e); err != nil {
+		return Expr{}, fmt.Errorf("%w: %w", ErrInvalidFilter, err)
+	}
+	return e, e.Validate()
+}
+
+// Normalize returns e without OpNot, by applying De Morgan's laws and
+// negating comparisons, for vector stores without a native negation. Note
+// that negated comparisons may differ in how they treat documents missing
+// the key: Not(Gt(k, v)) becomes Lte(k, v). Negating an operand matching
+// everything, like Nin(k, []string{}), gives one matching nothing, which is left out
+// of the Or it ends up in.
+func Normalize(e Expr) Expr {
+	return normalize(e, false)
+}
+
+var _negations = map[Operator]Operator{
+	OpEq: OpNe, OpNe: OpEq,
+	OpGt: OpLte, OpLte: OpGt,
+	OpLt: OpGte, OpGte: OpLt,
+	OpIn: OpNin, OpNin: OpIn,
+	OpAnd: OpOr, OpOr: OpAnd,
+}
+
+func normalize(e Expr, negate bool) Expr {
+	switch {
+	case e.Op == OpNot && len(e.Operands) == 1:
+		return normalize(e.Operands[0], !negate)
+	case e.Op == OpAnd || e.Op == OpOr:
+		op := e.Op
+		if negate {
+			op = _negations[op]
+		}
+		operands := make([]Expr, 0, len(e.Operands))
+		for _, operand := range e.Operands {
+			operand = normalize(operand, negate)
+			// Negating operands matching everything, like Nin with no values,
+			// gives operands matching nothing, left out of the Or.
+			if op == OpOr && matchesNothing(operand) {
+				continue
+			}
+			operands = append(operands, operand)
+		}
+		return Expr{Op: op, Operands: operands}
+	case negate && e.IsComparison():
+		return comparison(_negations[e.Op], e.Key, e.Value)
+	default:
+		return e
+	}
+}
+
+// matchesNothing reports whether e, without OpNot, matches no document.
+func matchesNothing(e Expr) bool {
+	switch e.Op { //nolint:exhaustive
+	case OpIn:
+		return len(e.Values()) == 0
+	case OpOr:
+		for _, operand := range e.Operands {
+			if !matchesNothing(operand) {
+				return false
+			}
+		}
+		return true
+	case OpAnd:
+		for _, operand := range e.Operands {
+			if matchesNothing(operand) {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+// Number returns v as a float64 if it is a number.
+func Number(v any) (float64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() { //nolint:exhaustive
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+func isScalar(v any) bool {
+	if _, ok := Number(v); ok {
+		return true
+	}
+	switch v.(type) {
+	case string, bool:
+		return true
+	default:
+		return false
+	}
+}
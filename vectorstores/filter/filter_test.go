@@ -0,0 +1,93 @@
+package filter_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/vectorstores/filter"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	e, err := filter.Parse([]byte(`{"op": "and", "operands": [
+		{"op": "eq", "key": "kind", "value": "manual"},
+		{"op": "not", "operands": [{"op": "in", "key": "year", "value": [2019, 2020]}]}
+	]}`))
+	require.NoError(t, err)
+	require.Equal(t, filter.And(
+		filter.Eq("kind", "manual"),
+		filter.Not(filter.In("year", []any{2019.0, 2020.0})),
+	), e)
+	require.Equal(t, []string{"kind", "year"}, e.Keys())
+
+	data, err := json.Marshal(e)
+	require.NoError(t, err)
+	roundTrip, err := filter.Parse(data)
+	require.NoError(t, err)
+	require.Equal(t, e, roundTrip)
+
+	for _, invalid := range []string{
+		`{"op": "like", "key": "kind", "value": "m%"}`,
+		`{"op": "eq", "value": "manual"}`,
+		`{"op": "eq", "key": "kind", "value": {"nested": true}}`,
+		`{"op": "in", "key": "kind", "value": "manual"}`,
+		`{"op": "not", "operands": []}`,
+		`{"op": "or", "operands": [{"op": "gt", "key": "year"}]}`,
+		`{"op": "or", "operands": []}`,
+		`{"op": "in", "key": "kind", "value": []}`,
+		`{"op": "and", "operands": [{"op": "not", "operands": [{"op": "nin", "key": "kind", "value": []}]}]}`,
+		`[]`,
+	} {
+		_, err := filter.Parse([]byte(invalid))
+		require.ErrorIs(t, err, filter.ErrInvalidFilter, invalid)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	t.Parallel()
+
+	e := filter.Not(filter.Or(
+		filter.Gt("year", 2020),
+		filter.Not(filter.Eq("kind", "manual")),
+		filter.And(filter.In("lang", []string{"de"}), filter.Lte("pages", 10)),
+	))
+	require.Equal(t, filter.And(
+		filter.Lte("year", 2020),
+		filter.Eq("kind", "manual"),
+		filter.Or(filter.Nin("lang", []string{"de"}), filter.Gt("pages", 10)),
+	), filter.Normalize(e))
+
+	// Negated, Nin without values matches nothing and is left out.
+	e = filter.Not(filter.And(filter.Eq("kind", "manual"), filter.Nin("lang", []string{})))
+	require.Equal(t, filter.Or(filter.Ne("kind", "manual")), filter.Normalize(e))
+}
+
+func TestToOperatorMap(t *testing.T) {
+	t.Parallel()
+
+	m, err := filter.ToOperatorMap(filter.And(
+		filter.Eq("kind", "manual"),
+		filter.Not(filter.Or(filter.Lt("year", 2020), filter.In("lang", []string{"de", "fr"}))),
+	))
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"$and": []map[string]any{
+		{"kind": map[string]any{"$eq": "manual"}},
+		{"$and": []map[string]any{
+			{"year": map[string]any{"$gte": 2020}},
+			{"lang": map[string]any{"$nin": []any{"de", "fr"}}},
+		}},
+	}}, m)
+
+	m, err = filter.ToOperatorMap(filter.And(filter.Ne("kind", "manual")))
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"kind": map[string]any{"$ne": "manual"}}, m)
+
+	m, err = filter.ToOperatorMap(filter.Or(filter.Eq("kind", "manual"), filter.And()))
+	require.NoError(t, err)
+	require.Nil(t, m)
+
+	_, err = filter.ToOperatorMap(filter.Not(filter.And()))
+	require.ErrorIs(t, err, filter.ErrInvalidFilter)
+}
@@ -0,0 +1,37 @@
+package filter
+
+// ToOperatorMap translates e to the MongoDB-like filter used by several
+// vector stores, such as {"$and": [{"kind": {"$eq": "manual"}}, ...]}.
+// Negations are removed with Normalize. A nil map is returned for an
+// expression matching everything, and an error for an invalid one.
+func ToOperatorMap(e Expr) (map[string]any, error) {
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+	return toOperatorMap(Normalize(e)), nil
+}
+
+func toOperatorMap(e Expr) map[string]any {
+	if e.MatchesAll() {
+		return nil
+	}
+	if e.IsComparison() {
+		value := e.Value
+		if e.Op == OpIn || e.Op == OpNin {
+			value = e.Values()
+		}
+		return map[string]any{e.Key: map[string]any{"$" + string(e.Op): value}}
+	}
+
+	operands := make([]map[string]any, 0, len(e.Operands))
+	for _, operand := range e.Operands {
+		// Operands matching everything are left out of And, and Or has none.
+		if m := toOperatorMap(operand); m != nil {
+			operands = append(operands, m)
+		}
+	}
+	if len(operands) == 1 {
+		return operands[0]
+	}
+	return map[string]any{"$" + string(e.Op): operands}
+}
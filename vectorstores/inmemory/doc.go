@@ -0,0 +1,31 @@
+// Package inmemory contains an implementation of the VectorStore interface
+// that keeps all vectors in process memory and can persist them to a file.
+//
+// It needs no external service, which makes it useful for unit tests, small
+// command line tools and offline RAG over modest document collections.
+// Searches are exact (brute force) and scale linearly with the number of
+// stored documents.
+//
+// # Filtering
+//
+// Filters are given to SimilaritySearch through vectorstores.WithFilters as a
+// map[string]any from metadata key to condition. A condition is either a
+// plain value, which must be equal to the metadata value, or a
+// map[string]any from operator to operand:
+//
+//	map[string]any{
+//		"source": "handbook.pdf",
+//		"page":   map[string]any{"$gte": 10, "$lt": 20},
+//		"lang":   map[string]any{"$in": []any{"en", "de"}},
+//	}
+//
+// The supported operators are $eq, $ne, $gt, $gte, $lt, $lte, $in and $nin.
+// All conditions must hold for a document to match. Numbers of any Go type
+// compare by value and strings compare lexicographically.
+//
+// # Persistence
+//
+// Store.Save writes the whole store, including vectors, as JSON and Load
+// reads it back. Metadata goes through encoding/json, so numbers come back
+// as float64.
+package inmemory
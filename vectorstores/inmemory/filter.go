@@ -0,0 +1,205 @@
+package inmemory
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/tmc/langchaingo/vectorstores/filter"
+)
+
+// Filter operators supported in metadata filters.
+const (
+	OpEq  = "$eq"
+	OpNe  = "$ne"
+	OpGt  = "$gt"
+	OpGte = "$gte"
+	OpLt  = "$lt"
+	OpLte = "$lte"
+	OpIn  = "$in"
+	OpNin = "$nin"
+)
+
+// metadataFilter is a parsed metadata filter. The zero value matches
+// everything.
+type metadataFilter struct {
+	conditions []condition
+	expr       *filter.Expr
+}
+
+type condition struct {
+	key     string
+	op      string
+	operand any
+}
+
+func newFilter(filters any) (metadataFilter, error) {
+	if filters == nil {
+		return metadataFilter{}, nil
+	}
+	if expr, ok := filters.(filter.Expr); ok {
+		if err := expr.Validate(); err != nil {
+			return metadataFilter{}, fmt.Errorf("%w: %w", ErrInvalidFilters, err)
+		}
+		return metadataFilter{expr: &expr}, nil
+	}
+	m, ok := filters.(map[string]any)
+	if !ok {
+		return metadataFilter{}, fmt.Errorf("%w: expected map[string]any or filter.Expr, got %T", ErrInvalidFilters, filters)
+	}
+
+	var f metadataFilter
+	for key, value := range m {
+		ops, ok := value.(map[string]any)
+		if !ok {
+			f.conditions = append(f.conditions, condition{key: key, op: OpEq, operand: value})
+			continue
+		}
+		for op, operand := range ops {
+			switch op {
+			case OpEq, OpNe, OpGt, OpGte, OpLt, OpLte:
+			case OpIn, OpNin:
+				if kind := reflect.ValueOf(operand).Kind(); kind != reflect.Slice && kind != reflect.Array {
+					return metadataFilter{}, fmt.Errorf("%w: operand of %s on %q must be a slice", ErrInvalidFilters, op, key)
+				}
+			default:
+				return metadataFilter{}, fmt.Errorf("%w: unknown operator %q on %q", ErrInvalidFilters, op, key)
+			}
+			f.conditions = append(f.conditions, condition{key: key, op: op, operand: operand})
+		}
+	}
+	return f, nil
+}
+
+func (f metadataFilter) match(metadata map[string]any) bool {
+	if f.expr != nil {
+		return matchExpr(*f.expr, metadata)
+	}
+	for _, c := range f.conditions {
+		value, ok := metadata[c.key]
+		if !ok {
+			// A missing key only satisfies negative conditions.
+			if c.op == OpNe || c.op == OpNin {
+				continue
+			}
+			return false
+		}
+		if !c.match(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchExpr evaluates a filter expression, treating missing keys like the
+// map filters do.
+func matchExpr(e filter.Expr, metadata map[string]any) bool {
+	switch e.Op { //nolint:exhaustive
+	case filter.OpAnd:
+		for _, operand := range e.Operands {
+			if !matchExpr(operand, metadata) {
+				return false
+			}
+		}
+		return true
+	case filter.OpOr:
+		for _, operand := range e.Operands {
+			if matchExpr(operand, metadata) {
+				return true
+			}
+		}
+		return false
+	case filter.OpNot:
+		return !matchExpr(e.Operands[0], metadata)
+	}
+	c := condition{key: e.Key, op: "$" + string(e.Op), operand: e.Value}
+	return metadataFilter{conditions: []condition{c}}.match(metadata)
+}
+
+func (c condition) match(value any) bool {
+	switch c.op {
+	case OpEq:
+		return equal(value, c.operand)
+	case OpNe:
+		return !equal(value, c.operand)
+	case OpIn:
+		return contains(c.operand, value)
+	case OpNin:
+		return !contains(c.operand, value)
+	}
+
+	cmp, ok := compare(value, c.operand)
+	if !ok {
+		return false
+	}
+	switch c.op {
+	case OpGt:
+		return cmp > 0
+	case OpGte:
+		return cmp >= 0
+	case OpLt:
+		return cmp < 0
+	case OpLte:
+		return cmp <= 0
+	}
+	return false
+}
+
+func contains(list, value any) bool {
+	v := reflect.ValueOf(list)
+	for i := 0; i < v.Len(); i++ {
+		if equal(value, v.Index(i).Interface()) {
+			return true
+		}
+	}
+	return false
+}
+
+func equal(a, b any) bool {
+	if cmp, ok := compare(a, b); ok {
+		return cmp == 0
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// compare returns the ordering of a and b if both are numbers or both are
+// strings.
+func compare(a, b any) (int, bool) {
+	if fa, ok := toFloat(a); ok {
+		fb, ok := toFloat(b)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case fa < fb:
+			return -1, true
+		case fa > fb:
+			return 1, true
+		default:
+			return 0, true
+		}
+	}
+	sa, ok := a.(string)
+	if !ok {
+		return 0, false
+	}
+	sb, ok := b.(string)
+	if !ok {
+		return 0, false
+	}
+	return strings.Compare(sa, sb), true
+}
+
+func toFloat(v any) (float64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() { //nolint:exhaustive
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,317 @@
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/tmc/langchaingo/embeddings"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/vectorstores"
+)
+
+var (
+	// ErrEmbedderWrongNumberVectors is returned when if the embedder returns a number
+	// of vectors that is not equal to the number of documents given.
+	ErrEmbedderWrongNumberVectors = errors.New(
+		"number of vectors from embedder does not match number of documents",
+	)
+	// ErrDimensionMismatch is returned when a vector doesn't have the same
+	// dimension as the vectors already in the name space.
+	ErrDimensionMismatch = errors.New("vector dimension does not match stored vectors")
+	// ErrInvalidScoreThreshold is returned when the score threshold is not
+	// between 0 and 1 with the Cosine and Euclidean metrics, whose scores are
+	// at most 1. Any threshold is valid with DotProduct.
+	ErrInvalidScoreThreshold = errors.New("score threshold must be between 0 and 1")
+	// ErrInvalidFilters is returned when the filters can't be interpreted.
+	ErrInvalidFilters = errors.New("invalid filters")
+)
+
+// DistanceMetric is the function used to compare two vectors.
+type DistanceMetric string
+
+const (
+	// Cosine scores documents by the cosine similarity of their vectors.
+	Cosine DistanceMetric = "cosine"
+	// DotProduct scores documents by the dot product of their vectors. It is
+	// equivalent to Cosine for normalized vectors.
+	DotProduct DistanceMetric = "dot"
+	// Euclidean scores documents by their euclidean (L2) distance d, reported
+	// as the similarity 1/(1+d) so that higher scores are always better.
+	Euclidean DistanceMetric = "l2"
+)
+
+// record is a single stored document and its vector.
+type record struct {
+	ID       string         `json:"id"`
+	Content  string         `json:"content"`
+	Metadata map[string]any `json:"metadata,omitempty"`
+	Vector   []float32      `json:"vector"`
+}
+
+// Store is a vector store that keeps its data in memory. It is safe for
+// concurrent use.
+type Store struct {
+	embedder  embeddings.Embedder
+	metric    DistanceMetric
+	nameSpace string
+
+	mu          sync.RWMutex
+	collections map[string][]record
+}
+
+var (
+	_ vectorstores.VectorStore    = &Store{}
+	_ vectorstores.VectorSearcher = &Store{}
+)
+
+// New creates a new empty Store with options. The WithEmbedder option must be
+// set.
+func New(opts ...Option) (*Store, error) {
+	return applyClientOptions(opts...)
+}
+
+// AddDocuments creates vector embeddings from the documents using the embedder
+// and stores them in the name space, returning the ids of the added documents.
+func (s *Store) AddDocuments(ctx context.Context,
+	docs []schema.Document,
+	options ...vectorstores.Option,
+) ([]string, error) {
+	opts := s.getOptions(options...)
+
+	docs = s.deduplicate(ctx, opts, docs)
+	if len(docs) == 0 {
+		return nil, nil
+	}
+
+	texts := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		texts = append(texts, doc.PageContent)
+	}
+
+	vectors, err := s.getEmbedder(opts).EmbedDocuments(ctx, texts)
+	if err != nil {
+		return nil, err
+	}
+	if len(vectors) != len(docs) {
+		return nil, ErrEmbedderWrongNumberVectors
+	}
+
+	records := make([]record, len(docs))
+	ids := make([]string, len(docs))
+	for i, doc := range docs {
+		ids[i] = uuid.New().String()
+		records[i] = record{
+			ID:       ids[i],
+			Content:  doc.PageContent,
+			Metadata: copyMetadata(doc.Metadata),
+			Vector:   vectors[i],
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	nameSpace := s.getNameSpace(opts)
+	if err := checkDimensions(s.collections[nameSpace], records); err != nil {
+		return nil, err
+	}
+	s.collections[nameSpace] = append(s.collections[nameSpace], records...)
+
+	return ids, nil
+}
+
+// SimilaritySearch creates a vector embedding from the query using the embedder
+// and returns the numDocuments most similar documents in the name space.
+func (s *Store) SimilaritySearch(ctx context.Context, query string, numDocuments int, options ...vectorstores.Option) ([]schema.Document, error) { //nolint:lll
+	result, err := s.SimilaritySearchVectors(ctx, query, numDocuments, options...)
+	return result.Documents, err
+}
+
+// SimilaritySearchVectors does the same as SimilaritySearch, also returning
+// the embedding of the query and the stored embeddings of the documents.
+func (s *Store) SimilaritySearchVectors(ctx context.Context, query string, numDocuments int, options ...vectorstores.Option) (vectorstores.VectorSearchResult, error) { //nolint:lll
+	opts := s.getOptions(options...)
+
+	scoreThreshold, err := s.getScoreThreshold(opts)
+	if err != nil {
+		return vectorstores.VectorSearchResult{}, err
+	}
+	filter, err := newFilter(opts.Filters)
+	if err != nil {
+		return vectorstores.VectorSearchResult{}, err
+	}
+
+	vector, err := s.getEmbedder(opts).EmbedQuery(ctx, query)
+	if err != nil {
+		return vectorstores.VectorSearchResult{}, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	records := s.collections[s.getNameSpace(opts)]
+	if len(records) > 0 && len(records[0].Vector) != len(vector) {
+		return vectorstores.VectorSearchResult{}, ErrDimensionMismatch
+	}
+
+	type match struct {
+		doc    schema.Document
+		vector []float32
+	}
+	var matches []match
+	for _, r := range records {
+		if !filter.match(r.Metadata) {
+			continue
+		}
+		score := s.score(vector, r.Vector)
+		if scoreThreshold != 0 && score < scoreThreshold {
+			continue
+		}
+		matches = append(matches, match{
+			doc: schema.Document{
+				PageContent: r.Content,
+				Metadata:    copyMetadata(r.Metadata),
+				Score:       score,
+			},
+			vector: r.Vector,
+		})
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].doc.Score > matches[j].doc.Score
+	})
+	if numDocuments >= 0 && len(matches) > numDocuments {
+		matches = matches[:numDocuments]
+	}
+
+	result := vectorstores.VectorSearchResult{
+		QueryVector: vector,
+		Documents:   make([]schema.Document, len(matches)),
+		Vectors:     make([][]float32, len(matches)),
+	}
+	for i, m := range matches {
+		result.Documents[i] = m.doc
+		result.Vectors[i] = slices.Clone(m.vector)
+	}
+	return result, nil
+}
+
+// Len returns the number of documents stored in the name space.
+func (s *Store) Len(nameSpace string) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.collections[nameSpace])
+}
+
+// score computes the similarity of two vectors with the store's metric.
+func (s *Store) score(a, b []float32) float32 {
+	switch s.metric {
+	case DotProduct:
+		return dot(a, b)
+	case Euclidean:
+		var sum float64
+		for i := range a {
+			d := float64(a[i] - b[i])
+			sum += d * d
+		}
+		return float32(1 / (1 + math.Sqrt(sum)))
+	case Cosine:
+		fallthrough
+	default:
+		normA, normB := dot(a, a), dot(b, b)
+		if normA == 0 || normB == 0 {
+			return 0
+		}
+		return dot(a, b) / float32(math.Sqrt(float64(normA))*math.Sqrt(float64(normB)))
+	}
+}
+
+func dot(a, b []float32) float32 {
+	var sum float32
+	for i := range a {
+		sum += a[i] * b[i]
+	}
+	return sum
+}
+
+// checkDimensions verifies that all new records have the same dimension as
+// the existing ones.
+func checkDimensions(existing, added []record) error {
+	dim := -1
+	if len(existing) > 0 {
+		dim = len(existing[0].Vector)
+	}
+	for _, r := range added {
+		if dim == -1 {
+			dim = len(r.Vector)
+		}
+		if len(r.Vector) != dim {
+			return ErrDimensionMismatch
+		}
+	}
+	return nil
+}
+
+func copyMetadata(metadata map[string]any) map[string]any {
+	if metadata == nil {
+		return nil
+	}
+	c := make(map[string]any, len(metadata))
+	for k, v := range metadata {
+		c[k] = v
+	}
+	return c
+}
+
+func (s *Store) deduplicate(
+	ctx context.Context,
+	opts vectorstores.Options,
+	docs []schema.Document,
+) []schema.Document {
+	if opts.Deduplicater == nil {
+		return docs
+	}
+
+	filtered := make([]schema.Document, 0, len(docs))
+	for _, doc := range docs {
+		if !opts.Deduplicater(ctx, doc) {
+			filtered = append(filtered, doc)
+		}
+	}
+
+	return filtered
+}
+
+func (s *Store) getEmbedder(opts vectorstores.Options) embeddings.Embedder {
+	if opts.Embedder != nil {
+		return opts.Embedder
+	}
+	return s.embedder
+}
+
+func (s *Store) getNameSpace(opts vectorstores.Options) string {
+	if opts.NameSpace != "" {
+		return opts.NameSpace
+	}
+	return s.nameSpace
+}
+
+func (s *Store) getScoreThreshold(opts vectorstores.Options) (float32, error) {
+	if s.metric != DotProduct && (opts.ScoreThreshold < 0 || opts.ScoreThreshold > 1) {
+		return 0, ErrInvalidScoreThreshold
+	}
+	return opts.ScoreThreshold, nil
+}
+
+func (s *Store) getOptions(options ...vectorstores.Option) vectorstores.Options {
+	opts := vectorstores.Options{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	return opts
+}
@@ -0,0 +1,263 @@
+package inmemory_test
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/vectorstores"
+	"github.com/tmc/langchaingo/vectorstores/filter"
+	"github.com/tmc/langchaingo/vectorstores/inmemory"
+)
+
+// keywordEmbedder embeds a text as the count of each keyword in it, which
+// makes similarity between texts easy to reason about in tests.
+type keywordEmbedder struct{}
+
+var keywords = []string{"cat", "dog", "fish", "bird"}
+
+func (keywordEmbedder) EmbedDocuments(_ context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		vectors[i] = embed(text)
+	}
+	return vectors, nil
+}
+
+func (keywordEmbedder) EmbedQuery(_ context.Context, text string) ([]float32, error) {
+	return embed(text), nil
+}
+
+func embed(text string) []float32 {
+	v := make([]float32, len(keywords))
+	for i, kw := range keywords {
+		v[i] = float32(strings.Count(text, kw))
+	}
+	return v
+}
+
+func newTestStore(t *testing.T, opts ...inmemory.Option) *inmemory.Store {
+	t.Helper()
+	store, err := inmemory.New(append([]inmemory.Option{inmemory.WithEmbedder(keywordEmbedder{})}, opts...)...)
+	require.NoError(t, err)
+
+	_, err = store.AddDocuments(context.Background(), []schema.Document{
+		{PageContent: "cat cat", Metadata: map[string]any{"kind": "mammal", "legs": 4}},
+		{PageContent: "dog", Metadata: map[string]any{"kind": "mammal", "legs": 4}},
+		{PageContent: "fish", Metadata: map[string]any{"kind": "fish", "legs": 0}},
+		{PageContent: "bird cat", Metadata: map[string]any{"kind": "bird", "legs": 2}},
+	})
+	require.NoError(t, err)
+	return store
+}
+
+func TestSimilaritySearch(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	for _, metric := range []inmemory.DistanceMetric{inmemory.Cosine, inmemory.DotProduct, inmemory.Euclidean} {
+		metric := metric
+		t.Run(string(metric), func(t *testing.T) {
+			t.Parallel()
+			store := newTestStore(t, inmemory.WithDistanceMetric(metric))
+
+			docs, err := store.SimilaritySearch(ctx, "cat", 2)
+			require.NoError(t, err)
+			require.Len(t, docs, 2)
+			require.Equal(t, "cat cat", docs[0].PageContent)
+			require.Equal(t, "bird cat", docs[1].PageContent)
+			require.GreaterOrEqual(t, docs[0].Score, docs[1].Score)
+		})
+	}
+}
+
+func TestSimilaritySearchOptions(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	docs, err := store.SimilaritySearch(ctx, "cat", 4, vectorstores.WithScoreThreshold(0.8))
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	require.Equal(t, "cat cat", docs[0].PageContent)
+
+	_, err = store.SimilaritySearch(ctx, "cat", 4, vectorstores.WithScoreThreshold(1.5))
+	require.ErrorIs(t, err, inmemory.ErrInvalidScoreThreshold)
+
+	docs, err = store.SimilaritySearch(ctx, "cat", -1)
+	require.NoError(t, err)
+	require.Len(t, docs, 4)
+
+	dotStore := newTestStore(t, inmemory.WithDistanceMetric(inmemory.DotProduct))
+	docs, err = dotStore.SimilaritySearch(ctx, "cat", 4, vectorstores.WithScoreThreshold(1.5))
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	require.Equal(t, "cat cat", docs[0].PageContent)
+
+	docs, err = store.SimilaritySearch(ctx, "cat", 4, vectorstores.WithFilters(map[string]any{
+		"kind": "mammal",
+	}))
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	require.Equal(t, "cat cat", docs[0].PageContent)
+	require.Equal(t, "dog", docs[1].PageContent)
+
+	docs, err = store.SimilaritySearch(ctx, "cat", 4, vectorstores.WithFilters(map[string]any{
+		"legs": map[string]any{"$gt": 0, "$lt": 4},
+	}))
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	require.Equal(t, "bird cat", docs[0].PageContent)
+
+	docs, err = store.SimilaritySearch(ctx, "cat", 4, vectorstores.WithFilters(map[string]any{
+		"kind": map[string]any{"$in": []string{"fish", "bird"}},
+	}))
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+
+	_, err = store.SimilaritySearch(ctx, "cat", 4, vectorstores.WithFilters(map[string]any{
+		"kind": map[string]any{"$like": "m%"},
+	}))
+	require.ErrorIs(t, err, inmemory.ErrInvalidFilters)
+
+	docs, err = store.SimilaritySearch(ctx, "cat", 4, vectorstores.WithNameSpace("other"))
+	require.NoError(t, err)
+	require.Empty(t, docs)
+}
+
+func TestSimilaritySearchFilterExpr(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	docs, err := store.SimilaritySearch(ctx, "cat", 4, vectorstores.WithFilters(filter.Or(
+		filter.Eq("kind", "fish"),
+		filter.And(filter.Eq("kind", "mammal"), filter.Not(filter.Lt("legs", 4))),
+	)))
+	require.NoError(t, err)
+	require.Len(t, docs, 3)
+	require.Equal(t, "cat cat", docs[0].PageContent)
+
+	docs, err = store.SimilaritySearch(ctx, "cat", 4, vectorstores.WithFilters(
+		filter.Nin("kind", []string{"mammal", "fish"}),
+	))
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	require.Equal(t, "bird cat", docs[0].PageContent)
+
+	for _, e := range []filter.Expr{{Op: "like", Key: "kind"}, filter.Not(filter.And())} {
+		_, err = store.SimilaritySearch(ctx, "cat", 4, vectorstores.WithFilters(e))
+		require.ErrorIs(t, err, inmemory.ErrInvalidFilters)
+		require.ErrorIs(t, err, filter.ErrInvalidFilter)
+	}
+}
+
+func TestMaxMarginalRelevanceSearch(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	store := newTestStore(t)
+	_, err := store.AddDocuments(ctx, []schema.Document{{PageContent: "cat"}})
+	require.NoError(t, err)
+
+	// "cat" and "cat cat" are the most similar but embed to the same
+	// direction, so the second document selected is "bird cat".
+	docs, err := vectorstores.MaxMarginalRelevanceSearch(ctx, store, "cat", 2, 3, 0.4)
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	require.Contains(t, []string{"cat", "cat cat"}, docs[0].PageContent)
+	require.Equal(t, "bird cat", docs[1].PageContent)
+
+	retriever := vectorstores.ToRetriever(store, 2, vectorstores.WithMaxMarginalRelevance(3, 0.4))
+	docs, err = retriever.GetRelevantDocuments(ctx, "cat")
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	require.Equal(t, "bird cat", docs[1].PageContent)
+
+	docs, err = vectorstores.ToRetriever(store, 2).GetRelevantDocuments(ctx, "cat")
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"cat", "cat cat"}, []string{docs[0].PageContent, docs[1].PageContent})
+}
+
+func TestAddDocumentsDeduplicater(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	ids, err := store.AddDocuments(ctx, []schema.Document{
+		{PageContent: "dog"},
+		{PageContent: "dog dog"},
+	}, vectorstores.WithDeduplicater(func(_ context.Context, doc schema.Document) bool {
+		return doc.PageContent == "dog"
+	}))
+	require.NoError(t, err)
+	require.Len(t, ids, 1)
+	require.Equal(t, 5, store.Len(inmemory.DefaultNameSpace))
+}
+
+func TestSaveLoad(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	store := newTestStore(t)
+	path := filepath.Join(t.TempDir(), "store.json")
+	require.NoError(t, store.Save(path))
+
+	loaded, err := inmemory.New(inmemory.WithEmbedder(keywordEmbedder{}))
+	require.NoError(t, err)
+	require.NoError(t, loaded.Load(path))
+	require.Equal(t, 4, loaded.Len(inmemory.DefaultNameSpace))
+
+	docs, err := loaded.SimilaritySearch(ctx, "fish", 1)
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	require.Equal(t, "fish", docs[0].PageContent)
+	require.Equal(t, map[string]any{"kind": "fish", "legs": float64(0)}, docs[0].Metadata)
+
+	mismatched, err := inmemory.New(
+		inmemory.WithEmbedder(keywordEmbedder{}),
+		inmemory.WithDistanceMetric(inmemory.Euclidean),
+	)
+	require.NoError(t, err)
+	require.ErrorIs(t, mismatched.Load(path), inmemory.ErrInvalidOptions)
+}
+
+func TestDocumentManagement(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	store, err := inmemory.New(inmemory.WithEmbedder(keywordEmbedder{}))
+	require.NoError(t, err)
+
+	ids, err := store.UpsertDocuments(ctx, []string{"a", "", "c"}, []schema.Document{
+		{PageContent: "cat", Metadata: map[string]any{"source": "x"}},
+		{PageContent: "dog", Metadata: map[string]any{"source": "x"}},
+		{PageContent: "fish", Metadata: map[string]any{"source": "y"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "a", ids[0])
+	require.NotEmpty(t, ids[1])
+	require.Equal(t, "c", ids[2])
+
+	_, err = store.UpsertDocuments(ctx, []string{"a"}, []schema.Document{{PageContent: "bird"}})
+	require.NoError(t, err)
+	require.Equal(t, 3, store.Len(inmemory.DefaultNameSpace))
+
+	docs, err := store.GetByIDs(ctx, []string{"c", "missing", "a"})
+	require.NoError(t, err)
+	require.Equal(t, []schema.Document{
+		{PageContent: "fish", Metadata: map[string]any{"source": "y"}},
+		{PageContent: "bird"},
+	}, docs)
+
+	require.ErrorIs(t, store.DeleteDocuments(ctx, nil), vectorstores.ErrNoDocumentsSelected)
+
+	require.NoError(t, store.DeleteDocuments(ctx, nil, vectorstores.WithFilters(map[string]any{"source": "x"})))
+	require.Equal(t, 2, store.Len(inmemory.DefaultNameSpace))
+
+	require.NoError(t, vectorstores.DeleteDocuments(ctx, store, []string{"a"}))
+	docs, err = store.SimilaritySearch(ctx, "fish", 5)
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	require.Equal(t, "fish", docs[0].PageContent)
+}
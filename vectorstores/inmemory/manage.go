@@ -0,0 +1,145 @@
+package inmemory
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/vectorstores"
+)
+
+var _ vectorstores.DocumentManager = &Store{}
+
+// DeleteDocuments removes the documents with the given ids from the name
+// space, or all documents matching the filters if ids is empty.
+func (s *Store) DeleteDocuments(_ context.Context, ids []string, options ...vectorstores.Option) error {
+	opts := s.getOptions(options...)
+	if len(ids) == 0 && opts.Filters == nil {
+		return vectorstores.ErrNoDocumentsSelected
+	}
+	filter, err := newFilter(opts.Filters)
+	if err != nil {
+		return err
+	}
+	remove := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		remove[id] = true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	nameSpace := s.getNameSpace(opts)
+	records := s.collections[nameSpace]
+	kept := records[:0]
+	for _, r := range records {
+		selected := remove[r.ID]
+		if len(ids) == 0 {
+			selected = filter.match(r.Metadata)
+		}
+		if !selected {
+			kept = append(kept, r)
+		}
+	}
+	// Clear the tail so removed records can be garbage collected.
+	for i := len(kept); i < len(records); i++ {
+		records[i] = record{}
+	}
+	s.collections[nameSpace] = kept
+
+	return nil
+}
+
+// UpsertDocuments embeds docs and stores them under ids, replacing documents
+// already stored under the same id in the name space.
+func (s *Store) UpsertDocuments(
+	ctx context.Context,
+	ids []string,
+	docs []schema.Document,
+	options ...vectorstores.Option,
+) ([]string, error) {
+	if len(ids) != len(docs) {
+		return nil, vectorstores.ErrMismatchedIDs
+	}
+	opts := s.getOptions(options...)
+
+	texts := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		texts = append(texts, doc.PageContent)
+	}
+	vectors, err := s.getEmbedder(opts).EmbedDocuments(ctx, texts)
+	if err != nil {
+		return nil, err
+	}
+	if len(vectors) != len(docs) {
+		return nil, ErrEmbedderWrongNumberVectors
+	}
+
+	upserted := make([]record, len(docs))
+	resultIDs := make([]string, len(docs))
+	for i, doc := range docs {
+		id := ids[i]
+		if id == "" {
+			id = uuid.New().String()
+		}
+		resultIDs[i] = id
+		upserted[i] = record{
+			ID:       id,
+			Content:  doc.PageContent,
+			Metadata: copyMetadata(doc.Metadata),
+			Vector:   vectors[i],
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	nameSpace := s.getNameSpace(opts)
+	records := s.collections[nameSpace]
+	if err := checkDimensions(records, upserted); err != nil {
+		return nil, err
+	}
+
+	index := make(map[string]int, len(records))
+	for i, r := range records {
+		index[r.ID] = i
+	}
+	for _, r := range upserted {
+		if i, ok := index[r.ID]; ok {
+			records[i] = r
+			continue
+		}
+		index[r.ID] = len(records)
+		records = append(records, r)
+	}
+	s.collections[nameSpace] = records
+
+	return resultIDs, nil
+}
+
+// GetByIDs returns the documents stored under ids in the name space.
+func (s *Store) GetByIDs(_ context.Context, ids []string, options ...vectorstores.Option) ([]schema.Document, error) {
+	opts := s.getOptions(options...)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	records := s.collections[s.getNameSpace(opts)]
+	index := make(map[string]int, len(records))
+	for i, r := range records {
+		index[r.ID] = i
+	}
+
+	docs := make([]schema.Document, 0, len(ids))
+	for _, id := range ids {
+		i, ok := index[id]
+		if !ok {
+			continue
+		}
+		docs = append(docs, schema.Document{
+			PageContent: records[i].Content,
+			Metadata:    copyMetadata(records[i].Metadata),
+		})
+	}
+	return docs, nil
+}
@@ -0,0 +1,69 @@
+package inmemory
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tmc/langchaingo/embeddings"
+)
+
+const (
+	// DefaultNameSpace is the name space used when none is given.
+	DefaultNameSpace = "langchain"
+	// DefaultDistanceMetric is the metric used when none is given.
+	DefaultDistanceMetric = Cosine
+)
+
+// ErrInvalidOptions is returned when the options given are invalid.
+var ErrInvalidOptions = errors.New("invalid options")
+
+// Option is a function type that can be used to modify the store.
+type Option func(s *Store)
+
+// WithEmbedder is an option for setting the embedder to use. Must be set.
+func WithEmbedder(e embeddings.Embedder) Option {
+	return func(s *Store) {
+		s.embedder = e
+	}
+}
+
+// WithDistanceMetric is an option for setting the metric used to compare
+// vectors. The default is Cosine.
+func WithDistanceMetric(metric DistanceMetric) Option {
+	return func(s *Store) {
+		s.metric = metric
+	}
+}
+
+// WithNameSpace is an option for setting the default name space documents
+// are added to and searched in. It can be overridden per call with
+// vectorstores.WithNameSpace.
+func WithNameSpace(nameSpace string) Option {
+	return func(s *Store) {
+		s.nameSpace = nameSpace
+	}
+}
+
+func applyClientOptions(opts ...Option) (*Store, error) {
+	s := &Store{
+		metric:      DefaultDistanceMetric,
+		nameSpace:   DefaultNameSpace,
+		collections: make(map[string][]record),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if s.embedder == nil {
+		return nil, fmt.Errorf("%w: missing embedder", ErrInvalidOptions)
+	}
+
+	switch s.metric {
+	case Cosine, DotProduct, Euclidean:
+	default:
+		return nil, fmt.Errorf("%w: unknown distance metric %q", ErrInvalidOptions, s.metric)
+	}
+
+	return s, nil
+}
@@ -0,0 +1,93 @@
+package inmemory
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// fileFormatVersion is the version of the format written by Save.
+const fileFormatVersion = 1
+
+// ErrUnsupportedFileVersion is returned by Load when the file was written by
+// an incompatible version of the store.
+var ErrUnsupportedFileVersion = errors.New("unsupported file version")
+
+type persistedStore struct {
+	Version     int                 `json:"version"`
+	Metric      DistanceMetric      `json:"metric"`
+	Collections map[string][]record `json:"collections"`
+}
+
+// Save writes the contents of the store to the file at path. The file is
+// written to a temporary file first and renamed, so an existing file is never
+// left half written.
+func (s *Store) Save(path string) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if err := s.Export(f); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// Load replaces the contents of the store with the contents of the file at
+// path, as written by Save.
+func (s *Store) Load(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return s.Import(f)
+}
+
+// Export writes the contents of the store to w.
+func (s *Store) Export(w io.Writer) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return json.NewEncoder(w).Encode(persistedStore{
+		Version:     fileFormatVersion,
+		Metric:      s.metric,
+		Collections: s.collections,
+	})
+}
+
+// Import replaces the contents of the store with the contents read from r,
+// as written by Export. The distance metric must match the one the store was
+// created with.
+func (s *Store) Import(r io.Reader) error {
+	var p persistedStore
+	if err := json.NewDecoder(r).Decode(&p); err != nil {
+		return fmt.Errorf("decode store: %w", err)
+	}
+	if p.Version != fileFormatVersion {
+		return fmt.Errorf("%w: %d", ErrUnsupportedFileVersion, p.Version)
+	}
+	if p.Metric != s.metric {
+		return fmt.Errorf("%w: file uses distance metric %q, store uses %q",
+			ErrInvalidOptions, p.Metric, s.metric)
+	}
+	if p.Collections == nil {
+		p.Collections = make(map[string][]record)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.collections = p.Collections
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package vectorstores
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/tmc/langchaingo/schema"
+)
+
+// DefaultBatchSize is the number of documents AddDocumentsLazy adds at once
+// when none is given.
+const DefaultBatchSize = 100
+
+// AddDocumentsLazy adds the documents of it to the vector store in batches of
+// batchSize, or DefaultBatchSize if it isn't positive, and returns the ids of
+// all the documents. Documents are read from it only as batches are added, so
+// a lazy loader is read at the pace of the vector store. It doesn't close it.
+func AddDocumentsLazy(
+	ctx context.Context,
+	store VectorStore,
+	it schema.DocumentIterator,
+	batchSize int,
+	options ...Option,
+) ([]string, error) {
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	var ids []string
+	batch := make([]schema.Document, 0, batchSize)
+	for {
+		doc, err := it.Next()
+		if err != nil && !errors.Is(err, io.EOF) {
+			return ids, err
+		}
+		if err == nil {
+			batch = append(batch, doc)
+			if len(batch) < batchSize {
+				continue
+			}
+		}
+
+		if len(batch) > 0 {
+			batchIDs, addErr := store.AddDocuments(ctx, batch, options...)
+			if addErr != nil {
+				return ids, addErr
+			}
+			ids = append(ids, batchIDs...)
+			batch = make([]schema.Document, 0, batchSize)
+		}
+		if err != nil {
+			return ids, nil
+		}
+	}
+}
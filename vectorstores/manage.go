@@ -0,0 +1,95 @@
+package vectorstores
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/tmc/langchaingo/schema"
+)
+
+var (
+	// ErrUnsupported is returned by the helper functions in this file when the
+	// vector store doesn't implement the optional interface they need.
+	ErrUnsupported = errors.New("operation not supported by vector store")
+	// ErrNoDocumentsSelected is returned by DeleteDocuments when neither ids
+	// nor filters are given, to avoid deleting a whole collection by accident.
+	ErrNoDocumentsSelected = errors.New("no ids or filters given")
+	// ErrMismatchedIDs is returned by UpsertDocuments when the number of ids
+	// is not equal to the number of documents.
+	ErrMismatchedIDs = errors.New("number of ids does not match number of documents")
+)
+
+// Deleter is an optional interface for vector stores that can remove
+// documents after they were added.
+type Deleter interface {
+	// DeleteDocuments removes the documents with the given ids. If ids is
+	// empty, it removes all documents matching the filters given with
+	// WithFilters instead; filters are ignored when ids are given. If both are
+	// empty ErrNoDocumentsSelected is returned. Ids that don't exist are
+	// ignored.
+	DeleteDocuments(ctx context.Context, ids []string, options ...Option) error
+}
+
+// Upserter is an optional interface for vector stores that can add documents
+// under caller chosen ids, replacing any documents already stored under them.
+type Upserter interface {
+	// UpsertDocuments embeds and stores docs under the given ids, which must
+	// have the same length as docs. An empty id is replaced by a new one.
+	// It returns the ids the documents were stored under.
+	UpsertDocuments(ctx context.Context, ids []string, docs []schema.Document, options ...Option) ([]string, error)
+}
+
+// Getter is an optional interface for vector stores that can look up
+// documents by id.
+type Getter interface {
+	// GetByIDs returns the documents stored under the given ids, in the same
+	// order. Ids that don't exist are skipped, so the result may be shorter
+	// than ids.
+	GetByIDs(ctx context.Context, ids []string, options ...Option) ([]schema.Document, error)
+}
+
+// DocumentManager is implemented by vector stores that support all of the
+// optional document management operations.
+type DocumentManager interface {
+	VectorStore
+	Deleter
+	Upserter
+	Getter
+}
+
+// DeleteDocuments deletes documents from vs if it implements Deleter and
+// returns ErrUnsupported otherwise.
+func DeleteDocuments(ctx context.Context, vs VectorStore, ids []string, options ...Option) error {
+	d, ok := vs.(Deleter)
+	if !ok {
+		return fmt.Errorf("%w: %T does not implement Deleter", ErrUnsupported, vs)
+	}
+	return d.DeleteDocuments(ctx, ids, options...)
+}
+
+// UpsertDocuments upserts documents into vs if it implements Upserter and
+// returns ErrUnsupported otherwise.
+func UpsertDocuments(
+	ctx context.Context,
+	vs VectorStore,
+	ids []string,
+	docs []schema.Document,
+	options ...Option,
+) ([]string, error) {
+	u, ok := vs.(Upserter)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T does not implement Upserter", ErrUnsupported, vs)
+	}
+	return u.UpsertDocuments(ctx, ids, docs, options...)
+}
+
+// GetByIDs looks up documents in vs if it implements Getter and returns
+// ErrUnsupported otherwise.
+func GetByIDs(ctx context.Context, vs VectorStore, ids []string, options ...Option) ([]schema.Document, error) {
+	g, ok := vs.(Getter)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T does not implement Getter", ErrUnsupported, vs)
+	}
+	return g.GetByIDs(ctx, ids, options...)
+}
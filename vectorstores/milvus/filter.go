@@ -0,0 +1,82 @@
+package milvus
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/tmc/langchaingo/vectorstores"
+	"github.com/tmc/langchaingo/vectorstores/filter"
+)
+
+var _comparisons = map[filter.Operator]string{ //nolint:gochecknoglobals
+	filter.OpEq:  "==",
+	filter.OpNe:  "!=",
+	filter.OpGt:  ">",
+	filter.OpGte: ">=",
+	filter.OpLt:  "<",
+	filter.OpLte: "<=",
+	filter.OpIn:  "in",
+	filter.OpNin: "not in",
+}
+
+// getFilters returns the boolean expression of the filters, which are either
+// a Milvus expression or a filter.Expr on the metadata field.
+func (s Store) getFilters(opts vectorstores.Options) (string, error) {
+	switch filters := opts.Filters.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return filters, nil
+	case filter.Expr:
+		if err := filters.Validate(); err != nil {
+			return "", fmt.Errorf("%w: %w", ErrInvalidFilters, err)
+		}
+		return s.exprString(filters), nil
+	default:
+		return "", ErrInvalidFilters
+	}
+}
+
+// exprString translates e to a boolean expression, or "" for an expression
+// matching everything.
+func (s Store) exprString(e filter.Expr) string {
+	if e.MatchesAll() {
+		return ""
+	}
+	switch e.Op { //nolint:exhaustive
+	case filter.OpAnd, filter.OpOr:
+		operands := make([]string, 0, len(e.Operands))
+		for _, operand := range e.Operands {
+			// Operands matching everything are left out of And, and Or has none.
+			if expr := s.exprString(operand); expr != "" {
+				operands = append(operands, "("+expr+")")
+			}
+		}
+		return strings.Join(operands, " "+string(e.Op)+" ")
+	case filter.OpNot:
+		return "not (" + s.exprString(e.Operands[0]) + ")"
+	}
+
+	field := s.metaField + "[" + strconv.Quote(e.Key) + "]"
+	if e.Op == filter.OpIn || e.Op == filter.OpNin {
+		values := e.Values()
+		literals := make([]string, len(values))
+		for i, value := range values {
+			literals[i] = literal(value)
+		}
+		return fmt.Sprintf("%s %s [%s]", field, _comparisons[e.Op], strings.Join(literals, ", "))
+	}
+	return fmt.Sprintf("%s %s %s", field, _comparisons[e.Op], literal(e.Value))
+}
+
+func literal(value any) string {
+	switch v := value.(type) {
+	case string:
+		return strconv.Quote(v)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	number, _ := filter.Number(value)
+	return strconv.FormatFloat(number, 'g', -1, 64)
+}
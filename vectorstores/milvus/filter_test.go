@@ -0,0 +1,69 @@
+package milvus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/vectorstores"
+	"github.com/tmc/langchaingo/vectorstores/filter"
+)
+
+func TestGetFilters(t *testing.T) {
+	t.Parallel()
+
+	s := Store{metaField: "meta"}
+	tests := []struct {
+		name string
+		expr filter.Expr
+		want string
+	}{
+		{
+			"not over and",
+			filter.Not(filter.And(filter.Eq("kind", "manual"), filter.Gt("year", 2020))),
+			`not ((meta["kind"] == "manual") and (meta["year"] > 2020))`,
+		},
+		{
+			"not over or",
+			filter.Not(filter.Or(filter.Eq("kind", "manual"), filter.Lt("year", 2020))),
+			`not ((meta["kind"] == "manual") or (meta["year"] < 2020))`,
+		},
+		{
+			"nin",
+			filter.Nin("lang", []string{"de", "fr"}),
+			`meta["lang"] not in ["de", "fr"]`,
+		},
+		{
+			"numeric range",
+			filter.And(filter.Gte("year", 2020), filter.Lt("year", 2024.5)),
+			`(meta["year"] >= 2020) and (meta["year"] < 2024.5)`,
+		},
+		{
+			"string quoting",
+			filter.Eq(`it's "q"`, `it's "q"\`),
+			`meta["it's \"q\""] == "it's \"q\"\\"`,
+		},
+		{
+			"matches everything",
+			filter.Or(filter.Eq("kind", "manual"), filter.And(filter.Nin("lang", []string{}))),
+			"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			expr, err := s.getFilters(vectorstores.Options{Filters: tt.expr})
+			require.NoError(t, err)
+			require.Equal(t, tt.want, expr)
+		})
+	}
+
+	for _, e := range []filter.Expr{
+		filter.In("kind", []string{}),
+		filter.Or(),
+		filter.And(filter.Eq("kind", "manual"), filter.Not(filter.And())),
+	} {
+		_, err := s.getFilters(vectorstores.Options{Filters: e})
+		require.ErrorIs(t, err, ErrInvalidFilters)
+		require.ErrorIs(t, err, filter.ErrInvalidFilter)
+	}
+}
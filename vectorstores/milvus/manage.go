@@ -0,0 +1,127 @@
+package milvus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/client"
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/vectorstores"
+)
+
+// Milvus generates the primary keys of the collections created by Store, so
+// it doesn't implement vectorstores.Upserter.
+
+// DeleteDocuments deletes the documents with the given primary keys, as
+// returned by AddDocuments, or all documents matching the boolean expression
+// given as filters if ids is empty.
+func (s Store) DeleteDocuments(ctx context.Context, ids []string, options ...vectorstores.Option) error {
+	opts := s.getOptions(options...)
+	expr, err := s.getFilters(opts)
+	if err != nil {
+		return err
+	}
+	if len(ids) > 0 {
+		if expr, err = s.primaryKeyExpr(ids); err != nil {
+			return err
+		}
+	}
+	if expr == "" {
+		return vectorstores.ErrNoDocumentsSelected
+	}
+	if err := s.init(ctx, 0); err != nil {
+		return err
+	}
+	return s.client.Delete(ctx, s.collectionName, s.partitionName, expr)
+}
+
+// GetByIDs returns the documents with the given primary keys.
+func (s Store) GetByIDs(ctx context.Context, ids []string, _ ...vectorstores.Option) ([]schema.Document, error) {
+	if len(ids) == 0 || s.schema == nil {
+		return nil, nil
+	}
+	expr, err := s.primaryKeyExpr(ids)
+	if err != nil {
+		return nil, err
+	}
+	partitions := []string{}
+	if s.partitionName != "" {
+		partitions = append(partitions, s.partitionName)
+	}
+	resultSet, err := s.client.Query(ctx,
+		s.collectionName,
+		partitions,
+		expr,
+		[]string{s.primaryField, s.textField, s.metaField},
+		client.WithSearchQueryConsistencyLevel(s.consistencyLevel),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := columnStrings(resultSet.GetColumn(s.primaryField))
+	if err != nil {
+		return nil, err
+	}
+	textcol, ok := resultSet.GetColumn(s.textField).(*entity.ColumnVarChar)
+	if !ok {
+		return nil, fmt.Errorf("%w: text column missing", ErrColumnNotFound)
+	}
+	metacol, ok := resultSet.GetColumn(s.metaField).(*entity.ColumnJSONBytes)
+	if !ok {
+		return nil, fmt.Errorf("%w: metadata column missing", ErrColumnNotFound)
+	}
+
+	found := make(map[string]schema.Document, len(keys))
+	for i, key := range keys {
+		doc := schema.Document{}
+		if doc.PageContent, err = textcol.ValueByIdx(i); err != nil {
+			return nil, err
+		}
+		metaStr, err := metacol.ValueByIdx(i)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(metaStr, &doc.Metadata); err != nil {
+			return nil, err
+		}
+		found[key] = doc
+	}
+
+	docs := make([]schema.Document, 0, len(found))
+	for _, id := range ids {
+		if doc, ok := found[id]; ok {
+			docs = append(docs, doc)
+		}
+	}
+	return docs, nil
+}
+
+// primaryKeyExpr returns a boolean expression matching the primary keys ids.
+func (s Store) primaryKeyExpr(ids []string) (string, error) {
+	varChar := false
+	if s.schema != nil {
+		for _, f := range s.schema.Fields {
+			if f.PrimaryKey {
+				varChar = f.DataType == entity.FieldTypeVarChar
+			}
+		}
+	}
+
+	values := make([]string, len(ids))
+	for i, id := range ids {
+		if varChar {
+			values[i] = strconv.Quote(id)
+			continue
+		}
+		if _, err := strconv.ParseInt(id, 10, 64); err != nil {
+			return "", fmt.Errorf("%w: invalid primary key %q", ErrInvalidFilters, id)
+		}
+		values[i] = id
+	}
+	return fmt.Sprintf("%s in [%s]", s.primaryField, strings.Join(values, ",")), nil
+}
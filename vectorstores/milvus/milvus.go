@@ -39,7 +39,10 @@ type Store struct {
 }
 
 var (
-	_ vectorstores.VectorStore = Store{}
+	_ vectorstores.VectorStore    = Store{}
+	_ vectorstores.Deleter        = Store{}
+	_ vectorstores.Getter         = Store{}
+	_ vectorstores.VectorSearcher = Store{}
 
 	ErrEmbedderWrongNumberVectors = errors.New(
 		"number of vectors from embedder does not match number of documents",
@@ -191,7 +194,7 @@ func (s *Store) load(ctx context.Context) error {
 }
 
 // AddDocuments adds the text and metadata from the documents to the Milvus collection associated with 'Store'.
-// and returns the ids of the added documents.
+// and returns the primary keys of the added documents.
 func (s Store) AddDocuments(ctx context.Context, docs []schema.Document,
 	_ ...vectorstores.Option,
 ) ([]string, error) {
@@ -222,17 +225,33 @@ func (s Store) AddDocuments(ctx context.Context, docs []schema.Document,
 		colsData = append(colsData, docMap)
 	}
 
-	_, err = s.client.InsertRows(ctx, s.collectionName, s.partitionName, colsData)
+	idCol, err := s.client.InsertRows(ctx, s.collectionName, s.partitionName, colsData)
 	if err != nil {
 		return nil, err
 	}
 	if err = s.client.Flush(ctx, s.collectionName, false); err != nil {
 		return nil, err
 	}
-	return nil, nil
+	return columnStrings(idCol)
 }
 
-func (s *Store) getSearchFields() []string {
+// columnStrings returns the values of col formatted as strings.
+func columnStrings(col entity.Column) ([]string, error) {
+	if col == nil {
+		return nil, nil
+	}
+	values := make([]string, col.Len())
+	for i := range values {
+		v, err := col.Get(i)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = fmt.Sprint(v)
+	}
+	return values, nil
+}
+
+func (s *Store) getSearchFields(withVector bool) []string {
 	fields := []string{}
 	for _, f := range s.schema.Fields {
 		if f.DataType == entity.FieldTypeBinaryVector || f.DataType == entity.FieldTypeFloatVector {
@@ -240,6 +259,9 @@ func (s *Store) getSearchFields() []string {
 		}
 		fields = append(fields, f.Name)
 	}
+	if withVector {
+		fields = append(fields, s.vectorField)
+	}
 	return fields
 }
 
@@ -251,8 +273,12 @@ func (s Store) getOptions(options ...vectorstores.Option) vectorstores.Options {
 	return opts
 }
 
-func (s Store) convertResultToDocument(searchResult []client.SearchResult) ([]schema.Document, error) {
+func (s Store) convertResultToDocument(
+	searchResult []client.SearchResult,
+	withVector bool,
+) ([]schema.Document, [][]float32, error) {
 	docs := []schema.Document{}
+	var vectors [][]float32
 	var err error
 
 	for _, res := range searchResult {
@@ -261,48 +287,71 @@ func (s Store) convertResultToDocument(searchResult []client.SearchResult) ([]sc
 		}
 		textcol, ok := res.Fields.GetColumn(s.textField).(*entity.ColumnVarChar)
 		if !ok {
-			return nil, fmt.Errorf("%w: text column missing", ErrColumnNotFound)
+			return nil, nil, fmt.Errorf("%w: text column missing", ErrColumnNotFound)
 		}
 		metacol, ok := res.Fields.GetColumn(s.metaField).(*entity.ColumnJSONBytes)
 		if !ok {
-			return nil, fmt.Errorf("%w: metadata column missing", ErrColumnNotFound)
+			return nil, nil, fmt.Errorf("%w: metadata column missing", ErrColumnNotFound)
+		}
+		var vectorcol *entity.ColumnFloatVector
+		if withVector {
+			vectorcol, ok = res.Fields.GetColumn(s.vectorField).(*entity.ColumnFloatVector)
+			if !ok {
+				return nil, nil, fmt.Errorf("%w: vector column missing", ErrColumnNotFound)
+			}
+			vectors = append(vectors, vectorcol.Data()[:res.ResultCount]...)
 		}
 		for i := 0; i < res.ResultCount; i++ {
 			doc := schema.Document{}
 
 			doc.PageContent, err = textcol.ValueByIdx(i)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 			metaStr, err := metacol.ValueByIdx(i)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 
 			if err := json.Unmarshal(metaStr, &doc.Metadata); err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 			doc.Score = res.Scores[i]
 			docs = append(docs, doc)
 		}
 	}
-	return docs, nil
+	return docs, vectors, nil
 }
 
 func (s Store) SimilaritySearch(ctx context.Context, query string, numDocuments int,
 	options ...vectorstores.Option,
 ) ([]schema.Document, error) {
+	result, err := s.similaritySearch(ctx, query, numDocuments, false, options...)
+	return result.Documents, err
+}
+
+// SimilaritySearchVectors does the same as SimilaritySearch, also returning
+// the embedding of the query and the stored embeddings of the documents.
+func (s Store) SimilaritySearchVectors(ctx context.Context, query string, numDocuments int,
+	options ...vectorstores.Option,
+) (vectorstores.VectorSearchResult, error) {
+	return s.similaritySearch(ctx, query, numDocuments, true, options...)
+}
+
+func (s Store) similaritySearch(ctx context.Context, query string, numDocuments int, withVector bool,
+	options ...vectorstores.Option,
+) (vectorstores.VectorSearchResult, error) {
 	opts := s.getOptions(options...)
-	filter, err := s.getFilters(opts)
+	expr, err := s.getFilters(opts)
 	if err != nil {
-		return nil, err
+		return vectorstores.VectorSearchResult{}, err
 	}
 	vector, err := s.embedder.EmbedQuery(ctx, query)
 	if err != nil {
-		return nil, err
+		return vectorstores.VectorSearchResult{}, err
 	}
 	if err := s.init(ctx, len(vector)); err != nil {
-		return nil, err
+		return vectorstores.VectorSearchResult{}, err
 	}
 	vectors := []entity.Vector{
 		entity.FloatVector(vector),
@@ -318,8 +367,8 @@ func (s Store) SimilaritySearch(ctx context.Context, query string, numDocuments
 	searchResult, err := s.client.Search(ctx,
 		s.collectionName,
 		partitions,
-		filter,
-		s.getSearchFields(),
+		expr,
+		s.getSearchFields(withVector),
 		vectors,
 		s.vectorField,
 		s.metricType,
@@ -328,19 +377,12 @@ func (s Store) SimilaritySearch(ctx context.Context, query string, numDocuments
 		client.WithSearchQueryConsistencyLevel(s.consistencyLevel),
 	)
 	if err != nil {
-		return nil, err
+		return vectorstores.VectorSearchResult{}, err
 	}
 
-	return s.convertResultToDocument(searchResult)
-}
-
-// getFilters return metadata filters.
-func (s Store) getFilters(opts vectorstores.Options) (string, error) {
-	if opts.Filters != nil {
-		if filters, ok := opts.Filters.(string); ok {
-			return filters, nil
-		}
-		return "", ErrInvalidFilters
+	docs, docVectors, err := s.convertResultToDocument(searchResult, withVector)
+	if err != nil {
+		return vectorstores.VectorSearchResult{}, err
 	}
-	return "", nil
+	return vectorstores.VectorSearchResult{QueryVector: vector, Documents: docs, Vectors: docVectors}, nil
 }
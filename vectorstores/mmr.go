@@ -0,0 +1,83 @@
+package vectorstores
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tmc/langchaingo/embeddings"
+	"github.com/tmc/langchaingo/schema"
+)
+
+const (
+	// DefaultFetchK is the number of documents fetched for a max marginal
+	// relevance search when no fetchK is given.
+	DefaultFetchK = 20
+	// DefaultLambda is the default trade-off between relevance and diversity
+	// of a max marginal relevance search.
+	DefaultLambda = 0.5
+)
+
+// SearchType is the kind of search a Retriever does.
+type SearchType string
+
+const (
+	// SearchTypeSimilarity returns the documents most similar to the query.
+	SearchTypeSimilarity SearchType = "similarity"
+	// SearchTypeMaxMarginalRelevance returns documents similar to the query
+	// but diverse among themselves. See MaxMarginalRelevanceSearch.
+	SearchTypeMaxMarginalRelevance SearchType = "mmr"
+)
+
+// VectorSearchResult is the result of a similarity search that includes the
+// embeddings of the query and of the documents found.
+type VectorSearchResult struct {
+	// QueryVector is the embedding of the query.
+	QueryVector []float32
+	// Documents are the documents found, most similar first.
+	Documents []schema.Document
+	// Vectors are the stored embeddings of Documents, in the same order.
+	Vectors [][]float32
+}
+
+// VectorSearcher is an optional interface for vector stores that can return
+// the stored embeddings of the documents they find.
+type VectorSearcher interface {
+	// SimilaritySearchVectors does the same as SimilaritySearch, also
+	// returning the embedding of the query and of the documents.
+	SimilaritySearchVectors(ctx context.Context, query string, numDocuments int, options ...Option) (VectorSearchResult, error) //nolint:lll
+}
+
+// MaxMarginalRelevanceSearch fetches the fetchK documents most similar to
+// the query from vs, or DefaultFetchK if fetchK is not positive, and returns
+// numDocuments of them selected by maximal marginal relevance, which avoids
+// returning near duplicates. lambda is the trade-off between relevance and
+// diversity, between 0 for maximum diversity and 1 for maximum relevance. It
+// returns ErrUnsupported if vs doesn't implement VectorSearcher.
+func MaxMarginalRelevanceSearch(
+	ctx context.Context,
+	vs VectorStore,
+	query string,
+	numDocuments, fetchK int,
+	lambda float32,
+	options ...Option,
+) ([]schema.Document, error) {
+	s, ok := vs.(VectorSearcher)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T does not implement VectorSearcher", ErrUnsupported, vs)
+	}
+	if fetchK <= 0 {
+		fetchK = DefaultFetchK
+	}
+	fetchK = max(fetchK, numDocuments)
+	result, err := s.SimilaritySearchVectors(ctx, query, fetchK, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := embeddings.MaximalMarginalRelevance(result.QueryVector, result.Vectors, numDocuments, lambda)
+	docs := make([]schema.Document, len(selected))
+	for i, index := range selected {
+		docs[i] = result.Documents[index]
+	}
+	return docs, nil
+}
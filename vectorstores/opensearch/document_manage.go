@@ -0,0 +1,158 @@
+package opensearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/google/uuid"
+	"github.com/opensearch-project/opensearch-go/opensearchapi"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/vectorstores"
+)
+
+// ErrInvalidFilters is returned when the filters are neither an opensearch
+// query nor a valid filter.Expr.
+var ErrInvalidFilters = errors.New("filters must be an opensearch query map or a filter expression")
+
+var _ vectorstores.DocumentManager = Store{}
+
+type mgetResults struct {
+	Docs []struct {
+		ID     string   `json:"_id"`
+		Found  bool     `json:"found"`
+		Source document `json:"_source"`
+	} `json:"docs"`
+}
+
+// DeleteDocuments deletes the documents with the given ids from the index
+// named by the name space, or all documents matching the filters if ids is
+// empty. Filters are an opensearch query, eg: {"term": {"metadata.source": "x"}},
+// or a filter.Expr.
+func (s Store) DeleteDocuments(ctx context.Context, ids []string, options ...vectorstores.Option) error {
+	opts := s.getOptions(options...)
+
+	var query any
+	switch {
+	case len(ids) > 0:
+		query = map[string]any{"ids": map[string]any{"values": ids}}
+	case opts.Filters != nil:
+		filters, err := s.getFilters(opts)
+		if err != nil {
+			return err
+		}
+		query = filters
+	default:
+		return vectorstores.ErrNoDocumentsSelected
+	}
+
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(map[string]any{"query": query}); err != nil {
+		return fmt.Errorf("error encoding delete query to json buffer %w", err)
+	}
+
+	deleteByQuery := opensearchapi.DeleteByQueryRequest{
+		Index: []string{opts.NameSpace},
+		Body:  buf,
+	}
+	res, err := deleteByQuery.Do(ctx, s.client)
+	if err != nil {
+		return fmt.Errorf("deleteByQuery.Do err: %w", err)
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		return fmt.Errorf("error deleting documents: %s", res.String())
+	}
+	return nil
+}
+
+// UpsertDocuments indexes the documents under ids in the index named by the
+// name space, replacing any documents with the same ids.
+func (s Store) UpsertDocuments(
+	ctx context.Context,
+	ids []string,
+	docs []schema.Document,
+	options ...vectorstores.Option,
+) ([]string, error) {
+	if len(ids) != len(docs) {
+		return nil, vectorstores.ErrMismatchedIDs
+	}
+	opts := s.getOptions(options...)
+
+	texts := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		texts = append(texts, doc.PageContent)
+	}
+
+	vectors, err := s.embedder.EmbedDocuments(ctx, texts)
+	if err != nil {
+		return nil, err
+	}
+	if len(vectors) != len(docs) {
+		return nil, ErrNumberOfVectorDoesNotMatch
+	}
+
+	resultIDs := make([]string, 0, len(docs))
+	for i, doc := range docs {
+		id := ids[i]
+		if id == "" {
+			id = uuid.NewString()
+		}
+		if _, err := s.documentIndexing(ctx, id, opts.NameSpace, doc.PageContent, vectors[i], doc.Metadata); err != nil {
+			return resultIDs, err
+		}
+		resultIDs = append(resultIDs, id)
+	}
+	return resultIDs, nil
+}
+
+// GetByIDs returns the documents stored under ids in the index named by the
+// name space.
+func (s Store) GetByIDs(ctx context.Context, ids []string, options ...vectorstores.Option) ([]schema.Document, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	opts := s.getOptions(options...)
+
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(map[string]any{"ids": ids}); err != nil {
+		return nil, fmt.Errorf("error encoding ids to json buffer %w", err)
+	}
+
+	mget := opensearchapi.MgetRequest{
+		Index: opts.NameSpace,
+		Body:  buf,
+	}
+	res, err := mget.Do(ctx, s.client)
+	if err != nil {
+		return nil, fmt.Errorf("mget.Do err: %w", err)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading mget response body: %w", err)
+	}
+	if res.IsError() {
+		return nil, fmt.Errorf("error getting documents: %s", body)
+	}
+	results := mgetResults{}
+	if err := json.Unmarshal(body, &results); err != nil {
+		return nil, fmt.Errorf("error unmarshalling mget response body: %w %s", err, body)
+	}
+
+	docs := make([]schema.Document, 0, len(results.Docs))
+	for _, hit := range results.Docs {
+		if !hit.Found {
+			continue
+		}
+		docs = append(docs, schema.Document{
+			PageContent: hit.Source.FieldsContent,
+			Metadata:    hit.Source.FieldsMetadata,
+		})
+	}
+	return docs, nil
+}
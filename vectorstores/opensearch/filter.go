@@ -0,0 +1,112 @@
+package opensearch
+
+import (
+	"fmt"
+
+	"github.com/tmc/langchaingo/vectorstores"
+	"github.com/tmc/langchaingo/vectorstores/filter"
+)
+
+var _rangeOperators = map[filter.Operator]string{ //nolint:gochecknoglobals
+	filter.OpGt:  "gt",
+	filter.OpGte: "gte",
+	filter.OpLt:  "lt",
+	filter.OpLte: "lte",
+}
+
+// getFilters returns the query of the filters, which are either an opensearch
+// query or a filter.Expr on the document metadata.
+func (s Store) getFilters(opts vectorstores.Options) (map[string]any, error) {
+	switch filters := opts.Filters.(type) {
+	case nil:
+		return nil, nil
+	case map[string]any:
+		return filters, nil
+	case filter.Expr:
+		if err := filters.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidFilters, err)
+		}
+		if filters.MatchesAll() {
+			return nil, nil
+		}
+		query, err := exprQuery(filters)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidFilters, err)
+		}
+		return query, nil
+	default:
+		return nil, ErrInvalidFilters
+	}
+}
+
+// exprQuery translates e to a query. Strings are matched exactly against the
+// keyword sub-field of dynamically mapped metadata.
+func exprQuery(e filter.Expr) (map[string]any, error) {
+	switch e.Op { //nolint:exhaustive
+	case filter.OpAnd:
+		queries, err := exprQueries(e.Operands)
+		if err != nil {
+			return nil, err
+		}
+		return boolQuery("filter", queries...), nil
+	case filter.OpOr:
+		queries, err := exprQueries(e.Operands)
+		if err != nil {
+			return nil, err
+		}
+		query := boolQuery("should", queries...)
+		query["bool"].(map[string]any)["minimum_should_match"] = 1
+		return query, nil
+	case filter.OpNot:
+		queries, err := exprQueries(e.Operands)
+		if err != nil {
+			return nil, err
+		}
+		return boolQuery("must_not", queries...), nil
+	case filter.OpEq:
+		return map[string]any{"term": map[string]any{metadataField(e.Key, e.Value): e.Value}}, nil
+	case filter.OpNe:
+		query, err := exprQuery(filter.Eq(e.Key, e.Value))
+		return boolQuery("must_not", query), err
+	case filter.OpIn:
+		values := e.Values()
+		return map[string]any{"terms": map[string]any{metadataField(e.Key, values[0]): values}}, nil
+	case filter.OpNin:
+		query, err := exprQuery(filter.In(e.Key, e.Value))
+		return boolQuery("must_not", query), err
+	}
+
+	return map[string]any{"range": map[string]any{
+		metadataField(e.Key, e.Value): map[string]any{_rangeOperators[e.Op]: e.Value},
+	}}, nil
+}
+
+func exprQueries(operands []filter.Expr) ([]any, error) {
+	queries := make([]any, 0, len(operands))
+	for _, operand := range operands {
+		// Operands matching everything are left out of filter, and the other
+		// clauses have none.
+		if operand.MatchesAll() {
+			continue
+		}
+		query, err := exprQuery(operand)
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, query)
+	}
+	return queries, nil
+}
+
+func boolQuery(occur string, queries ...any) map[string]any {
+	return map[string]any{"bool": map[string]any{occur: queries}}
+}
+
+// metadataField returns the field holding the metadata value of key, for
+// comparisons with value.
+func metadataField(key string, value any) string {
+	if _, ok := value.(string); ok {
+		return "metadata." + key + ".keyword"
+	}
+	return "metadata." + key
+}
@@ -0,0 +1,85 @@
+package opensearch
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/vectorstores"
+	"github.com/tmc/langchaingo/vectorstores/filter"
+)
+
+func TestGetFilters(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		expr filter.Expr
+		want string
+	}{
+		{
+			"not over and",
+			filter.Not(filter.And(filter.Eq("kind", "manual"), filter.Gt("year", 2020))),
+			`{"bool": {"must_not": [{"bool": {"filter": [
+				{"term": {"metadata.kind.keyword": "manual"}},
+				{"range": {"metadata.year": {"gt": 2020}}}
+			]}}]}}`,
+		},
+		{
+			"not over or",
+			filter.Not(filter.Or(filter.Eq("kind", "manual"), filter.Lt("year", 2020))),
+			`{"bool": {"must_not": [{"bool": {"minimum_should_match": 1, "should": [
+				{"term": {"metadata.kind.keyword": "manual"}},
+				{"range": {"metadata.year": {"lt": 2020}}}
+			]}}]}}`,
+		},
+		{
+			"nin",
+			filter.Nin("lang", []string{"de", "fr"}),
+			`{"bool": {"must_not": [{"terms": {"metadata.lang.keyword": ["de", "fr"]}}]}}`,
+		},
+		{
+			"numeric range",
+			filter.And(filter.Gte("year", 2020), filter.Lte("year", 2024.5)),
+			`{"bool": {"filter": [
+				{"range": {"metadata.year": {"gte": 2020}}},
+				{"range": {"metadata.year": {"lte": 2024.5}}}
+			]}}`,
+		},
+		{
+			"string quoting",
+			filter.Eq("title", `the "Dune" saga`),
+			`{"term": {"metadata.title.keyword": "the \"Dune\" saga"}}`,
+		},
+		{
+			"matches everything",
+			filter.Or(filter.Eq("kind", "manual"), filter.And(filter.Nin("lang", []string{}))),
+			`null`,
+		},
+		{
+			"and leaves out operands matching everything",
+			filter.And(filter.Eq("kind", "manual"), filter.Nin("lang", []string{})),
+			`{"bool": {"filter": [{"term": {"metadata.kind.keyword": "manual"}}]}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			query, err := Store{}.getFilters(vectorstores.Options{Filters: tt.expr})
+			require.NoError(t, err)
+			data, err := json.Marshal(query)
+			require.NoError(t, err)
+			require.JSONEq(t, tt.want, string(data))
+		})
+	}
+
+	for _, e := range []filter.Expr{
+		filter.In("kind", []string{}),
+		filter.Or(),
+		filter.And(filter.Eq("kind", "manual"), filter.Not(filter.And())),
+	} {
+		_, err := Store{}.getFilters(vectorstores.Options{Filters: e})
+		require.ErrorIs(t, err, ErrInvalidFilters)
+		require.ErrorIs(t, err, filter.ErrInvalidFilter)
+	}
+}
@@ -48,7 +48,10 @@ func New(client *opensearchgo.Client, opts ...Option) (Store, error) {
 	return s, nil
 }
 
-var _ vectorstores.VectorStore = Store{}
+var (
+	_ vectorstores.VectorStore    = Store{}
+	_ vectorstores.VectorSearcher = Store{}
+)
 
 // AddDocuments adds the text and metadata from the documents to the Chroma collection associated with 'Store'.
 // and returns the ids of the added documents.
@@ -94,47 +97,77 @@ func (s Store) SimilaritySearch(
 	numDocuments int,
 	options ...vectorstores.Option,
 ) ([]schema.Document, error) {
+	result, err := s.SimilaritySearchVectors(ctx, query, numDocuments, options...)
+	if result.Documents == nil {
+		result.Documents = []schema.Document{}
+	}
+	return result.Documents, err
+}
+
+// SimilaritySearchVectors does the same as SimilaritySearch, also returning
+// the embedding of the query and the stored embeddings of the documents.
+func (s Store) SimilaritySearchVectors(
+	ctx context.Context,
+	query string,
+	numDocuments int,
+	options ...vectorstores.Option,
+) (vectorstores.VectorSearchResult, error) {
 	opts := s.getOptions(options...)
+	filters, err := s.getFilters(opts)
+	if err != nil {
+		return vectorstores.VectorSearchResult{}, err
+	}
 
 	queryVector, err := s.embedder.EmbedQuery(ctx, query)
 	if err != nil {
-		return nil, err
+		return vectorstores.VectorSearchResult{}, err
 	}
+	result := vectorstores.VectorSearchResult{QueryVector: queryVector}
 
-	searchPayload := map[string]interface{}{
-		"size": numDocuments,
-		"query": map[string]interface{}{
-			"knn": map[string]interface{}{
-				"contentVector": map[string]interface{}{
-					"vector": queryVector,
-					"k":      numDocuments,
-				},
+	var searchQuery any = map[string]interface{}{
+		"knn": map[string]interface{}{
+			"contentVector": map[string]interface{}{
+				"vector": queryVector,
+				"k":      numDocuments,
 			},
 		},
 	}
+	if filters != nil {
+		// The filters apply to the nearest neighbors, so fewer than
+		// numDocuments documents may match.
+		searchQuery = map[string]interface{}{
+			"bool": map[string]interface{}{
+				"must":   []any{searchQuery},
+				"filter": []any{filters},
+			},
+		}
+	}
+	searchPayload := map[string]interface{}{
+		"size":  numDocuments,
+		"query": searchQuery,
+	}
 
 	buf := new(bytes.Buffer)
 	if err := json.NewEncoder(buf).Encode(searchPayload); err != nil {
-		return nil, fmt.Errorf("error encoding index schema to json buffer %w", err)
+		return result, fmt.Errorf("error encoding index schema to json buffer %w", err)
 	}
 
 	search := opensearchapi.SearchRequest{
 		Index: []string{opts.NameSpace},
 		Body:  buf,
 	}
-	output := []schema.Document{}
 	searchResponse, err := search.Do(ctx, s.client)
 	if err != nil {
-		return output, fmt.Errorf("search.Do err: %w", err)
+		return result, fmt.Errorf("search.Do err: %w", err)
 	}
 
 	body, err := io.ReadAll(searchResponse.Body)
 	if err != nil {
-		return output, fmt.Errorf("error reading search response body: %w", err)
+		return result, fmt.Errorf("error reading search response body: %w", err)
 	}
 	searchResults := searchResults{}
 	if err := json.Unmarshal(body, &searchResults); err != nil {
-		return output, fmt.Errorf("error unmarshalling search response body: %w %s", err, body)
+		return result, fmt.Errorf("error unmarshalling search response body: %w %s", err, body)
 	}
 
 	for _, hit := range searchResults.Hits.Hits {
@@ -142,12 +175,13 @@ func (s Store) SimilaritySearch(
 			continue
 		}
 
-		output = append(output, schema.Document{
+		result.Documents = append(result.Documents, schema.Document{
 			PageContent: hit.Source.FieldsContent,
 			Metadata:    hit.Source.FieldsMetadata,
 			Score:       hit.Score,
 		})
+		result.Vectors = append(result.Vectors, hit.Source.FieldsContentVector)
 	}
 
-	return output, nil
+	return result, nil
 }
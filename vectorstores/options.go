@@ -17,6 +17,11 @@ type Options struct {
 	Filters        any
 	Embedder       embeddings.Embedder
 	Deduplicater   func(context.Context, schema.Document) bool
+
+	// SearchType, FetchK and Lambda configure the search of a Retriever.
+	SearchType SearchType
+	FetchK     int
+	Lambda     float32
 }
 
 // WithNameSpace returns an Option for setting the name space.
@@ -36,6 +41,9 @@ func WithScoreThreshold(scoreThreshold float32) Option {
 // filters retrieve exactly the number of nearest-neighbors results that match the filters. In
 // most cases the search latency will be lower than unfiltered searches
 // See https://docs.pinecone.io/docs/metadata-filtering
+//
+// Filters are either in the native format of the vector store or a
+// filter.Expr, which all the vector stores of this module translate.
 func WithFilters(filters any) Option {
 	return func(o *Options) {
 		o.Filters = filters
@@ -59,3 +67,14 @@ func WithDeduplicater(fn func(ctx context.Context, doc schema.Document) bool) Op
 		o.Deduplicater = fn
 	}
 }
+
+// WithMaxMarginalRelevance returns an Option making a Retriever search with
+// MaxMarginalRelevanceSearch, fetching fetchK documents and selecting among
+// them with the given lambda. Vector stores ignore it.
+func WithMaxMarginalRelevance(fetchK int, lambda float32) Option {
+	return func(o *Options) {
+		o.SearchType = SearchTypeMaxMarginalRelevance
+		o.FetchK = fetchK
+		o.Lambda = lambda
+	}
+}
@@ -0,0 +1,124 @@
+package pgvector
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/tmc/langchaingo/vectorstores/filter"
+)
+
+// filterClause returns the SQL condition selecting the rows whose metadata
+// column matches filters, a map of keys to values or a filter.Expr, with its
+// parameters appended to args.
+func filterClause(filters any, column string, args []any) (string, []any, error) {
+	switch f := filters.(type) {
+	case nil:
+		return "TRUE", args, nil
+	case filter.Expr:
+		if err := f.Validate(); err != nil {
+			return "", nil, fmt.Errorf("%w: %w", ErrInvalidFilters, err)
+		}
+		return exprClause(f, column, args)
+	case map[string]any:
+		keys := make([]string, 0, len(f))
+		for k := range f {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		conditions := make([]string, 0, len(keys))
+		for _, k := range keys {
+			args = append(args, k, fmt.Sprint(f[k]))
+			conditions = append(conditions, fmt.Sprintf("(%s ->> $%d) = $%d", column, len(args)-1, len(args)))
+		}
+		if len(conditions) == 0 {
+			return "TRUE", args, nil
+		}
+		return strings.Join(conditions, " AND "), args, nil
+	default:
+		return "", nil, ErrInvalidFilters
+	}
+}
+
+var _sqlComparisons = map[filter.Operator]string{
+	filter.OpGt:  ">",
+	filter.OpGte: ">=",
+	filter.OpLt:  "<",
+	filter.OpLte: "<=",
+}
+
+// exprClause translates a valid filter expression, comparing jsonb values so
+// that numbers, strings and booleans compare with their own semantics.
+func exprClause(e filter.Expr, column string, args []any) (string, []any, error) { //nolint:cyclop
+	if e.MatchesAll() {
+		return "TRUE", args, nil
+	}
+	switch e.Op {
+	case filter.OpAnd, filter.OpOr:
+		clauses := make([]string, len(e.Operands))
+		for i, operand := range e.Operands {
+			var err error
+			clauses[i], args, err = exprClause(operand, column, args)
+			if err != nil {
+				return "", nil, err
+			}
+		}
+		return "(" + strings.Join(clauses, " "+strings.ToUpper(string(e.Op))+" ") + ")", args, nil
+	case filter.OpNot:
+		clause, args, err := exprClause(e.Operands[0], column, args)
+		if err != nil {
+			return "", nil, err
+		}
+		// A missing key makes the clause NULL, which NOT keeps NULL.
+		return "(NOT COALESCE(" + clause + ", FALSE))", args, nil
+	}
+
+	args = append(args, e.Key)
+	value := fmt.Sprintf("(%s -> $%d)", column, len(args))
+	switch e.Op { //nolint:exhaustive
+	case filter.OpEq, filter.OpNe:
+		param, err := jsonParam(e.Value)
+		if err != nil {
+			return "", nil, err
+		}
+		args = append(args, param)
+		if e.Op == filter.OpEq {
+			return fmt.Sprintf("%s = $%d::jsonb", value, len(args)), args, nil
+		}
+		return fmt.Sprintf("%s IS DISTINCT FROM $%d::jsonb", value, len(args)), args, nil
+	case filter.OpIn, filter.OpNin:
+		params := make([]string, 0)
+		for _, v := range e.Values() {
+			param, err := jsonParam(v)
+			if err != nil {
+				return "", nil, err
+			}
+			args = append(args, param)
+			params = append(params, fmt.Sprintf("$%d::jsonb", len(args)))
+		}
+		in := fmt.Sprintf("%s IN (%s)", value, strings.Join(params, ", "))
+		if e.Op == filter.OpIn {
+			return in, args, nil
+		}
+		return fmt.Sprintf("(%s IS NULL OR NOT %s)", value, in), args, nil
+	default:
+		param, err := jsonParam(e.Value)
+		if err != nil {
+			return "", nil, err
+		}
+		args = append(args, param)
+		// jsonb orders values of different types by type, so the type is
+		// checked first.
+		return fmt.Sprintf("(jsonb_typeof(%s) = jsonb_typeof($%d::jsonb) AND %s %s $%d::jsonb)",
+			value, len(args), value, _sqlComparisons[e.Op], len(args)), args, nil
+	}
+}
+
+func jsonParam(v any) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidFilters, err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,40 @@
+package pgvector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/vectorstores/filter"
+)
+
+func TestFilterClause(t *testing.T) {
+	t.Parallel()
+
+	clause, args, err := filterClause(map[string]any{"b": 2, "a": "x"}, "data.cmetadata", []any{1})
+	require.NoError(t, err)
+	require.Equal(t, "(data.cmetadata ->> $2) = $3 AND (data.cmetadata ->> $4) = $5", clause)
+	require.Equal(t, []any{1, "a", "x", "b", "2"}, args)
+
+	clause, args, err = filterClause(filter.Or(
+		filter.Eq("kind", "manual"),
+		filter.Not(filter.Gte("year", 2020)),
+		filter.Nin("lang", []string{"de"}),
+	), "cmetadata", nil)
+	require.NoError(t, err)
+	require.Equal(t, "((cmetadata -> $1) = $2::jsonb OR "+
+		"(NOT COALESCE((jsonb_typeof((cmetadata -> $3)) = jsonb_typeof($4::jsonb) AND (cmetadata -> $3) >= $4::jsonb), FALSE)) OR "+
+		"((cmetadata -> $5) IS NULL OR NOT (cmetadata -> $5) IN ($6::jsonb)))", clause)
+	require.Equal(t, []any{"kind", `"manual"`, "year", "2020", "lang", `"de"`}, args)
+
+	clause, _, err = filterClause(filter.Or(filter.Eq("kind", "manual"), filter.Nin("lang", []string{})), "cmetadata", nil)
+	require.NoError(t, err)
+	require.Equal(t, "TRUE", clause)
+
+	_, _, err = filterClause(filter.And(filter.In("kind", []string{}), filter.Or()), "cmetadata", nil)
+	require.ErrorIs(t, err, filter.ErrInvalidFilter)
+
+	_, _, err = filterClause(filter.Eq("", "manual"), "cmetadata", nil)
+	require.ErrorIs(t, err, ErrInvalidFilters)
+	_, _, err = filterClause("kind = 'manual'", "cmetadata", nil)
+	require.ErrorIs(t, err, ErrInvalidFilters)
+}
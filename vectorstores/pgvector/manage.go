@@ -0,0 +1,167 @@
+package pgvector
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/pgvector/pgvector-go"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/vectorstores"
+)
+
+var _ vectorstores.DocumentManager = Store{}
+
+// DeleteDocuments deletes the documents with the given ids from the
+// collection, or all documents matching the filters if ids is empty.
+func (s Store) DeleteDocuments(ctx context.Context, ids []string, options ...vectorstores.Option) error {
+	opts := s.getOptions(options...)
+	if len(ids) == 0 && opts.Filters == nil {
+		return vectorstores.ErrNoDocumentsSelected
+	}
+	filters, err := s.getFilters(opts)
+	if err != nil {
+		return err
+	}
+
+	args := []any{s.getNameSpace(opts)}
+	whereQuerys := []string{fmt.Sprintf(
+		"collection_id = (SELECT uuid FROM %s WHERE name = $1)", s.collectionTableName)}
+	if len(ids) > 0 {
+		args = append(args, ids)
+		whereQuerys = append(whereQuerys, fmt.Sprintf("uuid = ANY($%d::uuid[])", len(args)))
+	} else {
+		var whereQuery string
+		whereQuery, args, err = filterClause(filters, "cmetadata", args)
+		if err != nil {
+			return err
+		}
+		whereQuerys = append(whereQuerys, whereQuery)
+	}
+
+	sql := fmt.Sprintf(`DELETE FROM %s WHERE %s`, s.embeddingTableName, strings.Join(whereQuerys, " AND "))
+	_, err = s.conn.Exec(ctx, sql, args...)
+	return err
+}
+
+// UpsertDocuments embeds docs and stores them in the collection, or in the
+// existing one named with vectorstores.WithNameSpace, under ids, replacing
+// any documents already stored under the same ids.
+func (s Store) UpsertDocuments(
+	ctx context.Context,
+	ids []string,
+	docs []schema.Document,
+	options ...vectorstores.Option,
+) ([]string, error) {
+	if len(ids) != len(docs) {
+		return nil, vectorstores.ErrMismatchedIDs
+	}
+	opts := s.getOptions(options...)
+	if opts.ScoreThreshold != 0 || opts.Filters != nil {
+		return nil, ErrUnsupportedOptions
+	}
+	collectionUUID, err := s.getCollectionUUID(ctx, s.getNameSpace(opts))
+	if err != nil {
+		return nil, err
+	}
+
+	texts := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		texts = append(texts, doc.PageContent)
+	}
+
+	embedder := s.embedder
+	if opts.Embedder != nil {
+		embedder = opts.Embedder
+	}
+	vectors, err := embedder.EmbedDocuments(ctx, texts)
+	if err != nil {
+		return nil, err
+	}
+	if len(vectors) != len(docs) {
+		return nil, ErrEmbedderWrongNumberVectors
+	}
+
+	b := &pgx.Batch{}
+	sql := fmt.Sprintf(`INSERT INTO %s (uuid, document, embedding, cmetadata, collection_id)
+		VALUES($1, $2, $3, $4, $5) ON CONFLICT (uuid) DO
+		UPDATE SET document = $2, embedding = $3, cmetadata = $4, collection_id = $5`, s.embeddingTableName)
+
+	resultIDs := make([]string, len(docs))
+	for docIdx, doc := range docs {
+		id := ids[docIdx]
+		if id == "" {
+			id = uuid.New().String()
+		}
+		resultIDs[docIdx] = id
+		b.Queue(sql, id, doc.PageContent, pgvector.NewVector(vectors[docIdx]), doc.Metadata, collectionUUID)
+	}
+	return resultIDs, s.conn.SendBatch(ctx, b).Close()
+}
+
+// getCollectionUUID returns the uuid of the collection with the given name.
+func (s Store) getCollectionUUID(ctx context.Context, name string) (string, error) {
+	if name == s.collectionName {
+		return s.collectionUUID, nil
+	}
+	var collectionUUID string
+	sql := fmt.Sprintf(`SELECT uuid FROM %s WHERE name = $1`, s.collectionTableName)
+	if err := s.conn.QueryRow(ctx, sql, name).Scan(&collectionUUID); err != nil {
+		return "", fmt.Errorf("collection %q: %w", name, err)
+	}
+	return collectionUUID, nil
+}
+
+// GetByIDs returns the documents stored in the collection under ids. Ids
+// that aren't uuids match no document.
+func (s Store) GetByIDs(ctx context.Context, ids []string, options ...vectorstores.Option) ([]schema.Document, error) {
+	opts := s.getOptions(options...)
+
+	// Stored uuids are returned in their canonical lowercase form, so the
+	// requested ids are normalized to match them.
+	uuids := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if u, err := uuid.Parse(id); err == nil {
+			uuids = append(uuids, u.String())
+		}
+	}
+
+	sql := fmt.Sprintf(`SELECT
+	%s.uuid::text,
+	%s.document,
+	%s.cmetadata
+FROM %s
+JOIN %s ON %s.collection_id=%s.uuid
+WHERE %s.name = $1 AND %s.uuid = ANY($2::uuid[])`,
+		s.embeddingTableName, s.embeddingTableName, s.embeddingTableName, s.embeddingTableName,
+		s.collectionTableName, s.embeddingTableName, s.collectionTableName,
+		s.collectionTableName, s.embeddingTableName)
+	rows, err := s.conn.Query(ctx, sql, s.getNameSpace(opts), uuids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	found := make(map[string]schema.Document, len(uuids))
+	for rows.Next() {
+		var id string
+		doc := schema.Document{}
+		if err := rows.Scan(&id, &doc.PageContent, &doc.Metadata); err != nil {
+			return nil, err
+		}
+		found[id] = doc
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	docs := make([]schema.Document, 0, len(found))
+	for _, id := range uuids {
+		if doc, ok := found[id]; ok {
+			docs = append(docs, doc)
+		}
+	}
+	return docs, nil
+}
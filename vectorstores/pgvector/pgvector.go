@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"strings"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -14,6 +13,7 @@ import (
 	"github.com/tmc/langchaingo/embeddings"
 	"github.com/tmc/langchaingo/schema"
 	"github.com/tmc/langchaingo/vectorstores"
+	"github.com/tmc/langchaingo/vectorstores/filter"
 )
 
 const (
@@ -71,7 +71,10 @@ type HNSWIndex struct {
 	distanceFunction string
 }
 
-var _ vectorstores.VectorStore = Store{}
+var (
+	_ vectorstores.VectorStore    = Store{}
+	_ vectorstores.VectorSearcher = Store{}
+)
 
 // New creates a new Store with options.
 func New(ctx context.Context, opts ...Option) (Store, error) {
@@ -268,22 +271,48 @@ func (s Store) AddDocuments(
 	return ids, s.conn.SendBatch(ctx, b).Close()
 }
 
-//nolint:cyclop
 func (s Store) SimilaritySearch(
 	ctx context.Context,
 	query string,
 	numDocuments int,
 	options ...vectorstores.Option,
 ) ([]schema.Document, error) {
+	result, err := s.similaritySearch(ctx, query, numDocuments, false, options...)
+	return result.Documents, err
+}
+
+// SimilaritySearchVectors does the same as SimilaritySearch, also returning
+// the embedding of the query and the stored embeddings of the documents.
+func (s Store) SimilaritySearchVectors(
+	ctx context.Context,
+	query string,
+	numDocuments int,
+	options ...vectorstores.Option,
+) (vectorstores.VectorSearchResult, error) {
+	return s.similaritySearch(ctx, query, numDocuments, true, options...)
+}
+
+// similaritySearch selects the stored embeddings of the documents only if
+// withVector is true.
+//
+//nolint:cyclop
+func (s Store) similaritySearch(
+	ctx context.Context,
+	query string,
+	numDocuments int,
+	withVector bool,
+	options ...vectorstores.Option,
+) (vectorstores.VectorSearchResult, error) {
+	var result vectorstores.VectorSearchResult
 	opts := s.getOptions(options...)
 	collectionName := s.getNameSpace(opts)
 	scoreThreshold, err := s.getScoreThreshold(opts)
 	if err != nil {
-		return nil, err
+		return result, err
 	}
-	filter, err := s.getFilters(opts)
+	filters, err := s.getFilters(opts)
 	if err != nil {
-		return nil, err
+		return result, err
 	}
 	embedder := s.embedder
 	if opts.Embedder != nil {
@@ -291,20 +320,22 @@ func (s Store) SimilaritySearch(
 	}
 	embedderData, err := embedder.EmbedQuery(ctx, query)
 	if err != nil {
-		return nil, err
+		return result, err
 	}
-	whereQuerys := make([]string, 0)
-	if scoreThreshold != 0 {
-		whereQuerys = append(whereQuerys, fmt.Sprintf("data.distance < %f", 1-scoreThreshold))
+	dims := len(embedderData)
+	whereQuery, args, err := filterClause(filters, "data.cmetadata", []any{
+		dims, pgvector.NewVector(embedderData), numDocuments,
+	})
+	if err != nil {
+		return result, err
 	}
-	for k, v := range filter {
-		whereQuerys = append(whereQuerys, fmt.Sprintf("(data.cmetadata ->> '%s') = '%s'", k, v))
+	if scoreThreshold != 0 {
+		whereQuery += fmt.Sprintf(" AND data.distance < %f", 1-scoreThreshold)
 	}
-	whereQuery := strings.Join(whereQuerys, " AND ")
-	if len(whereQuery) == 0 {
-		whereQuery = "TRUE"
+	selectEmbedding := ""
+	if withVector {
+		selectEmbedding = ",\n\tdata.embedding"
 	}
-	dims := len(embedderData)
 	sql := fmt.Sprintf(`WITH filtered_embedding_dims AS MATERIALIZED (
     SELECT
         *
@@ -318,7 +349,7 @@ func (s Store) SimilaritySearch(
 SELECT
 	data.document,
 	data.cmetadata,
-	data.distance
+	data.distance%s
 FROM (
 	SELECT
 		filtered_embedding_dims.*,
@@ -329,24 +360,34 @@ FROM (
 WHERE %s
 ORDER BY
 	data.distance
-LIMIT $3`, s.embeddingTableName,
+LIMIT $3`, s.embeddingTableName, selectEmbedding,
 		s.collectionTableName, s.collectionTableName, s.collectionTableName, collectionName,
 		whereQuery)
-	rows, err := s.conn.Query(ctx, sql, dims, pgvector.NewVector(embedderData), numDocuments)
+	rows, err := s.conn.Query(ctx, sql, args...)
 	if err != nil {
-		return nil, err
+		return result, err
 	}
 	defer rows.Close()
 
-	docs := make([]schema.Document, 0)
 	for rows.Next() {
 		doc := schema.Document{}
-		if err := rows.Scan(&doc.PageContent, &doc.Metadata, &doc.Score); err != nil {
-			return nil, err
+		dest := []any{&doc.PageContent, &doc.Metadata, &doc.Score}
+		var embedding pgvector.Vector
+		if withVector {
+			dest = append(dest, &embedding)
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return result, err
+		}
+		result.Documents = append(result.Documents, doc)
+		if withVector {
+			result.Vectors = append(result.Vectors, embedding.Slice())
 		}
-		docs = append(docs, doc)
 	}
-	return docs, rows.Err()
+	if withVector {
+		result.QueryVector = embedderData
+	}
+	return result, rows.Err()
 }
 
 //nolint:cyclop
@@ -357,17 +398,13 @@ func (s Store) Search(
 ) ([]schema.Document, error) {
 	opts := s.getOptions(options...)
 	collectionName := s.getNameSpace(opts)
-	filter, err := s.getFilters(opts)
+	filters, err := s.getFilters(opts)
 	if err != nil {
 		return nil, err
 	}
-	whereQuerys := make([]string, 0)
-	for k, v := range filter {
-		whereQuerys = append(whereQuerys, fmt.Sprintf("(%s.cmetadata ->> '%s') = '%s'", s.embeddingTableName, k, v))
-	}
-	whereQuery := strings.Join(whereQuerys, " AND ")
-	if len(whereQuery) == 0 {
-		whereQuery = "TRUE"
+	whereQuery, args, err := filterClause(filters, s.embeddingTableName+".cmetadata", []any{numDocuments})
+	if err != nil {
+		return nil, err
 	}
 	sql := fmt.Sprintf(`SELECT
 	%s.document,
@@ -378,7 +415,7 @@ WHERE %s.name='%s' AND %s
 LIMIT $1`, s.embeddingTableName, s.embeddingTableName, s.embeddingTableName,
 		s.collectionTableName, s.embeddingTableName, s.collectionTableName, s.collectionTableName, collectionName,
 		whereQuery)
-	rows, err := s.conn.Query(ctx, sql, numDocuments)
+	rows, err := s.conn.Query(ctx, sql, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -445,16 +482,15 @@ func (s Store) getScoreThreshold(opts vectorstores.Options) (float32, error) {
 	return opts.ScoreThreshold, nil
 }
 
-// getFilters return metadata filters, now only support map[key]value pattern
-// TODO: should support more types like {"key1": {"key2":"values2"}} or {"key": ["value1", "values2"]}.
-func (s Store) getFilters(opts vectorstores.Options) (map[string]any, error) {
-	if opts.Filters != nil {
-		if filters, ok := opts.Filters.(map[string]any); ok {
-			return filters, nil
-		}
+// getFilters return metadata filters, either a map of keys to the values
+// they must be equal to or a filter.Expr.
+func (s Store) getFilters(opts vectorstores.Options) (any, error) {
+	switch opts.Filters.(type) {
+	case nil, map[string]any, filter.Expr:
+		return opts.Filters, nil
+	default:
 		return nil, ErrInvalidFilters
 	}
-	return map[string]any{}, nil
 }
 
 func (s Store) deduplicate(
@@ -695,3 +695,52 @@ func TestWithAllOptions(t *testing.T) {
 	require.Equal(t, "tokyo", docs[0].PageContent)
 	require.Equal(t, "japan", docs[0].Metadata["country"])
 }
+
+func TestPgvectorDocumentManager(t *testing.T) {
+	t.Parallel()
+	pgvectorURL := preCheckEnvSetting(t)
+	ctx := context.Background()
+
+	llm, err := openai.New(
+		openai.WithEmbeddingModel("text-embedding-ada-002"),
+	)
+	require.NoError(t, err)
+	e, err := embeddings.NewEmbedder(llm)
+	require.NoError(t, err)
+	conn, err := pgx.Connect(ctx, pgvectorURL)
+	require.NoError(t, err)
+	defer conn.Close(ctx)
+
+	newStore := func(name string) pgvector.Store {
+		store, err := pgvector.New(
+			ctx,
+			pgvector.WithConn(conn),
+			pgvector.WithEmbedder(e),
+			pgvector.WithCollectionName(name),
+		)
+		require.NoError(t, err)
+		t.Cleanup(func() { cleanupTestArtifacts(ctx, t, store, pgvectorURL) })
+		return store
+	}
+	otherName := makeNewCollectionName()
+	store, other := newStore(makeNewCollectionName()), newStore(otherName)
+
+	id := strings.ToUpper(uuid.New().String())
+	ids, err := store.UpsertDocuments(ctx, []string{id}, []schema.Document{{PageContent: "tokyo"}},
+		vectorstores.WithNameSpace(otherName))
+	require.NoError(t, err)
+	require.Equal(t, []string{id}, ids)
+
+	docs, err := other.GetByIDs(ctx, []string{id, "not a uuid"})
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	require.Equal(t, "tokyo", docs[0].PageContent)
+
+	docs, err = store.GetByIDs(ctx, []string{id})
+	require.NoError(t, err)
+	require.Empty(t, docs)
+
+	_, err = store.UpsertDocuments(ctx, []string{id}, []schema.Document{{PageContent: "tokyo"}},
+		vectorstores.WithNameSpace(makeNewCollectionName()))
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
@@ -0,0 +1,103 @@
+package pinecone
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/vectorstores"
+)
+
+var (
+	_ vectorstores.DocumentManager = Store{}
+	_ vectorstores.VectorSearcher  = Store{}
+)
+
+// DeleteDocuments deletes the vectors with the given ids from the name space,
+// or all vectors matching the filters if ids is empty.
+func (s Store) DeleteDocuments(ctx context.Context, ids []string, options ...vectorstores.Option) error {
+	opts := s.getOptions(options...)
+	filters := s.getFilters(opts)
+	if len(ids) == 0 && filters == nil {
+		return vectorstores.ErrNoDocumentsSelected
+	}
+
+	indexConn, err := s.client.IndexWithNamespace(s.host, s.getNameSpace(opts))
+	if err != nil {
+		return err
+	}
+	defer indexConn.Close()
+
+	if len(ids) > 0 {
+		return indexConn.DeleteVectorsById(&ctx, ids)
+	}
+	protoFilterStruct, err := s.createProtoStructFilter(filters)
+	if err != nil {
+		return err
+	}
+	return indexConn.DeleteVectorsByFilter(&ctx, protoFilterStruct)
+}
+
+// UpsertDocuments creates vector embeddings from the documents using the
+// embedder and upserts them to the pinecone index under ids.
+func (s Store) UpsertDocuments(
+	ctx context.Context,
+	ids []string,
+	docs []schema.Document,
+	options ...vectorstores.Option,
+) ([]string, error) {
+	if len(ids) != len(docs) {
+		return nil, vectorstores.ErrMismatchedIDs
+	}
+	if len(docs) == 0 {
+		return nil, nil
+	}
+	opts := s.getOptions(options...)
+
+	resultIDs := make([]string, len(ids))
+	for i, id := range ids {
+		if id == "" {
+			id = uuid.New().String()
+		}
+		resultIDs[i] = id
+	}
+	if err := s.upsert(ctx, s.getNameSpace(opts), resultIDs, docs); err != nil {
+		return nil, err
+	}
+	return resultIDs, nil
+}
+
+// GetByIDs fetches the vectors with the given ids from the name space.
+func (s Store) GetByIDs(ctx context.Context, ids []string, options ...vectorstores.Option) ([]schema.Document, error) {
+	opts := s.getOptions(options...)
+
+	indexConn, err := s.client.IndexWithNamespace(s.host, s.getNameSpace(opts))
+	if err != nil {
+		return nil, err
+	}
+	defer indexConn.Close()
+
+	res, err := indexConn.FetchVectors(&ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]schema.Document, 0, len(res.Vectors))
+	for _, id := range ids {
+		vector, ok := res.Vectors[id]
+		if !ok || vector == nil {
+			continue
+		}
+		metadata := vector.Metadata.AsMap()
+		pageContent, ok := metadata[s.textKey].(string)
+		if !ok {
+			return nil, ErrMissingTextKey
+		}
+		delete(metadata, s.textKey)
+		docs = append(docs, schema.Document{
+			PageContent: pageContent,
+			Metadata:    metadata,
+		})
+	}
+	return docs, nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/tmc/langchaingo/embeddings"
 	"github.com/tmc/langchaingo/schema"
 	"github.com/tmc/langchaingo/vectorstores"
+	"github.com/tmc/langchaingo/vectorstores/filter"
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
@@ -62,11 +63,22 @@ func (s Store) AddDocuments(ctx context.Context,
 ) ([]string, error) {
 	opts := s.getOptions(options...)
 
-	nameSpace := s.getNameSpace(opts)
+	ids := make([]string, len(docs))
+	for i := range ids {
+		ids[i] = uuid.New().String()
+	}
+	if err := s.upsert(ctx, s.getNameSpace(opts), ids, docs); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
 
+// upsert embeds docs and upserts them to the pinecone index under ids.
+func (s Store) upsert(ctx context.Context, nameSpace string, ids []string, docs []schema.Document) error {
 	indexConn, err := s.client.IndexWithNamespace(s.host, nameSpace)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer indexConn.Close()
 
@@ -77,11 +89,11 @@ func (s Store) AddDocuments(ctx context.Context,
 
 	vectors, err := s.embedder.EmbedDocuments(ctx, texts)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	if len(vectors) != len(docs) {
-		return nil, ErrEmbedderWrongNumberVectors
+		return ErrEmbedderWrongNumberVectors
 	}
 
 	metadatas := make([]map[string]any, 0, len(docs))
@@ -96,20 +108,16 @@ func (s Store) AddDocuments(ctx context.Context,
 	}
 
 	pineconeVectors := make([]*pinecone.Vector, 0, len(vectors))
-
-	ids := make([]string, len(vectors))
 	for i := 0; i < len(vectors); i++ {
 		metadataStruct, err := structpb.NewStruct(metadatas[i])
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		id := uuid.New().String()
-		ids[i] = id
 		pineconeVectors = append(
 			pineconeVectors,
 			&pinecone.Vector{
-				Id:       id,
+				Id:       ids[i],
 				Values:   vectors[i],
 				Metadata: metadataStruct,
 			},
@@ -117,22 +125,26 @@ func (s Store) AddDocuments(ctx context.Context,
 	}
 
 	_, err = indexConn.UpsertVectors(&ctx, pineconeVectors)
-	if err != nil {
-		return nil, err
-	}
-
-	return ids, nil
+	return err
 }
 
 // SimilaritySearch creates a vector embedding from the query using the embedder
 // and queries to find the most similar documents.
 func (s Store) SimilaritySearch(ctx context.Context, query string, numDocuments int, options ...vectorstores.Option) ([]schema.Document, error) { //nolint:lll
+	result, err := s.SimilaritySearchVectors(ctx, query, numDocuments, options...)
+	return result.Documents, err
+}
+
+// SimilaritySearchVectors does the same as SimilaritySearch, also returning
+// the embedding of the query and the stored values of the documents.
+func (s Store) SimilaritySearchVectors(ctx context.Context, query string, numDocuments int, options ...vectorstores.Option) (vectorstores.VectorSearchResult, error) { //nolint:lll
+	var result vectorstores.VectorSearchResult
 	opts := s.getOptions(options...)
 
 	nameSpace := s.getNameSpace(opts)
 	indexConn, err := s.client.IndexWithNamespace(s.host, nameSpace)
 	if err != nil {
-		return nil, err
+		return result, err
 	}
 	defer indexConn.Close()
 
@@ -141,18 +153,18 @@ func (s Store) SimilaritySearch(ctx context.Context, query string, numDocuments
 	if filters != nil {
 		protoFilterStruct, err = s.createProtoStructFilter(filters)
 		if err != nil {
-			return nil, err
+			return result, err
 		}
 	}
 
 	scoreThreshold, err := s.getScoreThreshold(opts)
 	if err != nil {
-		return nil, err
+		return result, err
 	}
 
 	vector, err := s.embedder.EmbedQuery(ctx, query)
 	if err != nil {
-		return nil, err
+		return result, err
 	}
 
 	queryResult, err := indexConn.QueryByVectorValues(
@@ -166,23 +178,26 @@ func (s Store) SimilaritySearch(ctx context.Context, query string, numDocuments
 		},
 	)
 	if err != nil {
-		return nil, err
+		return result, err
 	}
 
 	if len(queryResult.Matches) == 0 {
-		return nil, ErrEmptyResponse
+		return result, ErrEmptyResponse
 	}
 
-	return s.getDocumentsFromMatches(queryResult, scoreThreshold)
+	result.QueryVector = vector
+	result.Documents, result.Vectors, err = s.getDocumentsFromMatches(queryResult, scoreThreshold)
+	return result, err
 }
 
-func (s Store) getDocumentsFromMatches(queryResult *pinecone.QueryVectorsResponse, scoreThreshold float32) ([]schema.Document, error) {
+func (s Store) getDocumentsFromMatches(queryResult *pinecone.QueryVectorsResponse, scoreThreshold float32) ([]schema.Document, [][]float32, error) { //nolint:lll
 	resultDocuments := make([]schema.Document, 0)
+	resultVectors := make([][]float32, 0)
 	for _, match := range queryResult.Matches {
 		metadata := match.Vector.Metadata.AsMap()
 		pageContent, ok := metadata[s.textKey].(string)
 		if !ok {
-			return nil, ErrMissingTextKey
+			return nil, nil, ErrMissingTextKey
 		}
 		delete(metadata, s.textKey)
 
@@ -193,13 +208,13 @@ func (s Store) getDocumentsFromMatches(queryResult *pinecone.QueryVectorsRespons
 		}
 
 		// If scoreThreshold is not 0, we only return matches with a score above the threshold.
-		if scoreThreshold != 0 && match.Score >= scoreThreshold {
-			resultDocuments = append(resultDocuments, doc)
-		} else if scoreThreshold == 0 { // If scoreThreshold is 0, we return all matches.
-			resultDocuments = append(resultDocuments, doc)
+		if scoreThreshold != 0 && match.Score < scoreThreshold {
+			continue
 		}
+		resultDocuments = append(resultDocuments, doc)
+		resultVectors = append(resultVectors, match.Vector.Values)
 	}
-	return resultDocuments, nil
+	return resultDocuments, resultVectors, nil
 }
 
 func (s Store) getNameSpace(opts vectorstores.Options) string {
@@ -231,8 +246,18 @@ func (s Store) getOptions(options ...vectorstores.Option) vectorstores.Options {
 	return opts
 }
 
-func (s Store) createProtoStructFilter(filter any) (*structpb.Struct, error) {
-	filterBytes, err := json.Marshal(filter)
+// createProtoStructFilter converts the filters, which are either a Pinecone
+// metadata filter or a filter.Expr, to a protobuf struct.
+func (s Store) createProtoStructFilter(filters any) (*structpb.Struct, error) {
+	if expr, ok := filters.(filter.Expr); ok {
+		m, err := filter.ToOperatorMap(expr)
+		if err != nil || m == nil {
+			return nil, err
+		}
+		filters = m
+	}
+
+	filterBytes, err := json.Marshal(filters)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,75 @@
+package qdrant
+
+import (
+	"fmt"
+
+	"github.com/tmc/langchaingo/vectorstores"
+	"github.com/tmc/langchaingo/vectorstores/filter"
+)
+
+// getFilters returns the Qdrant filter of the options. Filters are either
+// Qdrant filters, used as is, or filter.Expr values.
+func (s Store) getFilters(opts vectorstores.Options) (any, error) {
+	expr, ok := opts.Filters.(filter.Expr)
+	if !ok {
+		return opts.Filters, nil
+	}
+	if err := expr.Validate(); err != nil {
+		return nil, err
+	}
+	if expr.MatchesAll() {
+		return nil, nil
+	}
+	condition, err := exprCondition(expr)
+	if err != nil {
+		return nil, err
+	}
+	if _, isField := condition["key"]; isField {
+		return map[string]any{"must": []any{condition}}, nil
+	}
+	return condition, nil
+}
+
+// exprCondition translates e to a Qdrant condition. Logical operators become
+// nested filters, which Qdrant accepts wherever a condition is expected.
+func exprCondition(e filter.Expr) (map[string]any, error) {
+	switch e.Op { //nolint:exhaustive
+	case filter.OpAnd:
+		return nestedFilter("must", e.Operands)
+	case filter.OpOr:
+		return nestedFilter("should", e.Operands)
+	case filter.OpNot:
+		return nestedFilter("must_not", e.Operands)
+	case filter.OpEq:
+		return map[string]any{"key": e.Key, "match": map[string]any{"value": e.Value}}, nil
+	case filter.OpNe:
+		return nestedFilter("must_not", []filter.Expr{filter.Eq(e.Key, e.Value)})
+	case filter.OpIn:
+		return map[string]any{"key": e.Key, "match": map[string]any{"any": e.Values()}}, nil
+	case filter.OpNin:
+		return nestedFilter("must_not", []filter.Expr{filter.In(e.Key, e.Value)})
+	}
+
+	// Range operators are named like the filter operators.
+	if _, ok := filter.Number(e.Value); !ok {
+		return nil, fmt.Errorf("%w: %s on %q needs a number", filter.ErrInvalidFilter, e.Op, e.Key)
+	}
+	return map[string]any{"key": e.Key, "range": map[string]any{string(e.Op): e.Value}}, nil
+}
+
+func nestedFilter(clause string, operands []filter.Expr) (map[string]any, error) {
+	conditions := make([]any, 0, len(operands))
+	for _, operand := range operands {
+		// Operands matching everything are left out of must, and the other
+		// clauses have none.
+		if operand.MatchesAll() {
+			continue
+		}
+		condition, err := exprCondition(operand)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, condition)
+	}
+	return map[string]any{clause: conditions}, nil
+}
@@ -18,7 +18,10 @@ type Store struct {
 	contentKey     string
 }
 
-var _ vectorstores.VectorStore = Store{}
+var (
+	_ vectorstores.DocumentManager = Store{}
+	_ vectorstores.VectorSearcher  = Store{}
+)
 
 func New(opts ...Option) (Store, error) {
 	s, err := applyClientOptions(opts...)
@@ -47,18 +50,74 @@ func (s Store) AddDocuments(ctx context.Context,
 		return nil, errors.New("number of vectors from embedder does not match number of documents")
 	}
 
+	return s.upsertPoints(ctx, &s.qdrantURL, make([]string, len(docs)), vectors, s.payloads(docs))
+}
+
+// DeleteDocuments deletes the points with the given ids from the collection,
+// or all points matching the filters if ids is empty. Filters are Qdrant
+// filters or filter.Expr values.
+func (s Store) DeleteDocuments(ctx context.Context, ids []string, options ...vectorstores.Option) error {
+	opts := s.getOptions(options...)
+	filters, err := s.getFilters(opts)
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 && filters == nil {
+		return vectorstores.ErrNoDocumentsSelected
+	}
+
+	return s.deletePoints(ctx, &s.qdrantURL, ids, filters)
+}
+
+// UpsertDocuments embeds docs and stores them under ids, replacing any points
+// already stored under the same ids. Qdrant requires ids to be UUIDs or
+// unsigned integers.
+func (s Store) UpsertDocuments(ctx context.Context,
+	ids []string,
+	docs []schema.Document,
+	_ ...vectorstores.Option,
+) ([]string, error) {
+	if len(ids) != len(docs) {
+		return nil, vectorstores.ErrMismatchedIDs
+	}
+
+	texts := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		texts = append(texts, doc.PageContent)
+	}
+
+	vectors,
+		err := s.embedder.EmbedDocuments(ctx, texts)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(vectors) != len(docs) {
+		return nil, errors.New("number of vectors from embedder does not match number of documents")
+	}
+
+	return s.upsertPoints(ctx, &s.qdrantURL, ids, vectors, s.payloads(docs))
+}
+
+// GetByIDs returns the documents stored under ids in the collection.
+func (s Store) GetByIDs(ctx context.Context, ids []string, _ ...vectorstores.Option) ([]schema.Document, error) {
+	return s.retrievePoints(ctx, &s.qdrantURL, ids)
+}
+
+// payloads returns the Qdrant payloads for docs, which hold the document
+// metadata and the page content under the content key.
+func (s Store) payloads(docs []schema.Document) []map[string]interface{} {
 	metadatas := make([]map[string]interface{}, 0, len(docs))
 	for i := 0; i < len(docs); i++ {
 		metadata := make(map[string]interface{}, len(docs[i].Metadata))
 		for key, value := range docs[i].Metadata {
 			metadata[key] = value
 		}
-		metadata[s.contentKey] = texts[i]
+		metadata[s.contentKey] = docs[i].PageContent
 
 		metadatas = append(metadatas, metadata)
 	}
-
-	return s.upsertPoints(ctx, &s.qdrantURL, vectors, metadatas)
+	return metadatas
 }
 
 func (s Store) SimilaritySearch(ctx context.Context,
@@ -67,7 +126,10 @@ func (s Store) SimilaritySearch(ctx context.Context,
 ) ([]schema.Document, error) {
 	opts := s.getOptions(options...)
 
-	filters := s.getFilters(opts)
+	filters, err := s.getFilters(opts)
+	if err != nil {
+		return nil, err
+	}
 
 	scoreThreshold,
 		err := s.getScoreThreshold(opts)
@@ -81,7 +143,38 @@ func (s Store) SimilaritySearch(ctx context.Context,
 		return nil, err
 	}
 
-	return s.searchPoints(ctx, &s.qdrantURL, vector, numDocuments, scoreThreshold, filters)
+	docs, _, err := s.searchPoints(ctx, &s.qdrantURL, vector, numDocuments, scoreThreshold, filters, false)
+	return docs, err
+}
+
+// SimilaritySearchVectors does the same as SimilaritySearch, also returning
+// the embedding of the query and the stored vectors of the documents. The
+// collection must use a single unnamed vector.
+func (s Store) SimilaritySearchVectors(ctx context.Context,
+	query string, numDocuments int,
+	options ...vectorstores.Option,
+) (vectorstores.VectorSearchResult, error) {
+	opts := s.getOptions(options...)
+
+	scoreThreshold, err := s.getScoreThreshold(opts)
+	if err != nil {
+		return vectorstores.VectorSearchResult{}, err
+	}
+
+	vector, err := s.embedder.EmbedQuery(ctx, query)
+	if err != nil {
+		return vectorstores.VectorSearchResult{}, err
+	}
+
+	filters, err := s.getFilters(opts)
+	if err != nil {
+		return vectorstores.VectorSearchResult{}, err
+	}
+	docs, vectors, err := s.searchPoints(ctx, &s.qdrantURL, vector, numDocuments, scoreThreshold, filters, true)
+	if err != nil {
+		return vectorstores.VectorSearchResult{}, err
+	}
+	return vectorstores.VectorSearchResult{QueryVector: vector, Documents: docs, Vectors: vectors}, nil
 }
 
 func (s Store) getScoreThreshold(opts vectorstores.Options) (float32, error) {
@@ -91,14 +184,6 @@ func (s Store) getScoreThreshold(opts vectorstores.Options) (float32, error) {
 	return opts.ScoreThreshold, nil
 }
 
-func (s Store) getFilters(opts vectorstores.Options) any {
-	if opts.Filters != nil {
-		return opts.Filters
-	}
-
-	return nil
-}
-
 func (s Store) getOptions(options ...vectorstores.Option) vectorstores.Options {
 	opts := vectorstores.Options{}
 	for _, opt := range options {
@@ -8,26 +8,31 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 
 	"github.com/google/uuid"
 	"github.com/tmc/langchaingo/schema"
 )
 
-// upsertPoints updates or inserts points into the Qdrant collection.
+// upsertPoints updates or inserts points into the Qdrant collection. Empty
+// ids are replaced by new UUIDs.
 func (s Store) upsertPoints(
 	ctx context.Context,
 	baseURL *url.URL,
+	ids []string,
 	vectors [][]float32,
 	payloads []map[string]interface{},
 ) ([]string, error) {
-	ids := make([]string, len(vectors))
+	ids = append([]string(nil), ids...)
 	for i := range ids {
-		ids[i] = uuid.NewString()
+		if ids[i] == "" {
+			ids[i] = uuid.NewString()
+		}
 	}
 
 	payload := upsertBody{
 		Batch: upsertBatch{
-			IDs:      ids,
+			IDs:      pointIDs(ids),
 			Vectors:  vectors,
 			Payloads: payloads,
 		},
@@ -56,6 +61,7 @@ func (s Store) upsertPoints(
 }
 
 // searchPoints queries the Qdrant collection for points based on the provided parameters.
+// The vectors of the points are only returned if withVector is true.
 func (s Store) searchPoints(
 	ctx context.Context,
 	baseURL *url.URL,
@@ -63,10 +69,11 @@ func (s Store) searchPoints(
 	numVectors int,
 	scoreThreshold float32,
 	filter any,
-) ([]schema.Document, error) {
+	withVector bool,
+) ([]schema.Document, [][]float32, error) {
 	payload := searchBody{
 		WithPayload: true,
-		WithVector:  false,
+		WithVector:  withVector,
 		Vector:      vector,
 		Limit:       numVectors,
 		Filter:      filter,
@@ -86,12 +93,12 @@ func (s Store) searchPoints(
 		payload,
 	)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer body.Close()
 
 	if statusCode != http.StatusOK {
-		return nil, newAPIError("querying collection", body)
+		return nil, nil, newAPIError("querying collection", body)
 	}
 
 	var response searchResponse
@@ -99,13 +106,17 @@ func (s Store) searchPoints(
 	decoder := json.NewDecoder(body)
 	err = decoder.Decode(&response)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	docs := make([]schema.Document, len(response.Result))
+	var vectors [][]float32
+	if withVector {
+		vectors = make([][]float32, len(response.Result))
+	}
 	for i, match := range response.Result {
 		pageContent, ok := match.Payload[s.contentKey].(string)
 		if !ok {
-			return nil, fmt.Errorf("payload does not contain content key '%s'", s.contentKey)
+			return nil, nil, fmt.Errorf("payload does not contain content key '%s'", s.contentKey)
 		}
 		delete(match.Payload, s.contentKey)
 
@@ -116,11 +127,150 @@ func (s Store) searchPoints(
 		}
 
 		docs[i] = doc
+		if withVector {
+			vectors[i] = match.Vector
+		}
+	}
+
+	return docs, vectors, nil
+}
+
+// deletePoints deletes points from the Qdrant collection, either by id or,
+// if ids is empty, by filter.
+func (s Store) deletePoints(
+	ctx context.Context,
+	baseURL *url.URL,
+	ids []string,
+	filter any,
+) error {
+	payload := deleteBody{Filter: filter}
+	if len(ids) > 0 {
+		payload = deleteBody{Points: pointIDs(ids)}
+	}
+
+	url := baseURL.JoinPath("collections", s.collectionName, "points", "delete")
+	body,
+		status,
+		err := DoRequest(
+		ctx, *url,
+		s.apiKey,
+		http.MethodPost,
+		payload,
+	)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	if status == http.StatusOK {
+		return nil
+	}
+
+	return newAPIError("deleting points", body)
+}
+
+// retrievePoints retrieves points from the Qdrant collection by id.
+func (s Store) retrievePoints(
+	ctx context.Context,
+	baseURL *url.URL,
+	ids []string,
+) ([]schema.Document, error) {
+	payload := retrieveBody{
+		IDs:         pointIDs(ids),
+		WithPayload: true,
+	}
+
+	url := baseURL.JoinPath("collections", s.collectionName, "points")
+	body,
+		statusCode,
+		err := DoRequest(
+		ctx, *url,
+		s.apiKey,
+		http.MethodPost,
+		payload,
+	)
+	if err != nil {
+		return nil, err
 	}
+	defer body.Close()
 
+	if statusCode != http.StatusOK {
+		return nil, newAPIError("retrieving points", body)
+	}
+
+	var response retrieveResponse
+	if err := json.NewDecoder(body).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	found := make(map[string]schema.Document, len(response.Result))
+	for _, point := range response.Result {
+		pageContent, ok := point.Payload[s.contentKey].(string)
+		if !ok {
+			return nil, fmt.Errorf("payload does not contain content key '%s'", s.contentKey)
+		}
+		delete(point.Payload, s.contentKey)
+
+		found[point.ID.normalize()] = schema.Document{
+			PageContent: pageContent,
+			Metadata:    point.Payload,
+		}
+	}
+
+	docs := make([]schema.Document, 0, len(found))
+	for _, id := range ids {
+		if doc, ok := found[pointID(id).normalize()]; ok {
+			docs = append(docs, doc)
+		}
+	}
 	return docs, nil
 }
 
+// pointID is the id of a point, which Qdrant requires to be an unsigned
+// integer or a UUID. Integer ids are sent as JSON numbers.
+type pointID string
+
+func pointIDs(ids []string) []pointID {
+	pointIDs := make([]pointID, len(ids))
+	for i, id := range ids {
+		pointIDs[i] = pointID(id)
+	}
+	return pointIDs
+}
+
+func (id pointID) MarshalJSON() ([]byte, error) {
+	if n, err := strconv.ParseUint(string(id), 10, 64); err == nil {
+		return json.Marshal(n)
+	}
+	return json.Marshal(string(id))
+}
+
+func (id *pointID) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		*id = pointID(s)
+		return nil
+	}
+	*id = pointID(data)
+	return nil
+}
+
+// normalize returns the id in a canonical form, the decimal form of integer
+// ids and the lower case, hyphenated form of UUIDs, to match ids given in
+// different forms.
+func (id pointID) normalize() string {
+	if n, err := strconv.ParseUint(string(id), 10, 64); err == nil {
+		return strconv.FormatUint(n, 10)
+	}
+	if u, err := uuid.Parse(string(id)); err == nil {
+		return u.String()
+	}
+	return string(id)
+}
+
 // doRequest performs an HTTP request to the Qdrant API.
 func DoRequest(ctx context.Context,
 	url url.URL,
@@ -0,0 +1,50 @@
+package qdrant
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPointIDMarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	data, err := json.Marshal(pointIDs([]string{"42", "7c9e6679-7425-40de-944b-e07fc1f90ae7"}))
+	require.NoError(t, err)
+	require.JSONEq(t, `[42, "7c9e6679-7425-40de-944b-e07fc1f90ae7"]`, string(data))
+}
+
+func TestRetrievePoints(t *testing.T) {
+	t.Parallel()
+
+	var request map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		_, _ = w.Write([]byte(`{"result": [
+			{"id": "7c9e6679-7425-40de-944b-e07fc1f90ae7", "payload": {"content": "uuid"}},
+			{"id": 12345678901234567890, "payload": {"content": "large"}},
+			{"id": 42, "payload": {"content": "number"}}
+		]}`))
+	}))
+	defer server.Close()
+
+	baseURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	s := Store{collectionName: "test", contentKey: "content"}
+
+	docs, err := s.retrievePoints(context.Background(), baseURL,
+		[]string{"42", "12345678901234567890", "7C9E6679-7425-40DE-944B-E07FC1F90AE7", "1"})
+	require.NoError(t, err)
+	require.Equal(t, []any{
+		float64(42), float64(12345678901234567890), "7C9E6679-7425-40DE-944B-E07FC1F90AE7", float64(1),
+	}, request["ids"])
+	require.Len(t, docs, 3)
+	require.Equal(t, "number", docs[0].PageContent)
+	require.Equal(t, "large", docs[1].PageContent)
+	require.Equal(t, "uuid", docs[2].PageContent)
+}
@@ -6,7 +6,7 @@
 package qdrant
 
 type upsertBatch struct {
-	IDs      []string                 `json:"ids"`
+	IDs      []pointID                `json:"ids"`
 	Payloads []map[string]interface{} `json:"payloads"`
 	Vectors  [][]float32              `json:"vectors"`
 }
@@ -18,6 +18,7 @@ type upsertBody struct {
 type result struct {
 	Score   float32                `json:"score"`
 	Payload map[string]interface{} `json:"payload"`
+	Vector  []float32              `json:"vector"`
 }
 
 type searchResponse struct {
@@ -32,3 +33,22 @@ type searchBody struct {
 	WithVector     bool      `json:"with_vector"`
 	WithPayload    bool      `json:"with_payload"`
 }
+
+type deleteBody struct {
+	Points []pointID `json:"points,omitempty"`
+	Filter any       `json:"filter,omitempty"`
+}
+
+type retrieveBody struct {
+	IDs         []pointID `json:"ids"`
+	WithPayload bool      `json:"with_payload"`
+}
+
+type point struct {
+	ID      pointID                `json:"id"`
+	Payload map[string]interface{} `json:"payload"`
+}
+
+type retrieveResponse struct {
+	Result []point `json:"result"`
+}
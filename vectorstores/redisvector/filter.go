@@ -0,0 +1,182 @@
+package redisvector
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/tmc/langchaingo/vectorstores"
+	"github.com/tmc/langchaingo/vectorstores/filter"
+)
+
+// getFilters returns the pre-filter query of the filters, which are either a
+// redis search query or a filter.Expr.
+func (s Store) getFilters(opts vectorstores.Options) (string, error) {
+	switch filters := opts.Filters.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return filters, nil
+	case filter.Expr:
+		if err := filters.Validate(); err != nil {
+			return "", fmt.Errorf("%w: %w", ErrInvalidFilters, err)
+		}
+		query, err := s.exprQuery(filters)
+		if err != nil {
+			return "", fmt.Errorf("%w: %w", ErrInvalidFilters, err)
+		}
+		return query, nil
+	default:
+		return "", ErrInvalidFilters
+	}
+}
+
+// exprQuery translates e to the query syntax, or "" for an expression
+// matching everything. String values match tag fields of the index schema
+// exactly and other fields as text phrases; numbers match numeric fields.
+func (s Store) exprQuery(e filter.Expr) (string, error) {
+	if e.MatchesAll() {
+		return "", nil
+	}
+	switch e.Op { //nolint:exhaustive
+	case filter.OpAnd, filter.OpOr:
+		operands := make([]string, 0, len(e.Operands))
+		for _, operand := range e.Operands {
+			query, err := s.exprQuery(operand)
+			if err != nil {
+				return "", err
+			}
+			// Operands matching everything are left out of And, and Or has none.
+			if query != "" {
+				operands = append(operands, query)
+			}
+		}
+		if len(operands) == 1 {
+			return operands[0], nil
+		}
+		separator := " "
+		if e.Op == filter.OpOr {
+			separator = " | "
+		}
+		return "(" + strings.Join(operands, separator) + ")", nil
+	case filter.OpNot:
+		query, err := s.exprQuery(e.Operands[0])
+		if err != nil {
+			return "", err
+		}
+		return "-" + query, nil
+	case filter.OpNe:
+		return s.negatedQuery(filter.Eq(e.Key, e.Value))
+	case filter.OpNin:
+		return s.negatedQuery(filter.In(e.Key, e.Value))
+	case filter.OpEq:
+		return s.matchQuery(e.Key, []any{e.Value})
+	case filter.OpIn:
+		return s.matchQuery(e.Key, e.Values())
+	}
+
+	number, ok := filter.Number(e.Value)
+	if !ok {
+		return "", fmt.Errorf("%w: %s on %q needs a number", filter.ErrInvalidFilter, e.Op, e.Key)
+	}
+	value := formatNumber(number)
+	var lower, upper string
+	switch e.Op { //nolint:exhaustive
+	case filter.OpGt:
+		lower, upper = "("+value, "+inf"
+	case filter.OpGte:
+		lower, upper = value, "+inf"
+	case filter.OpLt:
+		lower, upper = "-inf", "("+value
+	default:
+		lower, upper = "-inf", value
+	}
+	return fmt.Sprintf("@%s:[%s %s]", s.attribute(e.Key), lower, upper), nil
+}
+
+func (s Store) negatedQuery(e filter.Expr) (string, error) {
+	query, err := s.exprQuery(e)
+	if err != nil {
+		return "", err
+	}
+	return "-(" + query + ")", nil
+}
+
+// matchQuery returns the query matching any of values, of which there is at
+// least one.
+func (s Store) matchQuery(key string, values []any) (string, error) {
+	attribute := s.attribute(key)
+	if _, isString := values[0].(string); !isString {
+		ranges := make([]string, len(values))
+		for i, value := range values {
+			number, ok := filter.Number(value)
+			if !ok {
+				return "", fmt.Errorf("%w: values on %q must be strings or numbers", filter.ErrInvalidFilter, key)
+			}
+			ranges[i] = fmt.Sprintf("@%s:[%s %s]", attribute, formatNumber(number), formatNumber(number))
+		}
+		if len(ranges) == 1 {
+			return ranges[0], nil
+		}
+		return "(" + strings.Join(ranges, " | ") + ")", nil
+	}
+
+	terms := make([]string, len(values))
+	tag := s.isTag(key)
+	for i, value := range values {
+		text, ok := value.(string)
+		if !ok {
+			return "", fmt.Errorf("%w: mixed value types on %q", filter.ErrInvalidFilter, key)
+		}
+		if tag {
+			terms[i] = escapeTag(text)
+		} else {
+			terms[i] = strconv.Quote(text)
+		}
+	}
+	if tag {
+		return fmt.Sprintf("@%s:{%s}", attribute, strings.Join(terms, " | ")), nil
+	}
+	return fmt.Sprintf("@%s:(%s)", attribute, strings.Join(terms, " | ")), nil
+}
+
+// attribute returns the name key is queried by in the index schema.
+func (s Store) attribute(key string) string {
+	if s.indexSchema != nil {
+		for _, f := range s.indexSchema.Tag {
+			if f.Name == key && f.As != "" {
+				return f.As
+			}
+		}
+	}
+	return key
+}
+
+func (s Store) isTag(key string) bool {
+	if s.indexSchema == nil {
+		return false
+	}
+	for _, f := range s.indexSchema.Tag {
+		if f.Name == key {
+			return true
+		}
+	}
+	return false
+}
+
+// escapeTag escapes the punctuation and spaces of a tag value.
+func escapeTag(value string) string {
+	var b strings.Builder
+	for _, r := range value {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func formatNumber(number float64) string {
+	return strconv.FormatFloat(number, 'f', -1, 64)
+}
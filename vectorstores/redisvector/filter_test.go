@@ -0,0 +1,69 @@
+package redisvector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/vectorstores"
+	"github.com/tmc/langchaingo/vectorstores/filter"
+)
+
+func TestGetFilters(t *testing.T) {
+	t.Parallel()
+
+	s := Store{indexSchema: &IndexSchema{Tag: []TagField{{Name: "kind"}, {Name: "lang"}}}}
+	tests := []struct {
+		name string
+		expr filter.Expr
+		want string
+	}{
+		{
+			"not over and",
+			filter.Not(filter.And(filter.Eq("kind", "manual"), filter.Gt("year", 2020))),
+			"-(@kind:{manual} @year:[(2020 +inf])",
+		},
+		{
+			"not over or",
+			filter.Not(filter.Or(filter.Eq("kind", "manual"), filter.Lt("year", 2020))),
+			"-(@kind:{manual} | @year:[-inf (2020])",
+		},
+		{
+			"nin",
+			filter.Nin("lang", []string{"de", "fr"}),
+			"-(@lang:{de | fr})",
+		},
+		{
+			"numeric range",
+			filter.And(filter.Gte("year", 2020), filter.Lte("year", 2024.5)),
+			"(@year:[2020 +inf] @year:[-inf 2024.5])",
+		},
+		{
+			"string quoting",
+			filter.Or(filter.Eq("kind", "user's manual"), filter.Eq("title", `the "Dune" saga`)),
+			`(@kind:{user\'s\ manual} | @title:("the \"Dune\" saga"))`,
+		},
+		{
+			"matches everything",
+			filter.Or(filter.Eq("kind", "manual"), filter.And(filter.Nin("lang", []string{}))),
+			"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			query, err := s.getFilters(vectorstores.Options{Filters: tt.expr})
+			require.NoError(t, err)
+			require.Equal(t, tt.want, query)
+		})
+	}
+
+	for _, e := range []filter.Expr{
+		filter.In("kind", []string{}),
+		filter.Or(),
+		filter.And(filter.Eq("kind", "manual"), filter.Not(filter.And())),
+	} {
+		_, err := s.getFilters(vectorstores.Options{Filters: e})
+		require.ErrorIs(t, err, ErrInvalidFilters)
+		require.ErrorIs(t, err, filter.ErrInvalidFilter)
+	}
+}
@@ -190,3 +190,29 @@ func TestIndexSearchAsCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestIndexSearchReturnVector(t *testing.T) {
+	t.Parallel()
+
+	search, err := NewIndexVectorSearch("demo", []float32{0.111}, WithReturns([]string{"job"}))
+	require.NoError(t, err)
+	search.returnVector = true
+	assert.Equal(t,
+		"FT.SEARCH demo (*)=>[KNN 1 @content_vector $vector AS distance] RETURN 3 job distance content_vector SORTBY distance ASC DIALECT 2 LIMIT 0 1 PARAMS 2 vector \xf8S\xe3=",
+		strings.Join(search.AsCommand(), " "))
+}
+
+func TestParseVector(t *testing.T) {
+	t.Parallel()
+
+	vector, err := parseVector(VectorString32([]float32{0.5, -1, 2}), 3)
+	require.NoError(t, err)
+	assert.Equal(t, []float32{0.5, -1, 2}, vector)
+
+	vector, err = parseVector(VectorString64([]float64{0.5, -1, 2}), 3)
+	require.NoError(t, err)
+	assert.Equal(t, []float32{0.5, -1, 2}, vector)
+
+	_, err = parseVector(VectorString32([]float32{0.5, -1}), 3)
+	require.ErrorIs(t, err, ErrInvalidEmbeddingVector)
+}
@@ -18,6 +18,7 @@ type IndexVectorSearch struct {
 	offset         int
 	limit          int
 	sortBy         []string
+	returnVector   bool
 }
 
 type SearchOption func(s *IndexVectorSearch)
@@ -123,6 +124,9 @@ func (s IndexVectorSearch) AsCommand() []string {
 
 	if l := len(s.returns); l > 0 {
 		s.returns = append(s.returns, defaultDistanceFieldKey)
+		if s.returnVector {
+			s.returns = append(s.returns, vectorKey)
+		}
 		cmd = append(cmd, "RETURN", strconv.Itoa(len(s.returns)))
 		cmd = append(cmd, s.returns...)
 	}
@@ -161,3 +165,22 @@ func VectorString64(v []float64) string {
 	}
 	return unsafe.String(unsafe.SliceData(b), len(b))
 }
+
+// parse a vector of dims dimensions stored as FLOAT32 or FLOAT64 by
+// VectorString32 or VectorString64.
+func parseVector(v string, dims int) ([]float32, error) {
+	vector := make([]float32, dims)
+	switch len(v) {
+	case dims * 4:
+		for i := range vector {
+			vector[i] = math.Float32frombits(binary.LittleEndian.Uint32([]byte(v[i*4 : i*4+4])))
+		}
+	case dims * 8:
+		for i := range vector {
+			vector[i] = float32(math.Float64frombits(binary.LittleEndian.Uint64([]byte(v[i*8 : i*8+8]))))
+		}
+	default:
+		return nil, fmt.Errorf("%w: %d bytes for %d dimensions", ErrInvalidEmbeddingVector, len(v), dims)
+	}
+	return vector, nil
+}
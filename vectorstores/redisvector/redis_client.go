@@ -24,6 +24,19 @@ type RedisClient interface {
 	AddDocsWithHash(ctx context.Context, prefix string, docs []schema.Document) ([]string, error)
 	// TODO AddDocsWithJSON
 	Search(ctx context.Context, search IndexVectorSearch) (int64, []schema.Document, error)
+	// SearchWithVectors does the same as Search, also returning the content
+	// vectors of the documents found, as stored.
+	SearchWithVectors(ctx context.Context, search IndexVectorSearch) (int64, []schema.Document, []string, error)
+	// ReplaceDocsWithHash stores each doc under the key at the same index,
+	// removing any fields left from a previous document under that key.
+	ReplaceDocsWithHash(ctx context.Context, keys []string, docs []schema.Document) error
+	// GetDocsWithHash returns the documents stored under keys, skipping keys
+	// that don't exist.
+	GetDocsWithHash(ctx context.Context, keys []string) ([]schema.Document, error)
+	// DeleteDocs deletes the documents stored under keys.
+	DeleteDocs(ctx context.Context, keys []string) error
+	// SearchKeys returns up to limit keys of documents in index matching query.
+	SearchKeys(ctx context.Context, index string, query string, limit int) ([]string, error)
 }
 
 type RueidisClient struct {
@@ -100,9 +113,7 @@ func (c RueidisClient) AddDocsWithHash(ctx context.Context, prefix string, docs
 }
 
 func (c RueidisClient) Search(ctx context.Context, search IndexVectorSearch) (int64, []schema.Document, error) {
-	cmds := search.AsCommand()
-	// fmt.Println(strings.Join(cmds, " "))
-	total, docs, err := c.client.Do(ctx, c.client.B().Arbitrary(cmds[0]).Keys(cmds[1]).Args(cmds[2:]...).Build()).AsFtSearch()
+	total, docs, err := c.search(ctx, search)
 	if err != nil {
 		return 0, nil, err
 	}
@@ -110,7 +121,110 @@ func (c RueidisClient) Search(ctx context.Context, search IndexVectorSearch) (in
 	return total, convertFTSearchResIntoDocSchema(docs), nil
 }
 
+func (c RueidisClient) SearchWithVectors(ctx context.Context, search IndexVectorSearch) (int64, []schema.Document, []string, error) { //nolint:lll
+	search.returnVector = true
+	total, docs, err := c.search(ctx, search)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+
+	vectors := make([]string, len(docs))
+	for i, doc := range docs {
+		vectors[i] = doc.Doc[defaultContentVectorFieldKey]
+	}
+	return total, convertFTSearchResIntoDocSchema(docs), vectors, nil
+}
+
+func (c RueidisClient) search(ctx context.Context, search IndexVectorSearch) (int64, []rueidis.FtSearchDoc, error) {
+	cmds := search.AsCommand()
+	// fmt.Println(strings.Join(cmds, " "))
+	return c.client.Do(ctx, c.client.B().Arbitrary(cmds[0]).Keys(cmds[1]).Args(cmds[2:]...).Build()).AsFtSearch()
+}
+
+func (c RueidisClient) ReplaceDocsWithHash(ctx context.Context, keys []string, docs []schema.Document) error {
+	cmds := make([]rueidis.Completed, 0, len(docs)*2)
+	for i, doc := range docs {
+		cmds = append(cmds,
+			c.client.B().Del().Key(keys[i]).Build(),
+			c.hsetCMD(keys[i], doc),
+		)
+	}
+	errs := make([]error, 0, len(docs))
+	for _, res := range c.client.DoMulti(ctx, cmds...) {
+		if res.Error() != nil {
+			errs = append(errs, res.Error())
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (c RueidisClient) GetDocsWithHash(ctx context.Context, keys []string) ([]schema.Document, error) {
+	cmds := make([]rueidis.Completed, 0, len(keys))
+	for _, key := range keys {
+		cmds = append(cmds, c.client.B().Hgetall().Key(key).Build())
+	}
+	docs := make([]schema.Document, 0, len(keys))
+	for i, res := range c.client.DoMulti(ctx, cmds...) {
+		fields, err := res.AsStrMap()
+		if err != nil {
+			return nil, err
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		docs = append(docs, convertFTSearchResIntoDocSchema([]rueidis.FtSearchDoc{{Key: keys[i], Doc: fields}})...)
+	}
+	return docs, nil
+}
+
+func (c RueidisClient) DeleteDocs(ctx context.Context, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return c.client.Do(ctx, c.client.B().Del().Key(keys...).Build()).Error()
+}
+
+func (c RueidisClient) SearchKeys(ctx context.Context, index string, query string, limit int) ([]string, error) {
+	msg, err := c.client.Do(ctx, c.client.B().FtSearch().Index(index).Query(query).
+		Nocontent().Limit().OffsetNum(0, int64(limit)).Dialect(2).Build()).ToMessage()
+	if err != nil {
+		return nil, err
+	}
+
+	// RESP3 replies with a map that AsFtSearch understands, RESP2 with a
+	// flat array of the total followed by the keys.
+	if msg.IsMap() {
+		_, docs, err := msg.AsFtSearch()
+		if err != nil {
+			return nil, err
+		}
+		keys := make([]string, 0, len(docs))
+		for _, doc := range docs {
+			keys = append(keys, doc.Key)
+		}
+		return keys, nil
+	}
+	values, err := msg.ToArray()
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(values))
+	for _, v := range values[1:] {
+		key, err := v.ToString()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
 func (c RueidisClient) generateHSetCMD(prefix string, doc schema.Document) (string, rueidis.Completed) {
+	docID := getDocIDWithMetaData(prefix, doc.Metadata)
+	return docID, c.hsetCMD(docID, doc)
+}
+
+func (c RueidisClient) hsetCMD(docID string, doc schema.Document) rueidis.Completed {
 	kvs := make([]string, 0, len(maps.Keys(doc.Metadata))*2)
 	for k, v := range doc.Metadata {
 		kvs = append(kvs, k)
@@ -127,8 +241,7 @@ func (c RueidisClient) generateHSetCMD(prefix string, doc schema.Document) (stri
 			kvs = append(kvs, fmt.Sprintf("%v", v))
 		}
 	}
-	docID := getDocIDWithMetaData(prefix, doc.Metadata)
-	return docID, c.client.B().Arbitrary("Hmset").Keys(docID).Args(kvs...).Build()
+	return c.client.B().Arbitrary("Hmset").Keys(docID).Args(kvs...).Build()
 }
 
 // getPrefix get prefix with index name.
@@ -3,6 +3,7 @@ package redisvector
 import (
 	"context"
 	"errors"
+	"strings"
 
 	"github.com/tmc/langchaingo/embeddings"
 	"github.com/tmc/langchaingo/schema"
@@ -36,7 +37,10 @@ type Store struct {
 	schemaGenerator        *schemaGenerator
 }
 
-var _ vectorstores.VectorStore = &Store{}
+var (
+	_ vectorstores.DocumentManager = &Store{}
+	_ vectorstores.VectorSearcher  = &Store{}
+)
 
 // New creates a new Store with options.
 func New(ctx context.Context, opts ...Option) (*Store, error) {
@@ -109,20 +113,32 @@ func (s *Store) AddDocuments(ctx context.Context, docs []schema.Document, _ ...v
 // Support options:
 //
 //	WithScoreThreshold:
-//	WithFilters: filter string should match redis search pre-filter query pattern.(eg: @title:Dune)
+//	WithFilters: filter string should match redis search pre-filter query pattern.(eg: @title:Dune), or be a filter.Expr
 //		ref: https://redis.io/docs/latest/develop/interact/search-and-query/advanced-concepts/vectors/#pre-filter-query-attributes-hybrid-approach
 //	WithEmbedder: if set, it will embed query string with this embedder; otherwise embed with vector's embedder
 //
 // ref: https://redis.io/docs/latest/develop/interact/search-and-query/advanced-concepts/vectors/#pre-filter-query-attributes-hybrid-approach
 func (s *Store) SimilaritySearch(ctx context.Context, query string, numDocuments int, options ...vectorstores.Option) ([]schema.Document, error) {
+	result, err := s.similaritySearch(ctx, query, numDocuments, false, options...)
+	return result.Documents, err
+}
+
+// SimilaritySearchVectors does the same as SimilaritySearch, also returning
+// the embedding of the query and the content vectors of the documents.
+func (s *Store) SimilaritySearchVectors(ctx context.Context, query string, numDocuments int, options ...vectorstores.Option) (vectorstores.VectorSearchResult, error) { //nolint:lll
+	return s.similaritySearch(ctx, query, numDocuments, true, options...)
+}
+
+func (s *Store) similaritySearch(ctx context.Context, query string, numDocuments int, withVector bool, options ...vectorstores.Option) (vectorstores.VectorSearchResult, error) { //nolint:lll
+	var result vectorstores.VectorSearchResult
 	opts := s.getOptions(options...)
 	scoreThreshold, err := s.getScoreThreshold(opts)
 	if err != nil {
-		return nil, err
+		return result, err
 	}
 	filter, err := s.getFilters(opts)
 	if err != nil {
-		return nil, err
+		return result, err
 	}
 	embedder := s.embedder
 	if opts.Embedder != nil {
@@ -130,7 +146,7 @@ func (s *Store) SimilaritySearch(ctx context.Context, query string, numDocuments
 	}
 	embedderData, err := embedder.EmbedQuery(ctx, query)
 	if err != nil {
-		return nil, err
+		return result, err
 	}
 
 	searchOpts := []SearchOption{WithScoreThreshold(scoreThreshold), WithOffsetLimit(0, numDocuments), WithPreFilters(filter)}
@@ -143,15 +159,129 @@ func (s *Store) SimilaritySearch(ctx context.Context, query string, numDocuments
 		embedderData,
 		searchOpts...,
 	)
+	if err != nil {
+		return result, err
+	}
+
+	if !withVector {
+		_, result.Documents, err = s.client.Search(ctx, *search)
+		return result, err
+	}
+
+	_, docs, vectors, err := s.client.SearchWithVectors(ctx, *search)
+	if err != nil {
+		return result, err
+	}
+	result.QueryVector = embedderData
+	result.Documents = docs
+	result.Vectors = make([][]float32, len(vectors))
+	for i, v := range vectors {
+		if result.Vectors[i], err = parseVector(v, len(embedderData)); err != nil {
+			return vectorstores.VectorSearchResult{}, err
+		}
+	}
+	return result, nil
+}
+
+// DeleteDocuments deletes the documents with the given ids, as returned by
+// AddDocuments, or all documents in the index matching the filters if ids is
+// empty. Filters use the redis search query syntax (eg: @title:Dune) or are
+// filter.Expr values.
+func (s *Store) DeleteDocuments(ctx context.Context, ids []string, options ...vectorstores.Option) error {
+	if len(ids) > 0 {
+		return s.client.DeleteDocs(ctx, s.keys(ids))
+	}
+
+	opts := s.getOptions(options...)
+	filter, err := s.getFilters(opts)
+	if err != nil {
+		return err
+	}
+	if filter == "" {
+		return vectorstores.ErrNoDocumentsSelected
+	}
+
+	// Deleted documents leave the index, so search until nothing matches.
+	const batchSize = 1000
+	for {
+		keys, err := s.client.SearchKeys(ctx, s.indexName, filter, batchSize)
+		if err != nil {
+			return err
+		}
+		if len(keys) == 0 {
+			return nil
+		}
+		if err := s.client.DeleteDocs(ctx, keys); err != nil {
+			return err
+		}
+	}
+}
+
+// UpsertDocuments adds the documents to redis under ids, replacing any
+// documents already stored under the same ids. Ids may be given with or
+// without the `doc:{index_name}:` prefix; the returned ids always have it.
+func (s *Store) UpsertDocuments(ctx context.Context,
+	ids []string,
+	docs []schema.Document,
+	_ ...vectorstores.Option,
+) ([]string, error) {
+	if len(ids) != len(docs) {
+		return nil, vectorstores.ErrMismatchedIDs
+	}
+	if len(docs) == 0 {
+		return nil, nil
+	}
+
+	err := s.appendDocumentsWithVectors(ctx, docs)
 	if err != nil {
 		return nil, err
 	}
 
-	_, docs, err := s.client.Search(ctx, *search)
+	indexSchema, err := generateSchemaWithMetadata(docs[0].Metadata)
 	if err != nil {
 		return nil, err
 	}
-	return docs, nil
+
+	if s.indexSchema == nil {
+		s.indexSchema = indexSchema
+	}
+
+	if s.createIndexIfNotExists && !s.client.CheckIndexExists(ctx, s.indexName) {
+		if err := s.client.CreateIndexIfNotExists(ctx, s.indexName, indexSchema); err != nil {
+			return nil, err
+		}
+	}
+
+	keys := s.keys(ids)
+	for i := range keys {
+		if ids[i] == "" {
+			keys[i] = getDocIDWithMetaData(getPrefix(s.indexName), docs[i].Metadata)
+		}
+	}
+	if err := s.client.ReplaceDocsWithHash(ctx, keys, docs); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// GetByIDs returns the documents stored under ids, with or without the
+// `doc:{index_name}:` prefix.
+func (s *Store) GetByIDs(ctx context.Context, ids []string, _ ...vectorstores.Option) ([]schema.Document, error) {
+	return s.client.GetDocsWithHash(ctx, s.keys(ids))
+}
+
+// keys returns the redis keys for ids, adding the index prefix where missing.
+func (s *Store) keys(ids []string) []string {
+	prefix := getPrefix(s.indexName) + ":"
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		if strings.HasPrefix(id, prefix) {
+			keys[i] = id
+		} else {
+			keys[i] = prefix + id
+		}
+	}
+	return keys
 }
 
 func (s *Store) DropIndex(ctx context.Context, index string, deleteDocuments bool) error {
@@ -176,17 +306,6 @@ func (s Store) getScoreThreshold(opts vectorstores.Options) (float32, error) {
 	return opts.ScoreThreshold, nil
 }
 
-// getFilters return metadata filters.
-func (s Store) getFilters(opts vectorstores.Options) (string, error) {
-	if opts.Filters != nil {
-		if filters, ok := opts.Filters.(string); ok {
-			return filters, nil
-		}
-		return "", ErrInvalidFilters
-	}
-	return "", nil
-}
-
 // append content & content_vector into doc.Metadata.
 func (s Store) appendDocumentsWithVectors(ctx context.Context, docs []schema.Document) error {
 	if len(docs) == 0 {
@@ -302,6 +302,13 @@ func TestSimilaritySearch(t *testing.T) {
 	assert.Len(t, docs, 5)
 	assert.Len(t, docs[0].Metadata, 3)
 
+	// search with the vectors of the documents
+	result, err := store.SimilaritySearchVectors(ctx, "Tokyo", 5)
+	require.NoError(t, err)
+	assert.Len(t, result.Documents, 5)
+	assert.Len(t, result.Vectors, 5)
+	assert.Len(t, result.Vectors[0], len(result.QueryVector))
+
 	// search with score threshold
 	docs, err = store.SimilaritySearch(ctx, "Tokyo", 2,
 		vectorstores.WithScoreThreshold(0.5),
@@ -30,7 +30,17 @@ func (r Retriever) GetRelevantDocuments(ctx context.Context, query string) ([]sc
 		r.CallbacksHandler.HandleRetrieverStart(ctx, query)
 	}
 
-	docs, err := r.v.SimilaritySearch(ctx, query, r.numDocs, r.options...)
+	var opts Options
+	for _, opt := range r.options {
+		opt(&opts)
+	}
+	var docs []schema.Document
+	var err error
+	if opts.SearchType == SearchTypeMaxMarginalRelevance {
+		docs, err = MaxMarginalRelevanceSearch(ctx, r.v, query, r.numDocs, opts.FetchK, opts.Lambda, r.options...)
+	} else {
+		docs, err = r.v.SimilaritySearch(ctx, query, r.numDocs, r.options...)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -43,7 +53,8 @@ func (r Retriever) GetRelevantDocuments(ctx context.Context, query string) ([]sc
 }
 
 // ToRetriever takes a vector store and returns a retriever using the
-// vector store to retrieve documents.
+// vector store to retrieve documents. Pass WithMaxMarginalRelevance to
+// search with MaxMarginalRelevanceSearch instead of SimilaritySearch.
 func ToRetriever(vectorStore VectorStore, numDocuments int, options ...Option) Retriever {
 	return Retriever{
 		v:       vectorStore,
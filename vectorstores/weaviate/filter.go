@@ -0,0 +1,100 @@
+package weaviate
+
+import (
+	"fmt"
+
+	"github.com/tmc/langchaingo/vectorstores/filter"
+	"github.com/weaviate/weaviate-go-client/v4/weaviate/filters"
+)
+
+var _operators = map[filter.Operator]filters.WhereOperator{ //nolint:gochecknoglobals
+	filter.OpEq:  filters.Equal,
+	filter.OpNe:  filters.NotEqual,
+	filter.OpGt:  filters.GreaterThan,
+	filter.OpGte: filters.GreaterThanEqual,
+	filter.OpLt:  filters.LessThan,
+	filter.OpLte: filters.LessThanEqual,
+	filter.OpIn:  filters.ContainsAny,
+	filter.OpAnd: filters.And,
+	filter.OpOr:  filters.Or,
+}
+
+// whereFromExpr translates e to a where filter on the object properties. It
+// returns nil for an expression matching everything.
+func whereFromExpr(e filter.Expr) (*filters.WhereBuilder, error) {
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+	return exprWhere(filter.Normalize(e))
+}
+
+func exprWhere(e filter.Expr) (*filters.WhereBuilder, error) {
+	if e.MatchesAll() {
+		return nil, nil
+	}
+	switch e.Op { //nolint:exhaustive
+	case filter.OpAnd, filter.OpOr:
+		operands := make([]*filters.WhereBuilder, 0, len(e.Operands))
+		for _, operand := range e.Operands {
+			where, err := exprWhere(operand)
+			if err != nil {
+				return nil, err
+			}
+			// Operands matching everything are left out of And, and Or has none.
+			if where != nil {
+				operands = append(operands, where)
+			}
+		}
+		if len(operands) == 1 {
+			return operands[0], nil
+		}
+		return filters.Where().WithOperator(_operators[e.Op]).WithOperands(operands), nil
+	case filter.OpNin:
+		// Weaviate has no negated ContainsAny.
+		values := e.Values()
+		operands := make([]filter.Expr, len(values))
+		for i, value := range values {
+			operands[i] = filter.Ne(e.Key, value)
+		}
+		return exprWhere(filter.And(operands...))
+	case filter.OpIn:
+		return withValues(filters.Where().WithPath([]string{e.Key}).WithOperator(filters.ContainsAny), e.Key, e.Values())
+	}
+	return withValues(filters.Where().WithPath([]string{e.Key}).WithOperator(_operators[e.Op]), e.Key, []any{e.Value})
+}
+
+// withValues sets the values of a comparison, which must share a type.
+func withValues(where *filters.WhereBuilder, key string, values []any) (*filters.WhereBuilder, error) {
+	switch values[0].(type) {
+	case string:
+		texts := make([]string, len(values))
+		for i, value := range values {
+			text, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("%w: mixed value types on %q", filter.ErrInvalidFilter, key)
+			}
+			texts[i] = text
+		}
+		return where.WithValueText(texts...), nil
+	case bool:
+		booleans := make([]bool, len(values))
+		for i, value := range values {
+			boolean, ok := value.(bool)
+			if !ok {
+				return nil, fmt.Errorf("%w: mixed value types on %q", filter.ErrInvalidFilter, key)
+			}
+			booleans[i] = boolean
+		}
+		return where.WithValueBoolean(booleans...), nil
+	default:
+		numbers := make([]float64, len(values))
+		for i, value := range values {
+			number, ok := filter.Number(value)
+			if !ok {
+				return nil, fmt.Errorf("%w: mixed value types on %q", filter.ErrInvalidFilter, key)
+			}
+			numbers[i] = number
+		}
+		return where.WithValueNumber(numbers...), nil
+	}
+}
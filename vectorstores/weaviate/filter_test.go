@@ -0,0 +1,114 @@
+package weaviate
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/vectorstores/filter"
+	"github.com/weaviate/weaviate-go-client/v4/weaviate/filters"
+)
+
+func TestWhereFromExpr(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		expr filter.Expr
+		want string
+	}{
+		{
+			"not over and",
+			filter.Not(filter.And(filter.Eq("kind", "manual"), filter.Gt("year", 2020))),
+			`{"operator": "Or", "operands": [
+				{"operator": "NotEqual", "path": ["kind"], "valueText": "manual"},
+				{"operator": "LessThanEqual", "path": ["year"], "valueNumber": 2020}
+			]}`,
+		},
+		{
+			"not over or",
+			filter.Not(filter.Or(filter.Eq("kind", "manual"), filter.Lt("year", 2020))),
+			`{"operator": "And", "operands": [
+				{"operator": "NotEqual", "path": ["kind"], "valueText": "manual"},
+				{"operator": "GreaterThanEqual", "path": ["year"], "valueNumber": 2020}
+			]}`,
+		},
+		{
+			"nin",
+			filter.Nin("lang", []string{"de", "fr"}),
+			`{"operator": "And", "operands": [
+				{"operator": "NotEqual", "path": ["lang"], "valueText": "de"},
+				{"operator": "NotEqual", "path": ["lang"], "valueText": "fr"}
+			]}`,
+		},
+		{
+			"numeric range",
+			filter.And(filter.Gte("year", 2020), filter.Lt("year", 2024.5)),
+			`{"operator": "And", "operands": [
+				{"operator": "GreaterThanEqual", "path": ["year"], "valueNumber": 2020},
+				{"operator": "LessThan", "path": ["year"], "valueNumber": 2024.5}
+			]}`,
+		},
+		{
+			"string quoting",
+			filter.Eq("title", `the "Dune" saga`),
+			`{"operator": "Equal", "path": ["title"], "valueText": "the \"Dune\" saga"}`,
+		},
+		{
+			"not over and with an operand matching everything",
+			filter.Not(filter.And(filter.Eq("kind", "manual"), filter.Nin("lang", []string{}))),
+			`{"operator": "NotEqual", "path": ["kind"], "valueText": "manual"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			where, err := whereFromExpr(tt.expr)
+			require.NoError(t, err)
+			require.JSONEq(t, tt.want, whereJSON(t, where))
+		})
+	}
+
+	where, err := whereFromExpr(filter.Or(filter.Eq("kind", "manual"), filter.And(filter.Nin("lang", []string{}))))
+	require.NoError(t, err)
+	require.Nil(t, where)
+
+	for _, e := range []filter.Expr{
+		filter.In("kind", []string{}),
+		filter.Or(),
+		filter.And(filter.Eq("kind", "manual"), filter.Not(filter.And())),
+	} {
+		_, err := whereFromExpr(e)
+		require.ErrorIs(t, err, filter.ErrInvalidFilter)
+	}
+}
+
+// whereJSON returns the JSON of where without its null fields.
+func whereJSON(t *testing.T, where *filters.WhereBuilder) string {
+	t.Helper()
+
+	data, err := json.Marshal(where.Build())
+	require.NoError(t, err)
+	var v any
+	require.NoError(t, json.Unmarshal(data, &v))
+	var dropNulls func(v any)
+	dropNulls = func(v any) {
+		switch v := v.(type) {
+		case map[string]any:
+			for key, value := range v {
+				if value == nil {
+					delete(v, key)
+				}
+				dropNulls(value)
+			}
+		case []any:
+			for _, value := range v {
+				dropNulls(value)
+			}
+		}
+	}
+	dropNulls(v)
+	data, err = json.Marshal(v)
+	require.NoError(t, err)
+	return string(data)
+}
@@ -0,0 +1,137 @@
+package weaviate
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/google/uuid"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/vectorstores"
+	"github.com/weaviate/weaviate-go-client/v4/weaviate/fault"
+	"github.com/weaviate/weaviate-go-client/v4/weaviate/filters"
+	"github.com/weaviate/weaviate/entities/models"
+)
+
+var _ vectorstores.DocumentManager = Store{}
+
+// DeleteDocuments deletes the objects with the given ids from the name space,
+// or all objects matching the `*filters.WhereBuilder` or `filter.Expr` filter
+// if ids is empty.
+func (s Store) DeleteDocuments(ctx context.Context, ids []string, options ...vectorstores.Option) error {
+	opts := s.getOptions(options...)
+	filter := s.getFilters(opts)
+	if len(ids) > 0 {
+		filter = filters.Where().WithPath([]string{"id"}).WithOperator(filters.ContainsAny).WithValueText(ids...)
+	} else if filter == nil {
+		return vectorstores.ErrNoDocumentsSelected
+	}
+
+	whereBuilder, err := s.createWhereBuilder(s.getNameSpace(opts), filter)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.Batch().ObjectsBatchDeleter().
+		WithClassName(s.indexName).
+		WithWhere(whereBuilder).
+		Do(ctx)
+	return err
+}
+
+// UpsertDocuments creates vector embeddings from the documents using the
+// embedder and stores them as objects with the given ids, which must be
+// UUIDs. Existing objects with the same ids are replaced.
+func (s Store) UpsertDocuments(
+	ctx context.Context,
+	ids []string,
+	docs []schema.Document,
+	options ...vectorstores.Option,
+) ([]string, error) {
+	if len(ids) != len(docs) {
+		return nil, vectorstores.ErrMismatchedIDs
+	}
+	if len(docs) == 0 {
+		return nil, nil
+	}
+	opts := s.getOptions(options...)
+	nameSpace := s.getNameSpace(opts)
+
+	texts := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		texts = append(texts, doc.PageContent)
+	}
+	vectors, err := opts.Embedder.EmbedDocuments(ctx, texts)
+	if err != nil {
+		return nil, err
+	}
+	if len(vectors) != len(docs) {
+		return nil, ErrEmbedderWrongNumberVectors
+	}
+
+	objects := make([]*models.Object, 0, len(docs))
+	resultIDs := make([]string, len(docs))
+	for i, doc := range docs {
+		id := ids[i]
+		if id == "" {
+			id = uuid.New().String()
+		}
+		resultIDs[i] = id
+
+		metadata := make(map[string]any, len(doc.Metadata)+2)
+		for key, value := range doc.Metadata {
+			metadata[key] = value
+		}
+		metadata[s.textKey] = doc.PageContent
+		metadata[s.nameSpaceKey] = nameSpace
+
+		objects = append(objects, &models.Object{
+			Class:      s.indexName,
+			ID:         strfmt.UUID(id),
+			Vector:     vectors[i],
+			Properties: metadata,
+		})
+	}
+	if _, err := s.client.Batch().ObjectsBatcher().WithObjects(objects...).Do(ctx); err != nil {
+		return nil, err
+	}
+	return resultIDs, nil
+}
+
+// GetByIDs returns the documents stored in the name space under ids.
+func (s Store) GetByIDs(ctx context.Context, ids []string, options ...vectorstores.Option) ([]schema.Document, error) {
+	opts := s.getOptions(options...)
+	nameSpace := s.getNameSpace(opts)
+
+	docs := make([]schema.Document, 0, len(ids))
+	for _, id := range ids {
+		objects, err := s.client.Data().ObjectsGetter().
+			WithClassName(s.indexName).
+			WithID(id).
+			Do(ctx)
+		var clientErr *fault.WeaviateClientError
+		if errors.As(err, &clientErr) && clientErr.StatusCode == http.StatusNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, object := range objects {
+			properties, ok := object.Properties.(map[string]any)
+			if !ok || properties[s.nameSpaceKey] != nameSpace {
+				continue
+			}
+			pageContent, ok := properties[s.textKey].(string)
+			if !ok {
+				return nil, ErrMissingTextKey
+			}
+			delete(properties, s.textKey)
+			delete(properties, s.nameSpaceKey)
+			docs = append(docs, schema.Document{
+				PageContent: pageContent,
+				Metadata:    properties,
+			})
+		}
+	}
+	return docs, nil
+}
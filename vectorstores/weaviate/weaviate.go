@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"strings"
 
 	"github.com/go-openapi/strfmt"
@@ -12,6 +13,7 @@ import (
 	"github.com/tmc/langchaingo/embeddings"
 	"github.com/tmc/langchaingo/schema"
 	"github.com/tmc/langchaingo/vectorstores"
+	"github.com/tmc/langchaingo/vectorstores/filter"
 	"github.com/weaviate/weaviate-go-client/v4/weaviate"
 	"github.com/weaviate/weaviate-go-client/v4/weaviate/auth"
 	"github.com/weaviate/weaviate-go-client/v4/weaviate/filters"
@@ -36,6 +38,9 @@ var (
 	ErrInvalidFilter = errors.New("invalid filter")
 )
 
+// _vectorField is the additional field weaviate returns object vectors in.
+const _vectorField = "vector"
+
 // Store is a wrapper around the weaviate client.
 type Store struct {
 	embedder embeddings.Embedder
@@ -61,7 +66,10 @@ type Store struct {
 	additionalFields []string
 }
 
-var _ vectorstores.VectorStore = Store{}
+var (
+	_ vectorstores.VectorStore    = Store{}
+	_ vectorstores.VectorSearcher = Store{}
+)
 
 // New creates a new Store with options.
 // When using weaviate,
@@ -154,23 +162,49 @@ func (s Store) SimilaritySearch(
 	numDocuments int,
 	options ...vectorstores.Option,
 ) ([]schema.Document, error) {
+	result, err := s.similaritySearch(ctx, query, numDocuments, false, options...)
+	return result.Documents, err
+}
+
+// SimilaritySearchVectors does the same as SimilaritySearch, also returning
+// the embedding of the query and the stored embeddings of the documents.
+func (s Store) SimilaritySearchVectors(
+	ctx context.Context,
+	query string,
+	numDocuments int,
+	options ...vectorstores.Option,
+) (vectorstores.VectorSearchResult, error) {
+	return s.similaritySearch(ctx, query, numDocuments, true, options...)
+}
+
+func (s Store) similaritySearch(
+	ctx context.Context,
+	query string,
+	numDocuments int,
+	withVector bool,
+	options ...vectorstores.Option,
+) (vectorstores.VectorSearchResult, error) {
 	opts := s.getOptions(options...)
 	nameSpace := s.getNameSpace(opts)
 	scoreThreshold, err := s.getScoreThreshold(opts)
 	if err != nil {
-		return nil, err
+		return vectorstores.VectorSearchResult{}, err
 	}
-	filter := s.getFilters(opts)
-	whereBuilder, err := s.createWhereBuilder(nameSpace, filter)
+	where := s.getFilters(opts)
+	whereBuilder, err := s.createWhereBuilder(nameSpace, where)
 	if err != nil {
-		return nil, err
+		return vectorstores.VectorSearchResult{}, err
 	}
 
 	vector, err := opts.Embedder.EmbedQuery(ctx, query)
 	if err != nil {
-		return nil, err
+		return vectorstores.VectorSearchResult{}, err
 	}
 
+	fields := s.createFields()
+	if withVector {
+		fields = s.createFields(_vectorField)
+	}
 	res, err := s.client.GraphQL().
 		Get().
 		WithNearVector(s.client.GraphQL().
@@ -181,16 +215,20 @@ func (s Store) SimilaritySearch(
 		WithWhere(whereBuilder).
 		WithClassName(s.indexName).
 		WithLimit(numDocuments).
-		WithFields(s.createFields()...).Do(ctx)
+		WithFields(fields...).Do(ctx)
 	if err != nil {
-		return nil, err
+		return vectorstores.VectorSearchResult{}, err
 	}
-	return s.parseDocumentsByGraphQLResponse(res)
+	docs, vectors, err := s.parseGraphQLResponse(res, withVector)
+	if err != nil {
+		return vectorstores.VectorSearchResult{}, err
+	}
+	return vectorstores.VectorSearchResult{QueryVector: vector, Documents: docs, Vectors: vectors}, nil
 }
 
 // MetadataSearch searches weaviate based on metadata rather than based on similarity.
-// Use `vectorstores.WithFilter(*filters.WhereBuilder)` to provide a where condition
-// as an option.
+// Use `vectorstores.WithFilters` with a `*filters.WhereBuilder` or a
+// `filter.Expr` to provide a where condition as an option.
 func (s Store) MetadataSearch(
 	ctx context.Context,
 	numDocuments int,
@@ -198,8 +236,8 @@ func (s Store) MetadataSearch(
 ) ([]schema.Document, error) {
 	opts := s.getOptions(options...)
 	nameSpace := s.getNameSpace(opts)
-	filter := s.getFilters(opts)
-	whereBuilder, err := s.createWhereBuilder(nameSpace, filter)
+	where := s.getFilters(opts)
+	whereBuilder, err := s.createWhereBuilder(nameSpace, where)
 	if err != nil {
 		return nil, err
 	}
@@ -217,37 +255,60 @@ func (s Store) MetadataSearch(
 	return s.parseDocumentsByGraphQLResponse(res)
 }
 
-//nolint:cyclop
 func (s Store) parseDocumentsByGraphQLResponse(res *models.GraphQLResponse) ([]schema.Document, error) {
+	docs, _, err := s.parseGraphQLResponse(res, false)
+	return docs, err
+}
+
+// parseGraphQLResponse parses the documents of a response and, if withVector
+// is set, the vectors returned in their additional fields.
+//
+//nolint:cyclop,funlen
+func (s Store) parseGraphQLResponse(
+	res *models.GraphQLResponse,
+	withVector bool,
+) ([]schema.Document, [][]float32, error) {
 	if len(res.Errors) > 0 {
 		messages := make([]string, 0, len(res.Errors))
 		for _, e := range res.Errors {
 			messages = append(messages, e.Message)
 		}
-		return nil, fmt.Errorf("%w: %s", ErrInvalidResponse, strings.Join(messages, ", "))
+		return nil, nil, fmt.Errorf("%w: %s", ErrInvalidResponse, strings.Join(messages, ", "))
 	}
 
 	data, ok := res.Data["Get"].(map[string]any)[s.indexName]
 	if !ok || data == nil {
-		return nil, ErrEmptyResponse
+		return nil, nil, ErrEmptyResponse
 	}
 	items, ok := data.([]any)
 	if !ok || len(items) == 0 {
-		return nil, ErrEmptyResponse
+		return nil, nil, ErrEmptyResponse
 	}
 	docs := make([]schema.Document, 0, len(items))
+	var vectors [][]float32
 	for _, item := range items {
 		itemMap, ok := item.(map[string]any)
 		if !ok {
-			return nil, ErrInvalidResponse
+			return nil, nil, ErrInvalidResponse
 		}
 		pageContent, ok := itemMap[s.textKey].(string)
 		if !ok {
-			return nil, ErrMissingTextKey
+			return nil, nil, ErrMissingTextKey
 		}
 		var score float64
+		var vector []float32
 		if additional, ok := itemMap["_additional"].(map[string]any); ok {
 			score, _ = additional["certainty"].(float64)
+			if withVector {
+				vector = parseVector(additional[_vectorField])
+				delete(additional, _vectorField)
+			}
+		}
+		if withVector {
+			if vector == nil {
+				return nil, nil, fmt.Errorf("%w: missing vector", ErrInvalidResponse)
+			}
+			vectors = append(vectors, vector)
 		}
 		delete(itemMap, s.textKey)
 		doc := schema.Document{
@@ -257,7 +318,25 @@ func (s Store) parseDocumentsByGraphQLResponse(res *models.GraphQLResponse) ([]s
 		}
 		docs = append(docs, doc)
 	}
-	return docs, nil
+	return docs, vectors, nil
+}
+
+// parseVector converts a vector decoded from JSON, or returns nil if v isn't
+// one.
+func parseVector(v any) []float32 {
+	values, ok := v.([]any)
+	if !ok {
+		return nil
+	}
+	vector := make([]float32, len(values))
+	for i, value := range values {
+		f, ok := value.(float64)
+		if !ok {
+			return nil
+		}
+		vector[i] = float32(f)
+	}
+	return vector
 }
 
 func (s Store) deduplicate(ctx context.Context,
@@ -311,22 +390,34 @@ func (s Store) getOptions(options ...vectorstores.Option) vectorstores.Options {
 	return opts
 }
 
-func (s Store) createWhereBuilder(namespace string, filter any) (*filters.WhereBuilder, error) {
-	if filter == nil {
-		return filters.Where().WithPath([]string{s.nameSpaceKey}).WithOperator(filters.Equal).WithValueString(namespace), nil
-	}
-
-	whereFilter, ok := filter.(*filters.WhereBuilder)
-	if !ok {
+// createWhereBuilder restricts the filters, which are either a
+// *filters.WhereBuilder or a filter.Expr, to the name space.
+func (s Store) createWhereBuilder(namespace string, where any) (*filters.WhereBuilder, error) {
+	nameSpaceFilter := filters.Where().WithPath([]string{s.nameSpaceKey}).WithOperator(filters.Equal).WithValueString(namespace)
+
+	var whereFilter *filters.WhereBuilder
+	switch f := where.(type) {
+	case nil:
+	case *filters.WhereBuilder:
+		whereFilter = f
+	case filter.Expr:
+		var err error
+		if whereFilter, err = whereFromExpr(f); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidFilter, err)
+		}
+	default:
 		return nil, ErrInvalidFilter
 	}
+	if whereFilter == nil {
+		return nameSpaceFilter, nil
+	}
 	return filters.Where().WithOperator(filters.And).WithOperands([]*filters.WhereBuilder{
-		filters.Where().WithPath([]string{s.nameSpaceKey}).WithOperator(filters.Equal).WithValueString(namespace),
+		nameSpaceFilter,
 		whereFilter,
 	}), nil
 }
 
-func (s Store) createFields() []graphql.Field {
+func (s Store) createFields(extraAdditionalFields ...string) []graphql.Field {
 	fields := make([]graphql.Field, 0, len(s.queryAttrs))
 	for _, attr := range s.queryAttrs {
 		fields = append(fields, graphql.Field{
@@ -334,8 +425,8 @@ func (s Store) createFields() []graphql.Field {
 		})
 	}
 
-	additionalFields := make([]graphql.Field, 0, len(s.additionalFields))
-	for _, attr := range s.additionalFields {
+	additionalFields := make([]graphql.Field, 0, len(s.additionalFields)+len(extraAdditionalFields))
+	for _, attr := range append(slices.Clone(s.additionalFields), extraAdditionalFields...) {
 		additionalFields = append(additionalFields, graphql.Field{
 			Name: attr,
 		})